@@ -26,6 +26,10 @@ import (
 const (
 	apiV1 = "v1"
 	apiV2 = "v2"
+
+	// scannerUploadBodyLimit limits the size of SQLs uploaded by scannerd, the body may be gzip
+	// compressed and the limit applies to the decompressed body.
+	scannerUploadBodyLimit = "64M"
 )
 
 type restApi struct {
@@ -222,6 +226,13 @@ func StartApi(net *gracenet.Net, exitChan chan struct{}, config *config.SqleOpti
 		v1ProjectRouter.POST("/:project_name/workflows/complete", DeprecatedBy(apiV2))
 		v1ProjectRouter.POST("/:project_name/workflows/:workflow_name/tasks/:task_id/execute", DeprecatedBy(apiV2))
 		v1ProjectRouter.POST("/:project_name/workflows/:workflow_id/tasks/:task_id/terminate", v1.TerminateSingleTaskByWorkflowV1)
+		v1ProjectRouter.POST("/:project_name/workflows/:workflow_id/tasks/:task_id/resume", v1.ResumeTaskOnWorkflowV1)
+		v1ProjectRouter.POST("/:project_name/workflows/:workflow_id/tasks/:task_id/confirm", v1.ConfirmTaskSQLOnWorkflowV1)
+		v1ProjectRouter.GET("/:project_name/workflows/:workflow_id/tasks/:task_id/rehearsal", v1.GetTaskRehearsalV1)
+		v1ProjectRouter.GET("/:project_name/workflows/:workflow_id/tasks/:task_id/online_ddl", v1.GetTaskOnlineDDLExecutionsV1)
+		v1ProjectRouter.GET("/:project_name/workflows/:workflow_id/tasks/:task_id/execution_progress", v1.StreamTaskExecutionProgressV1)
+		v1ProjectRouter.PATCH("/:project_name/workflows/:workflow_id/tasks/:task_id/sqls/:number/online_ddl", v1.UpdateOnlineDDLExecutionV1)
+		v1ProjectRouter.GET("/:project_name/workflows/:workflow_id/tasks/:task_id/sqls/:number/chunks", v1.GetTaskSQLChunksV1)
 		v1ProjectRouter.GET("/:project_name/workflows/:workflow_name/tasks", DeprecatedBy(apiV2))
 		v1ProjectRouter.POST("/:project_name/workflows/:workflow_name/tasks/execute", DeprecatedBy(apiV2))
 		v1ProjectRouter.POST("/:project_name/workflows/:workflow_id/tasks/terminate", v1.TerminateMultipleTaskByWorkflowV1)
@@ -238,6 +249,7 @@ func StartApi(net *gracenet.Net, exitChan chan struct{}, config *config.SqleOpti
 		v1ProjectRouter.GET("/:project_name/audit_plans/:audit_plan_name/reports", v1.GetAuditPlanReports)
 		v1ProjectRouter.GET("/:project_name/audit_plans/:audit_plan_name/reports/:audit_plan_report_id/", v1.GetAuditPlanReport)
 		v1ProjectRouter.GET("/:project_name/audit_plans/:audit_plan_name/sqls", v1.GetAuditPlanSQLs)
+		v1ProjectRouter.GET("/:project_name/audit_plans/:audit_plan_name/sql_metrics", v1.GetAuditPlanSQLMetrics)
 		v1ProjectRouter.POST("/:project_name/audit_plans/:audit_plan_name/trigger", v1.TriggerAuditPlan)
 		v1ProjectRouter.PATCH("/:project_name/audit_plans/:audit_plan_name/notify_config", v1.UpdateAuditPlanNotifyConfig)
 		v1ProjectRouter.GET("/:project_name/audit_plans/:audit_plan_name/notify_config", v1.GetAuditPlanNotifyConfig)
@@ -247,8 +259,8 @@ func StartApi(net *gracenet.Net, exitChan chan struct{}, config *config.SqleOpti
 		v1ProjectRouter.GET("/:project_name/audit_plans/:audit_plan_name/reports/:audit_plan_report_id/export", v1.ExportAuditPlanReportV1)
 
 		// scanner token auth
-		v1ProjectRouter.POST("/:project_name/audit_plans/:audit_plan_name/sqls/full", v1.FullSyncAuditPlanSQLs, sqleMiddleware.ScannerVerifier())
-		v1ProjectRouter.POST("/:project_name/audit_plans/:audit_plan_name/sqls/partial", v1.PartialSyncAuditPlanSQLs, sqleMiddleware.ScannerVerifier())
+		v1ProjectRouter.POST("/:project_name/audit_plans/:audit_plan_name/sqls/full", v1.FullSyncAuditPlanSQLs, sqleMiddleware.ScannerVerifier(), middleware.Decompress(), middleware.BodyLimit(scannerUploadBodyLimit))
+		v1ProjectRouter.POST("/:project_name/audit_plans/:audit_plan_name/sqls/partial", v1.PartialSyncAuditPlanSQLs, sqleMiddleware.ScannerVerifier(), middleware.Decompress(), middleware.BodyLimit(scannerUploadBodyLimit))
 
 		// sql manager
 		v1ProjectRouter.GET("/:project_name/sql_manages", v1.GetSqlManageList)
@@ -295,8 +307,8 @@ func StartApi(net *gracenet.Net, exitChan chan struct{}, config *config.SqleOpti
 		v2ProjectRouter.GET("/:project_name/sql_manages", v2.GetSqlManageList)
 
 		// scanner token auth
-		v2ProjectRouter.POST("/:project_name/audit_plans/:audit_plan_name/sqls/full", v2.FullSyncAuditPlanSQLs, sqleMiddleware.ScannerVerifier())
-		v2ProjectRouter.POST("/:project_name/audit_plans/:audit_plan_name/sqls/partial", v2.PartialSyncAuditPlanSQLs, sqleMiddleware.ScannerVerifier())
+		v2ProjectRouter.POST("/:project_name/audit_plans/:audit_plan_name/sqls/full", v2.FullSyncAuditPlanSQLs, sqleMiddleware.ScannerVerifier(), middleware.Decompress(), middleware.BodyLimit(scannerUploadBodyLimit))
+		v2ProjectRouter.POST("/:project_name/audit_plans/:audit_plan_name/sqls/partial", v2.PartialSyncAuditPlanSQLs, sqleMiddleware.ScannerVerifier(), middleware.Decompress(), middleware.BodyLimit(scannerUploadBodyLimit))
 
 	}
 
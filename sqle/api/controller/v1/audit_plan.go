@@ -116,7 +116,7 @@ func GetAuditPlanMetas(c echo.Context) error {
 type AuditPlanTypesV1 struct {
 	Type         string `json:"type"`
 	Desc         string `json:"desc"`
-	InstanceType string `json:"instance_type" enums:"MySQL,Oracle,TiDB,OceanBase For MySQL,"`
+	InstanceType string `json:"instance_type" enums:"MySQL,Oracle,TiDB,OceanBase For MySQL,PostgreSQL,"`
 }
 
 type GetAuditPlanTypesResV1 struct {
@@ -1413,6 +1413,106 @@ func GetAuditPlanSQLs(c echo.Context) error {
 	})
 }
 
+type GetAuditPlanSQLMetricsReqV1 struct {
+	Fingerprint     string `json:"fingerprint" query:"fingerprint" valid:"required"`
+	Schema          string `json:"schema" query:"schema"`
+	FilterStartTime string `json:"filter_start_time" query:"filter_start_time"`
+	FilterEndTime   string `json:"filter_end_time" query:"filter_end_time"`
+}
+
+type GetAuditPlanSQLMetricsResV1 struct {
+	controller.BaseRes
+	Data []AuditPlanSQLMetricResV1 `json:"data"`
+}
+
+type AuditPlanSQLMetricResV1 struct {
+	BucketTime   string  `json:"bucket_time" example:"2023-08-01T10:00:00+08:00"`
+	Count        int64   `json:"count" example:"10"`
+	QueryTimeAvg float64 `json:"query_time_avg" example:"0.5"`
+	QueryTimeMax float64 `json:"query_time_max" example:"1.2"`
+	RowsExamined int64   `json:"rows_examined" example:"1000"`
+}
+
+// @Summary 获取扫描任务指定SQL的指标趋势
+// @Description get the metrics trend of the SQL fingerprint of audit plan, the metrics are aggregated per hour
+// @Id getAuditPlanSQLMetricsV1
+// @Tags audit_plan
+// @Security ApiKeyAuth
+// @Param project_name path string true "project name"
+// @Param audit_plan_name path string true "audit plan name"
+// @Param fingerprint query string true "sql fingerprint"
+// @Param schema query string false "schema name"
+// @Param filter_start_time query string false "filter start time, RFC3339 format, default is 24 hours before the end time"
+// @Param filter_end_time query string false "filter end time, RFC3339 format, default is now"
+// @Success 200 {object} v1.GetAuditPlanSQLMetricsResV1
+// @router /v1/projects/{project_name}/audit_plans/{audit_plan_name}/sql_metrics [get]
+func GetAuditPlanSQLMetrics(c echo.Context) error {
+	req := new(GetAuditPlanSQLMetricsReqV1)
+	if err := controller.BindAndValidateReq(c, req); err != nil {
+		return err
+	}
+
+	end := time.Now()
+	if req.FilterEndTime != "" {
+		t, err := time.Parse(time.RFC3339, req.FilterEndTime)
+		if err != nil {
+			return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid, fmt.Errorf("parse filter end time failed: %v", err)))
+		}
+		end = t
+	}
+	start := end.Add(-24 * time.Hour)
+	if req.FilterStartTime != "" {
+		t, err := time.Parse(time.RFC3339, req.FilterStartTime)
+		if err != nil {
+			return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid, fmt.Errorf("parse filter start time failed: %v", err)))
+		}
+		start = t
+	}
+	if start.After(end) {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid, fmt.Errorf("filter start time should not be after filter end time")))
+	}
+
+	projectUid, err := dms.GetPorjectUIDByName(context.TODO(), c.Param("project_name"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	apName := c.Param("audit_plan_name")
+
+	ap, exist, err := GetAuditPlanIfCurrentUserCanAccess(c, projectUid, apName, v1.OpPermissionTypeViewOtherAuditPlan)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !exist {
+		return controller.JSONBaseErrorReq(c, errAuditPlanNotExist)
+	}
+
+	fingerprintMD5 := (&model.AuditPlanSQLV2{Fingerprint: req.Fingerprint, Schema: req.Schema}).GetFingerprintMD5()
+	s := model.GetStorage()
+	metrics, err := s.GetAuditPlanSQLMetrics(ap.ID, fingerprintMD5, start.Truncate(model.AuditPlanSQLMetricBucket), end)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	data := make([]AuditPlanSQLMetricResV1, 0, len(metrics))
+	for _, metric := range metrics {
+		var avg float64
+		if metric.Count > 0 {
+			avg = metric.QueryTimeTotal / float64(metric.Count)
+		}
+		data = append(data, AuditPlanSQLMetricResV1{
+			BucketTime:   metric.BucketAt.Format(time.RFC3339),
+			Count:        metric.Count,
+			QueryTimeAvg: avg,
+			QueryTimeMax: metric.QueryTimeMax,
+			RowsExamined: metric.RowsExamined,
+		})
+	}
+	return c.JSON(http.StatusOK, &GetAuditPlanSQLMetricsResV1{
+		BaseRes: controller.NewBaseReq(nil),
+		Data:    data,
+	})
+}
+
 type GetAuditPlanReportSQLsReqV1 struct {
 	PageIndex uint32 `json:"page_index" query:"page_index" valid:"required"`
 	PageSize  uint32 `json:"page_size" query:"page_size" valid:"required"`
@@ -167,9 +167,10 @@ func TestDingTalkConfigV1(c echo.Context) error {
 }
 
 type UpdateSystemVariablesReqV1 struct {
-	WorkflowExpiredHours        *int    `json:"workflow_expired_hours" form:"workflow_expired_hours" example:"720"`
-	Url                         *string `json:"url" form:"url" example:"http://10.186.61.32:8080" validate:"url"`
-	OperationRecordExpiredHours *int    `json:"operation_record_expired_hours" form:"operation_record_expired_hours" example:"2160"`
+	WorkflowExpiredHours           *int    `json:"workflow_expired_hours" form:"workflow_expired_hours" example:"720"`
+	Url                            *string `json:"url" form:"url" example:"http://10.186.61.32:8080" validate:"url"`
+	OperationRecordExpiredHours    *int    `json:"operation_record_expired_hours" form:"operation_record_expired_hours" example:"2160"`
+	AuditPlanSQLMetricExpiredHours *int    `json:"audit_plan_sql_metric_expired_hours" form:"audit_plan_sql_metric_expired_hours" example:"720" valid:"omitempty,min=1"`
 }
 
 // @Summary 修改系统变量
@@ -204,6 +205,13 @@ func UpdateSystemVariables(c echo.Context) error {
 		})
 	}
 
+	if req.AuditPlanSQLMetricExpiredHours != nil {
+		systemVariables = append(systemVariables, model.SystemVariable{
+			Key:   model.SystemVariableAuditPlanSQLMetricExpiredHours,
+			Value: strconv.Itoa(*req.AuditPlanSQLMetricExpiredHours),
+		})
+	}
+
 	if req.Url != nil {
 		systemVariables = append(systemVariables, model.SystemVariable{
 			Key:   model.SystemVariableSqleUrl,
@@ -224,9 +232,10 @@ type GetSystemVariablesResV1 struct {
 }
 
 type SystemVariablesResV1 struct {
-	WorkflowExpiredHours        int    `json:"workflow_expired_hours"`
-	Url                         string `json:"url"`
-	OperationRecordExpiredHours int    `json:"operation_record_expired_hours"`
+	WorkflowExpiredHours           int    `json:"workflow_expired_hours"`
+	Url                            string `json:"url"`
+	OperationRecordExpiredHours    int    `json:"operation_record_expired_hours"`
+	AuditPlanSQLMetricExpiredHours int    `json:"audit_plan_sql_metric_expired_hours"`
 }
 
 // @Summary 获取系统变量
@@ -253,12 +262,18 @@ func GetSystemVariables(c echo.Context) error {
 		return controller.JSONBaseErrorReq(c, err)
 	}
 
+	auditPlanSQLMetricExpiredHours, err := strconv.Atoi(systemVariables[model.SystemVariableAuditPlanSQLMetricExpiredHours].Value)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
 	return c.JSON(http.StatusOK, &GetSystemVariablesResV1{
 		BaseRes: controller.NewBaseReq(nil),
 		Data: SystemVariablesResV1{
-			WorkflowExpiredHours:        expiredHours,
-			Url:                         systemVariables[model.SystemVariableSqleUrl].Value,
-			OperationRecordExpiredHours: operationRecordExpiredHours,
+			WorkflowExpiredHours:           expiredHours,
+			Url:                            systemVariables[model.SystemVariableSqleUrl].Value,
+			OperationRecordExpiredHours:    operationRecordExpiredHours,
+			AuditPlanSQLMetricExpiredHours: auditPlanSQLMetricExpiredHours,
 		},
 	})
 }
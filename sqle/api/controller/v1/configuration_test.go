@@ -0,0 +1,19 @@
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/api/controller"
+	v1 "github.com/actiontech/sqle/sqle/api/controller/v1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSystemVariablesReqValidate(t *testing.T) {
+	hours := func(h int) *int { return &h }
+
+	assert.NoError(t, controller.Validate(&v1.UpdateSystemVariablesReqV1{}))
+	assert.NoError(t, controller.Validate(&v1.UpdateSystemVariablesReqV1{AuditPlanSQLMetricExpiredHours: hours(720)}))
+	assert.Error(t, controller.Validate(&v1.UpdateSystemVariablesReqV1{AuditPlanSQLMetricExpiredHours: hours(0)}))
+	assert.Error(t, controller.Validate(&v1.UpdateSystemVariablesReqV1{AuditPlanSQLMetricExpiredHours: hours(-1)}))
+}
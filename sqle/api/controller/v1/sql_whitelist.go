@@ -3,21 +3,46 @@ package v1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"net/http"
 
 	"github.com/actiontech/sqle/sqle/api/controller"
 	"github.com/actiontech/sqle/sqle/dms"
 	"github.com/actiontech/sqle/sqle/errors"
+	"github.com/actiontech/sqle/sqle/log"
 	"github.com/actiontech/sqle/sqle/model"
+	"github.com/actiontech/sqle/sqle/server"
 
 	"github.com/labstack/echo/v4"
 )
 
 type CreateAuditWhitelistReqV1 struct {
-	Value     string `json:"value" example:"create table" valid:"required"`
-	MatchType string `json:"match_type" example:"exact_match" enums:"exact_match,fp_match" valid:"omitempty,oneof=exact_match fp_match"`
-	Desc      string `json:"desc" example:"used for rapid release"`
+	Value          string     `json:"value" example:"create table" valid:"required"`
+	MatchType      string     `json:"match_type" example:"exact_match" enums:"exact_match,fp_match,regex_match,table_match" valid:"omitempty,oneof=exact_match fp_match regex_match table_match"`
+	Desc           string     `json:"desc" example:"used for rapid release"`
+	InstanceName   string     `json:"instance_name" example:"inst_1"`
+	InstanceSchema string     `json:"instance_schema" example:"db1"`
+	RuleNames      []string   `json:"rule_names" example:"ddl_check_index_count"`
+	ExpiredAt      *time.Time `json:"expired_at" example:"2024-01-01T00:00:00+08:00"`
+}
+
+// checkAuditWhitelistScope checks the instance scope and expiry time of whitelist.
+func checkAuditWhitelistScope(ctx context.Context, projectUid, instanceName string, expiredAt *time.Time) error {
+	if expiredAt != nil && !expiredAt.After(time.Now()) {
+		return errors.New(errors.DataInvalid, fmt.Errorf("expired time of sql audit whitelist should be later than now"))
+	}
+	if instanceName == "" {
+		return nil
+	}
+	_, exist, err := dms.GetInstanceInProjectByName(ctx, projectUid, instanceName)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.New(errors.DataNotExist, fmt.Errorf("instance %s is not exist", instanceName))
+	}
+	return nil
 }
 
 // @Summary 添加SQL白名单
@@ -39,14 +64,27 @@ func CreateAuditWhitelist(c echo.Context) error {
 	if err != nil {
 		return controller.JSONBaseErrorReq(c, err)
 	}
+	if err := checkAuditWhitelistScope(c.Request().Context(), projectUid, req.InstanceName, req.ExpiredAt); err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if err := server.CheckSqlWhitelistValue(req.MatchType, req.Value); err != nil {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid, err))
+	}
 	s := model.GetStorage()
 
 	sqlWhitelist := &model.SqlWhitelist{
-		ProjectId: model.ProjectUID(projectUid),
-		Value:     req.Value,
-		Desc:      req.Desc,
-		MatchType: req.MatchType,
+		ProjectId:      model.ProjectUID(projectUid),
+		Value:          req.Value,
+		Desc:           req.Desc,
+		MatchType:      req.MatchType,
+		InstanceName:   req.InstanceName,
+		InstanceSchema: req.InstanceSchema,
+		RuleNames:      req.RuleNames,
+		ExpiredAt:      req.ExpiredAt,
+		Status:         model.SQLWhitelistStatusEnabled,
+		CreateUserId:   controller.GetUserID(c),
 	}
+	server.GenSqlWhitelistFingerprints(log.NewEntry(), sqlWhitelist)
 
 	err = s.Save(sqlWhitelist)
 	if err != nil {
@@ -57,9 +95,14 @@ func CreateAuditWhitelist(c echo.Context) error {
 }
 
 type UpdateAuditWhitelistReqV1 struct {
-	Value     *string `json:"value" example:"create table"`
-	MatchType *string `json:"match_type" example:"exact_match" enums:"exact_match,fp_match"`
-	Desc      *string `json:"desc" example:"used for rapid release"`
+	Value          *string    `json:"value" example:"create table"`
+	MatchType      *string    `json:"match_type" example:"exact_match" enums:"exact_match,fp_match,regex_match,table_match" valid:"omitempty,oneof=exact_match fp_match regex_match table_match"`
+	Desc           *string    `json:"desc" example:"used for rapid release"`
+	InstanceName   *string    `json:"instance_name" example:"inst_1"`
+	InstanceSchema *string    `json:"instance_schema" example:"db1"`
+	RuleNames      *[]string  `json:"rule_names" example:"ddl_check_index_count"`
+	ExpiredAt      *time.Time `json:"expired_at" example:"2024-01-01T00:00:00+08:00"`
+	Status         *string    `json:"status" example:"enabled" enums:"enabled,disabled" valid:"omitempty,oneof=enabled disabled"`
 }
 
 // @Summary 更新SQL白名单
@@ -96,10 +139,19 @@ func UpdateAuditWhitelistById(c echo.Context) error {
 	}
 
 	// nothing to update
-	if req.Value == nil && req.Desc == nil && req.MatchType == nil {
+	if req.Value == nil && req.Desc == nil && req.MatchType == nil && req.InstanceName == nil &&
+		req.InstanceSchema == nil && req.RuleNames == nil && req.ExpiredAt == nil && req.Status == nil {
 		return c.JSON(http.StatusOK, controller.NewBaseReq(nil))
 	}
 
+	instanceName := ""
+	if req.InstanceName != nil {
+		instanceName = *req.InstanceName
+	}
+	if err := checkAuditWhitelistScope(c.Request().Context(), projectUid, instanceName, req.ExpiredAt); err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
 	if req.Value != nil {
 		sqlWhitelist.Value = *req.Value
 	}
@@ -109,6 +161,31 @@ func UpdateAuditWhitelistById(c echo.Context) error {
 	if req.Desc != nil {
 		sqlWhitelist.Desc = *req.Desc
 	}
+	if req.InstanceName != nil {
+		sqlWhitelist.InstanceName = *req.InstanceName
+	}
+	if req.InstanceSchema != nil {
+		sqlWhitelist.InstanceSchema = *req.InstanceSchema
+	}
+	if req.RuleNames != nil {
+		sqlWhitelist.RuleNames = *req.RuleNames
+	}
+	if req.ExpiredAt != nil {
+		sqlWhitelist.ExpiredAt = req.ExpiredAt
+	}
+	if req.Status != nil {
+		sqlWhitelist.Status = *req.Status
+	}
+	if req.Value != nil || req.MatchType != nil {
+		if err := server.CheckSqlWhitelistValue(sqlWhitelist.MatchType, sqlWhitelist.Value); err != nil {
+			return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid, err))
+		}
+		server.GenSqlWhitelistFingerprints(log.NewEntry(), sqlWhitelist)
+	}
+	if !sqlWhitelist.IsEffective(time.Now()) && sqlWhitelist.Status == model.SQLWhitelistStatusEnabled {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid,
+			fmt.Errorf("sql audit whitelist is expired, please update the expired time before enable it")))
+	}
 
 	err = s.Save(sqlWhitelist)
 	if err != nil {
@@ -164,10 +241,16 @@ type GetAuditWhitelistResV1 struct {
 }
 
 type AuditWhitelistResV1 struct {
-	Id        uint   `json:"audit_whitelist_id"`
-	Value     string `json:"value"`
-	MatchType string `json:"match_type"`
-	Desc      string `json:"desc"`
+	Id             uint       `json:"audit_whitelist_id"`
+	Value          string     `json:"value"`
+	MatchType      string     `json:"match_type"`
+	Desc           string     `json:"desc"`
+	InstanceName   string     `json:"instance_name"`
+	InstanceSchema string     `json:"instance_schema"`
+	RuleNames      []string   `json:"rule_names"`
+	ExpiredAt      *time.Time `json:"expired_at"`
+	Status         string     `json:"status" enums:"enabled,disabled"`
+	CreateUser     string     `json:"create_user"`
 }
 
 // @Summary 获取Sql审核白名单
@@ -195,13 +278,27 @@ func GetSqlWhitelist(c echo.Context) error {
 	if err != nil {
 		return controller.JSONBaseErrorReq(c, err)
 	}
+	userNames := map[string]string{}
 	whitelistRes := make([]*AuditWhitelistResV1, 0, len(sqlWhitelist))
 	for _, v := range sqlWhitelist {
+		if _, ok := userNames[v.CreateUserId]; !ok {
+			userNames[v.CreateUserId] = dms.GetUserNameWithDelTag(v.CreateUserId)
+		}
+		status := v.Status
+		if status == "" {
+			status = model.SQLWhitelistStatusEnabled
+		}
 		whitelistRes = append(whitelistRes, &AuditWhitelistResV1{
-			Id:        v.ID,
-			Value:     v.Value,
-			Desc:      v.Desc,
-			MatchType: v.MatchType,
+			Id:             v.ID,
+			Value:          v.Value,
+			Desc:           v.Desc,
+			MatchType:      v.MatchType,
+			InstanceName:   v.InstanceName,
+			InstanceSchema: v.InstanceSchema,
+			RuleNames:      v.RuleNames,
+			ExpiredAt:      v.ExpiredAt,
+			Status:         status,
+			CreateUser:     userNames[v.CreateUserId],
 		})
 	}
 	return c.JSON(http.StatusOK, &GetAuditWhitelistResV1{
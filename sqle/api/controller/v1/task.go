@@ -49,6 +49,10 @@ type AuditTaskResV1 struct {
 	SQLSource      string     `json:"sql_source" enums:"form_data,sql_file,mybatis_xml_file,audit_plan"`
 	ExecStartTime  *time.Time `json:"exec_start_time,omitempty"`
 	ExecEndTime    *time.Time `json:"exec_end_time,omitempty"`
+	// the status of generating rollback SQLs by binlog after execution, it is empty if they are not generated by binlog
+	BinlogRollbackStatus string `json:"binlog_rollback_status,omitempty" enums:"generating,succeeded,failed"`
+	// the status of rolling back the task automatically after its execution fails or is terminated
+	AutoRollbackStatus string `json:"auto_rollback_status,omitempty" enums:"rolling_back,succeeded,failed"`
 }
 
 func convertTaskToRes(task *model.Task) *AuditTaskResV1 {
@@ -64,6 +68,9 @@ func convertTaskToRes(task *model.Task) *AuditTaskResV1 {
 		SQLSource:      task.SQLSource,
 		ExecStartTime:  task.ExecStartAt,
 		ExecEndTime:    task.ExecEndAt,
+
+		BinlogRollbackStatus: task.BinlogRollbackStatus,
+		AutoRollbackStatus:   task.AutoRollbackStatus,
 	}
 }
 
@@ -283,15 +290,21 @@ type GetAuditTaskSQLsResV1 struct {
 }
 
 type AuditTaskSQLResV1 struct {
-	Number      uint   `json:"number"`
-	ExecSQL     string `json:"exec_sql"`
-	AuditResult string `json:"audit_result"`
-	AuditLevel  string `json:"audit_level"`
-	AuditStatus string `json:"audit_status"`
-	ExecResult  string `json:"exec_result"`
-	ExecStatus  string `json:"exec_status"`
-	RollbackSQL string `json:"rollback_sql,omitempty"`
-	Description string `json:"description"`
+	Number         uint                  `json:"number"`
+	ExecSQL        string                `json:"exec_sql"`
+	AuditResult    string                `json:"audit_result"`
+	AuditLevel     string                `json:"audit_level"`
+	AuditStatus    string                `json:"audit_status"`
+	ExecResult     string                `json:"exec_result"`
+	ExecStatus     string                `json:"exec_status"`
+	RollbackSQL    string                `json:"rollback_sql,omitempty"`
+	Description    string                `json:"description"`
+	FixSuggestions []*FixSuggestionResV1 `json:"fix_suggestions,omitempty"`
+}
+
+type FixSuggestionResV1 struct {
+	Description  string `json:"description"`
+	RewrittenSQL string `json:"rewritten_sql"`
 }
 
 // @Summary 获取指定扫描任务的SQLs信息
@@ -357,6 +370,12 @@ func GetTaskSQLs(c echo.Context) error {
 			ExecStatus:  taskSQL.ExecStatus,
 			RollbackSQL: taskSQL.RollbackSQL.String,
 		}
+		for _, fix := range taskSQL.AuditResults.FixSuggestions() {
+			taskSQLRes.FixSuggestions = append(taskSQLRes.FixSuggestions, &FixSuggestionResV1{
+				Description:  fix.Description,
+				RewrittenSQL: fix.RewrittenSQL,
+			})
+		}
 		taskSQLsRes = append(taskSQLsRes, taskSQLRes)
 	}
 
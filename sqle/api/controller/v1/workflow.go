@@ -3,6 +3,7 @@ package v1
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	e "errors"
 	"fmt"
 	"net/http"
@@ -46,6 +47,9 @@ type WorkflowTemplateDetailResV1 struct {
 	Name                          string                       `json:"workflow_template_name"`
 	Desc                          string                       `json:"desc,omitempty"`
 	AllowSubmitWhenLessAuditLevel string                       `json:"allow_submit_when_less_audit_level" enums:"normal,notice,warn,error"`
+	RehearsalInstanceName         string                       `json:"rehearsal_instance_name,omitempty"`
+	TaskExecutionTimeoutSeconds   uint                         `json:"task_execution_timeout_seconds"`
+	EnableAutoRollback            bool                         `json:"enable_auto_rollback"`
 	Steps                         []*WorkFlowStepTemplateResV1 `json:"workflow_step_template_list"`
 	UpdateTime                    time.Time                    `json:"update_time"`
 }
@@ -114,6 +118,9 @@ func convertWorkflowTemplateToRes(template *model.WorkflowTemplate) *WorkflowTem
 		Name:                          template.Name,
 		Desc:                          template.Desc,
 		AllowSubmitWhenLessAuditLevel: template.AllowSubmitWhenLessAuditLevel,
+		RehearsalInstanceName:         template.RehearsalInstanceName,
+		TaskExecutionTimeoutSeconds:   template.TaskExecutionTimeoutSeconds,
+		EnableAutoRollback:            template.EnableAutoRollback,
 		UpdateTime:                    template.UpdatedAt,
 	}
 	stepsRes := make([]*WorkFlowStepTemplateResV1, 0, len(template.Steps))
@@ -176,9 +183,14 @@ func validWorkflowTemplateReq(steps []*WorkFlowStepTemplateReqV1) error {
 }
 
 type UpdateWorkflowTemplateReqV1 struct {
-	Desc                          *string                      `json:"desc" form:"desc"`
-	AllowSubmitWhenLessAuditLevel *string                      `json:"allow_submit_when_less_audit_level" enums:"normal,notice,warn,error"`
-	Steps                         []*WorkFlowStepTemplateReqV1 `json:"workflow_step_template_list" form:"workflow_step_template_list"`
+	Desc                          *string `json:"desc" form:"desc"`
+	AllowSubmitWhenLessAuditLevel *string `json:"allow_submit_when_less_audit_level" enums:"normal,notice,warn,error"`
+	RehearsalInstanceName         *string `json:"rehearsal_instance_name" form:"rehearsal_instance_name"`
+	// the task is terminated if its execution exceeds the timeout, 0 means no timeout
+	TaskExecutionTimeoutSeconds *uint `json:"task_execution_timeout_seconds" form:"task_execution_timeout_seconds"`
+	// roll back the task automatically when its execution fails or is terminated
+	EnableAutoRollback *bool                        `json:"enable_auto_rollback" form:"enable_auto_rollback"`
+	Steps              []*WorkFlowStepTemplateReqV1 `json:"workflow_step_template_list" form:"workflow_step_template_list"`
 }
 
 // @Summary 更新Sql审批流程模板
@@ -254,6 +266,27 @@ func UpdateWorkflowTemplate(c echo.Context) error {
 		workflowTemplate.AllowSubmitWhenLessAuditLevel = *req.AllowSubmitWhenLessAuditLevel
 	}
 
+	if req.RehearsalInstanceName != nil {
+		if *req.RehearsalInstanceName != "" {
+			_, exist, err := dms.GetInstanceInProjectByName(c.Request().Context(), projectUid, *req.RehearsalInstanceName)
+			if err != nil {
+				return controller.JSONBaseErrorReq(c, err)
+			}
+			if !exist {
+				return controller.JSONBaseErrorReq(c, ErrInstanceNotExist)
+			}
+		}
+		workflowTemplate.RehearsalInstanceName = *req.RehearsalInstanceName
+	}
+
+	if req.TaskExecutionTimeoutSeconds != nil {
+		workflowTemplate.TaskExecutionTimeoutSeconds = *req.TaskExecutionTimeoutSeconds
+	}
+
+	if req.EnableAutoRollback != nil {
+		workflowTemplate.EnableAutoRollback = *req.EnableAutoRollback
+	}
+
 	err = s.Save(workflowTemplate)
 	if err != nil {
 		return controller.JSONBaseErrorReq(c, err)
@@ -1160,3 +1193,614 @@ func getTerminatingTaskIDs(workflow *model.Workflow) (taskIDs []uint) {
 	}
 	return taskIDs
 }
+
+type ResumeTaskReqV1 struct {
+	// the failed SQL is executed again by retry and edit, and is not executed by skip
+	Operation string `json:"operation" form:"operation" valid:"required,oneof=retry edit skip" enums:"retry,edit,skip"`
+	// the SQL to replace the failed SQL, it is required by edit
+	SQL    string `json:"sql" form:"sql"`
+	Reason string `json:"reason" form:"reason"`
+}
+
+// ResumeTaskOnWorkflowV1
+// @Summary 恢复上线失败的任务
+// @Description resume the failed task from the failed SQL, the SQLs executed successfully are not executed again
+// @Tags workflow
+// @Id resumeTaskOnWorkflowV1
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param workflow_id path string true "workflow id"
+// @Param project_name path string true "project name"
+// @Param task_id path string true "task id"
+// @Param instance body v1.ResumeTaskReqV1 true "resume task request"
+// @Success 200 {object} controller.BaseRes
+// @router /v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/resume [post]
+func ResumeTaskOnWorkflowV1(c echo.Context) error {
+	req := new(ResumeTaskReqV1)
+	if err := controller.BindAndValidateReq(c, req); err != nil {
+		return err
+	}
+	if req.Operation == model.TaskResumeOperationEdit && strings.TrimSpace(req.SQL) == "" {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid, fmt.Errorf("sql is required to edit the failed SQL")))
+	}
+	if req.Operation != model.TaskResumeOperationRetry && strings.TrimSpace(req.Reason) == "" {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid, fmt.Errorf("reason is required to %s the failed SQL", req.Operation)))
+	}
+
+	projectUid, err := dms.GetPorjectUIDByName(context.TODO(), c.Param("project_name"), true)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	taskId, err := FormatStringToInt(c.Param("task_id"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	s := model.GetStorage()
+	workflow, err := dms.GetWorkflowDetailByWorkflowId(projectUid, c.Param("workflow_id"), s.GetWorkflowDetailWithoutInstancesByWorkflowID)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	user, err := controller.GetCurrentUser(c, dms.GetUser)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if err := checkUserIsTaskExecutor(c, projectUid, workflow, user, uint(taskId)); err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	err = server.ResumeWorkflowTask(workflow, uint(taskId), user.GetIDStr(), req.Operation, req.SQL, req.Reason)
+	return c.JSON(http.StatusOK, controller.NewBaseReq(err))
+}
+
+// checkUserIsTaskExecutor checks that the user is one of the executors of the task.
+func checkUserIsTaskExecutor(c echo.Context, projectId string, workflow *model.Workflow, user *model.User, taskId uint) error {
+	err := CheckCurrentUserCanOperateTasks(c, projectId, workflow, []dmsV1.OpPermissionType{dmsV1.OpPermissionTypeExecuteWorkflow}, []uint{taskId})
+	if err != nil {
+		return err
+	}
+	for _, record := range workflow.Record.InstanceRecords {
+		if record.TaskId != taskId {
+			continue
+		}
+		for _, u := range strings.Split(record.ExecutionAssignees, ",") {
+			if u == user.GetIDStr() {
+				return nil
+			}
+		}
+	}
+	return e.New("you are not the executor of the task")
+}
+
+// ConfirmTaskSQLOnWorkflowV1
+// @Summary 确认执行被阻塞的 DDL
+// @Description confirm to execute the DDL which is paused because it is blocked by other sessions, terminate the task to cancel it
+// @Tags workflow
+// @Id confirmTaskSQLOnWorkflowV1
+// @Security ApiKeyAuth
+// @Produce json
+// @Param workflow_id path string true "workflow id"
+// @Param project_name path string true "project name"
+// @Param task_id path string true "task id"
+// @Success 200 {object} controller.BaseRes
+// @router /v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/confirm [post]
+func ConfirmTaskSQLOnWorkflowV1(c echo.Context) error {
+	projectUid, err := dms.GetPorjectUIDByName(context.TODO(), c.Param("project_name"), true)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	taskId, err := FormatStringToInt(c.Param("task_id"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	s := model.GetStorage()
+	workflow, err := dms.GetWorkflowDetailByWorkflowId(projectUid, c.Param("workflow_id"), s.GetWorkflowDetailWithoutInstancesByWorkflowID)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	user, err := controller.GetCurrentUser(c, dms.GetUser)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if err := checkUserIsTaskExecutor(c, projectUid, workflow, user, uint(taskId)); err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	confirmed, err := s.ConfirmExecuteSQLOfTask(uint(taskId))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !confirmed {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid,
+			fmt.Errorf("there is no SQL waiting for confirmation in task %d", taskId)))
+	}
+	return c.JSON(http.StatusOK, controller.NewBaseReq(nil))
+}
+
+type GetTaskRehearsalResV1 struct {
+	controller.BaseRes
+	Data *TaskRehearsalResV1 `json:"data"`
+}
+
+type TaskRehearsalResV1 struct {
+	InstanceName string               `json:"instance_name"`
+	Status       string               `json:"status" enums:"running,succeeded,failed"`
+	ErrorMessage string               `json:"error_message,omitempty"`
+	StartAt      *time.Time           `json:"start_at,omitempty"`
+	EndAt        *time.Time           `json:"end_at,omitempty"`
+	SQLs         []*RehearsalSQLResV1 `json:"sql_list"`
+}
+
+type RehearsalSQLResV1 struct {
+	Number         uint   `json:"number"`
+	SQL            string `json:"sql"`
+	ExecStatus     string `json:"exec_status" enums:"initialized,succeeded,failed"`
+	ExecResult     string `json:"exec_result"`
+	RowAffects     int64  `json:"row_affects"`
+	ExecDurationMs int64  `json:"exec_duration_ms"`
+}
+
+// GetTaskRehearsalV1
+// @Summary 获取工单数据源任务在沙箱实例上的预演报告
+// @Description get the rehearsal report of task on the sandbox instance
+// @Tags workflow
+// @Id getTaskRehearsalV1
+// @Security ApiKeyAuth
+// @Param project_name path string true "project name"
+// @Param workflow_id path string true "workflow id"
+// @Param task_id path string true "task id"
+// @Success 200 {object} v1.GetTaskRehearsalResV1
+// @router /v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/rehearsal [get]
+func GetTaskRehearsalV1(c echo.Context) error {
+	projectUid, err := dms.GetPorjectUIDByName(context.TODO(), c.Param("project_name"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	taskId, err := FormatStringToUint64(c.Param("task_id"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	s := model.GetStorage()
+	workflow, err := dms.GetWorkflowDetailByWorkflowId(projectUid, c.Param("workflow_id"), s.GetWorkflowDetailWithoutInstancesByWorkflowID)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	err = CheckCurrentUserCanOperateWorkflow(c, projectUid, workflow, []dmsV1.OpPermissionType{dmsV1.OpPermissionTypeViewOthersWorkflow})
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	inWorkflow := false
+	for _, record := range workflow.Record.InstanceRecords {
+		if uint64(record.TaskId) == taskId {
+			inWorkflow = true
+		}
+	}
+	if !inWorkflow {
+		return controller.JSONBaseErrorReq(c, errors.NewTaskNoExistOrNoAccessErr())
+	}
+
+	rehearsal, exist, err := s.GetTaskRehearsalByTaskId(uint(taskId))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !exist {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataNotExist, fmt.Errorf("the task has not been rehearsed")))
+	}
+
+	res := &TaskRehearsalResV1{
+		InstanceName: rehearsal.InstanceName,
+		Status:       rehearsal.Status,
+		ErrorMessage: rehearsal.ErrorMessage,
+		StartAt:      rehearsal.StartAt,
+		EndAt:        rehearsal.EndAt,
+		SQLs:         make([]*RehearsalSQLResV1, 0, len(rehearsal.SQLs)),
+	}
+	for _, sql := range rehearsal.SQLs {
+		res.SQLs = append(res.SQLs, &RehearsalSQLResV1{
+			Number:         sql.Number,
+			SQL:            sql.Content,
+			ExecStatus:     sql.ExecStatus,
+			ExecResult:     sql.ExecResult,
+			RowAffects:     sql.RowAffects,
+			ExecDurationMs: sql.ExecDurationMs,
+		})
+	}
+	return c.JSON(http.StatusOK, &GetTaskRehearsalResV1{
+		BaseRes: controller.NewBaseReq(nil),
+		Data:    res,
+	})
+}
+
+type GetTaskOnlineDDLExecutionsResV1 struct {
+	controller.BaseRes
+	Data []*OnlineDDLExecutionResV1 `json:"data"`
+}
+
+type OnlineDDLExecutionResV1 struct {
+	Number         uint     `json:"number"`
+	Running        bool     `json:"running"`
+	Paused         bool     `json:"paused"`
+	NiceRatio      *float64 `json:"nice_ratio"`
+	MaxLagMillis   int64    `json:"max_lag_millis"`
+	ChunkSize      int64    `json:"chunk_size"`
+	RowsCopied     int64    `json:"rows_copied"`
+	RowsEstimate   int64    `json:"rows_estimate"`
+	ProgressPct    float64  `json:"progress_pct"`
+	ETASeconds     int64    `json:"eta_seconds"`
+	Throttled      bool     `json:"throttled"`
+	ThrottleReason string   `json:"throttle_reason"`
+}
+
+// GetTaskOnlineDDLExecutionsV1
+// @Summary 获取工单数据源任务中 online DDL 的执行进度
+// @Description get the progress and control of the online DDL executed by the SQLs of task
+// @Tags workflow
+// @Id getTaskOnlineDDLExecutionsV1
+// @Security ApiKeyAuth
+// @Param project_name path string true "project name"
+// @Param workflow_id path string true "workflow id"
+// @Param task_id path string true "task id"
+// @Success 200 {object} v1.GetTaskOnlineDDLExecutionsResV1
+// @router /v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/online_ddl [get]
+func GetTaskOnlineDDLExecutionsV1(c echo.Context) error {
+	projectUid, err := dms.GetPorjectUIDByName(context.TODO(), c.Param("project_name"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	taskId, err := FormatStringToUint64(c.Param("task_id"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	s := model.GetStorage()
+	workflow, err := dms.GetWorkflowDetailByWorkflowId(projectUid, c.Param("workflow_id"), s.GetWorkflowDetailWithoutInstancesByWorkflowID)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	err = CheckCurrentUserCanOperateWorkflow(c, projectUid, workflow, []dmsV1.OpPermissionType{dmsV1.OpPermissionTypeViewOthersWorkflow})
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !isTaskInWorkflow(workflow, uint(taskId)) {
+		return controller.JSONBaseErrorReq(c, errors.NewTaskNoExistOrNoAccessErr())
+	}
+
+	executions, err := s.GetOnlineDDLExecutionsByTaskId(uint(taskId))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	data := make([]*OnlineDDLExecutionResV1, 0, len(executions))
+	for _, e := range executions {
+		data = append(data, convertOnlineDDLExecutionToRes(e))
+	}
+	return c.JSON(http.StatusOK, &GetTaskOnlineDDLExecutionsResV1{
+		BaseRes: controller.NewBaseReq(nil),
+		Data:    data,
+	})
+}
+
+func convertOnlineDDLExecutionToRes(e *model.OnlineDDLExecution) *OnlineDDLExecutionResV1 {
+	return &OnlineDDLExecutionResV1{
+		Number:         e.Number,
+		Running:        e.Running,
+		Paused:         e.Paused,
+		NiceRatio:      e.NiceRatio,
+		MaxLagMillis:   e.MaxLagMillis,
+		ChunkSize:      e.ChunkSize,
+		RowsCopied:     e.RowsCopied,
+		RowsEstimate:   e.RowsEstimate,
+		ProgressPct:    e.ProgressPct,
+		ETASeconds:     e.ETASeconds,
+		Throttled:      e.Throttled,
+		ThrottleReason: e.ThrottleReason,
+	}
+}
+
+type UpdateOnlineDDLExecutionReqV1 struct {
+	// pause or resume the row copy of online DDL, the binlog is still applied while paused
+	Paused       *bool    `json:"paused" form:"paused"`
+	NiceRatio    *float64 `json:"nice_ratio" form:"nice_ratio" valid:"omitempty,min=0"`
+	MaxLagMillis *int64   `json:"max_lag_millis" form:"max_lag_millis" valid:"omitempty,min=100"`
+	ChunkSize    *int64   `json:"chunk_size" form:"chunk_size" valid:"omitempty,min=10,max=100000"`
+}
+
+// UpdateOnlineDDLExecutionV1
+// @Summary 暂停、恢复或调整正在执行的 online DDL
+// @Description pause, resume or throttle the online DDL executed by the SQL of task
+// @Tags workflow
+// @Id updateOnlineDDLExecutionV1
+// @Accept json
+// @Security ApiKeyAuth
+// @Param project_name path string true "project name"
+// @Param workflow_id path string true "workflow id"
+// @Param task_id path string true "task id"
+// @Param number path string true "sql number"
+// @Param instance body v1.UpdateOnlineDDLExecutionReqV1 true "control of online DDL"
+// @Success 200 {object} controller.BaseRes
+// @router /v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/sqls/{number}/online_ddl [patch]
+func UpdateOnlineDDLExecutionV1(c echo.Context) error {
+	req := new(UpdateOnlineDDLExecutionReqV1)
+	if err := controller.BindAndValidateReq(c, req); err != nil {
+		return err
+	}
+	projectUid, err := dms.GetPorjectUIDByName(context.TODO(), c.Param("project_name"), true)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	taskId, err := FormatStringToUint64(c.Param("task_id"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	s := model.GetStorage()
+	workflow, err := dms.GetWorkflowDetailByWorkflowId(projectUid, c.Param("workflow_id"), s.GetWorkflowDetailWithoutInstancesByWorkflowID)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !isTaskInWorkflow(workflow, uint(taskId)) {
+		return controller.JSONBaseErrorReq(c, errors.NewTaskNoExistOrNoAccessErr())
+	}
+	err = CheckCurrentUserCanOperateTasks(c, projectUid, workflow, []dmsV1.OpPermissionType{dmsV1.OpPermissionTypeExecuteWorkflow}, []uint{uint(taskId)})
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	executeSQL, exist, err := s.GetTaskSQLByNumber(c.Param("task_id"), c.Param("number"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !exist {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataNotExist, fmt.Errorf("sql number not found")))
+	}
+	if executeSQL.ExecStatus != model.SQLExecuteStatusDoing {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid, fmt.Errorf("the sql is not executing")))
+	}
+
+	execution, exist, err := s.GetOnlineDDLExecutionByExecuteSQLId(executeSQL.ID)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !exist || !execution.Running {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataInvalid, fmt.Errorf("the sql is not executed by online DDL")))
+	}
+	if req.Paused != nil {
+		execution.Paused = *req.Paused
+	}
+	if req.NiceRatio != nil {
+		execution.NiceRatio = req.NiceRatio
+	}
+	if req.MaxLagMillis != nil {
+		execution.MaxLagMillis = *req.MaxLagMillis
+	}
+	if req.ChunkSize != nil {
+		execution.ChunkSize = *req.ChunkSize
+	}
+	return controller.JSONBaseErrorReq(c, s.SaveOnlineDDLControl(execution))
+}
+
+type GetTaskSQLChunksResV1 struct {
+	controller.BaseRes
+	Data []*TaskSQLChunkResV1 `json:"data"`
+}
+
+type TaskSQLChunkResV1 struct {
+	Number              uint   `json:"number"`
+	SQL                 string `json:"sql"`
+	LowerBound          string `json:"lower_bound"`
+	UpperBound          string `json:"upper_bound"`
+	ExecStatus          string `json:"exec_status" enums:"initialized,doing,succeeded,failed,terminating,terminate_succeeded,terminate_failed,waiting_for_confirm"`
+	ExecResult          string `json:"exec_result"`
+	RowAffects          int64  `json:"row_affects"`
+	RollbackSQL         string `json:"rollback_sql"`
+	RollbackDescription string `json:"rollback_description"`
+}
+
+// GetTaskSQLChunksV1
+// @Summary 获取工单数据源任务中分批执行的 DML 的批次
+// @Description get the chunks of the DML executed in chunks by the SQL of task
+// @Tags workflow
+// @Id getTaskSQLChunksV1
+// @Security ApiKeyAuth
+// @Param project_name path string true "project name"
+// @Param workflow_id path string true "workflow id"
+// @Param task_id path string true "task id"
+// @Param number path string true "sql number"
+// @Success 200 {object} v1.GetTaskSQLChunksResV1
+// @router /v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/sqls/{number}/chunks [get]
+func GetTaskSQLChunksV1(c echo.Context) error {
+	projectUid, err := dms.GetPorjectUIDByName(context.TODO(), c.Param("project_name"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	taskId, err := FormatStringToUint64(c.Param("task_id"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	s := model.GetStorage()
+	workflow, err := dms.GetWorkflowDetailByWorkflowId(projectUid, c.Param("workflow_id"), s.GetWorkflowDetailWithoutInstancesByWorkflowID)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	err = CheckCurrentUserCanOperateWorkflow(c, projectUid, workflow, []dmsV1.OpPermissionType{dmsV1.OpPermissionTypeViewOthersWorkflow})
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !isTaskInWorkflow(workflow, uint(taskId)) {
+		return controller.JSONBaseErrorReq(c, errors.NewTaskNoExistOrNoAccessErr())
+	}
+
+	executeSQL, exist, err := s.GetTaskSQLByNumber(c.Param("task_id"), c.Param("number"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !exist {
+		return controller.JSONBaseErrorReq(c, errors.New(errors.DataNotExist, fmt.Errorf("sql number not found")))
+	}
+	chunks, err := s.GetExecuteSQLChunksByExecuteSQLId(executeSQL.ID)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	data := make([]*TaskSQLChunkResV1, 0, len(chunks))
+	for _, chunk := range chunks {
+		data = append(data, &TaskSQLChunkResV1{
+			Number:              chunk.Number,
+			SQL:                 chunk.Content,
+			LowerBound:          chunk.LowerBound,
+			UpperBound:          chunk.UpperBound,
+			ExecStatus:          chunk.ExecStatus,
+			ExecResult:          chunk.ExecResult,
+			RowAffects:          chunk.RowAffects,
+			RollbackSQL:         chunk.RollbackSQL,
+			RollbackDescription: chunk.RollbackDescription,
+		})
+	}
+	return c.JSON(http.StatusOK, &GetTaskSQLChunksResV1{
+		BaseRes: controller.NewBaseReq(nil),
+		Data:    data,
+	})
+}
+
+func isTaskInWorkflow(workflow *model.Workflow, taskId uint) bool {
+	for _, record := range workflow.Record.InstanceRecords {
+		if record.TaskId == taskId {
+			return true
+		}
+	}
+	return false
+}
+
+type TaskExecutionProgressResV1 struct {
+	TaskStatus string                       `json:"task_status" enums:"initialized,audited,executing,exec_succeeded,exec_failed,manually_executed,terminating,terminate_succeeded,terminate_failed"`
+	SQLs       []*SQLExecutionProgressResV1 `json:"sql_list"`
+}
+
+type SQLExecutionProgressResV1 struct {
+	Number         uint                     `json:"number"`
+	SQL            string                   `json:"sql"`
+	ElapsedSeconds int64                    `json:"elapsed_seconds"`
+	State          string                   `json:"state"`
+	RowsExamined   int64                    `json:"rows_examined"`
+	RowsAffected   int64                    `json:"rows_affected"`
+	OnlineDDL      *OnlineDDLExecutionResV1 `json:"online_ddl,omitempty"`
+}
+
+// StreamTaskExecutionProgressV1
+// @Summary 以 SSE 推送工单数据源任务正在执行的 SQL 的进度
+// @Description stream the progress of the executing SQLs of task by server-sent events, the event "progress" is sent every second until the task is not executing
+// @Tags workflow
+// @Id streamTaskExecutionProgressV1
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param project_name path string true "project name"
+// @Param workflow_id path string true "workflow id"
+// @Param task_id path string true "task id"
+// @Success 200 {object} v1.TaskExecutionProgressResV1
+// @router /v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/execution_progress [get]
+func StreamTaskExecutionProgressV1(c echo.Context) error {
+	projectUid, err := dms.GetPorjectUIDByName(context.TODO(), c.Param("project_name"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	taskId, err := FormatStringToUint64(c.Param("task_id"))
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+
+	s := model.GetStorage()
+	workflow, err := dms.GetWorkflowDetailByWorkflowId(projectUid, c.Param("workflow_id"), s.GetWorkflowDetailWithoutInstancesByWorkflowID)
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	err = CheckCurrentUserCanOperateWorkflow(c, projectUid, workflow, []dmsV1.OpPermissionType{dmsV1.OpPermissionTypeViewOthersWorkflow})
+	if err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
+	if !isTaskInWorkflow(workflow, uint(taskId)) {
+		return controller.JSONBaseErrorReq(c, errors.NewTaskNoExistOrNoAccessErr())
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		progress, err := getTaskExecutionProgress(s, uint(taskId))
+		if err != nil {
+			fmt.Fprintf(res, "event: error\ndata: %s\n\n", err.Error())
+			res.Flush()
+			return nil
+		}
+		data, err := json.Marshal(progress)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(res, "event: progress\ndata: %s\n\n", data)
+		res.Flush()
+		if progress.TaskStatus != model.TaskStatusExecuting && progress.TaskStatus != model.TaskStatusTerminating {
+			return nil
+		}
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func getTaskExecutionProgress(s *model.Storage, taskId uint) (*TaskExecutionProgressResV1, error) {
+	task, exist, err := s.GetTaskById(strconv.Itoa(int(taskId)))
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NewTaskNoExistOrNoAccessErr()
+	}
+	res := &TaskExecutionProgressResV1{
+		TaskStatus: task.Status,
+		SQLs:       []*SQLExecutionProgressResV1{},
+	}
+	if task.Status != model.TaskStatusExecuting && task.Status != model.TaskStatusTerminating {
+		return res, nil
+	}
+
+	executeSQLs, err := s.GetExecutingSQLsByTaskId(taskId)
+	if err != nil {
+		return nil, err
+	}
+	for _, executeSQL := range executeSQLs {
+		sqlRes := &SQLExecutionProgressResV1{
+			Number: executeSQL.Number,
+			SQL:    executeSQL.Content,
+		}
+		// the progress is not reported in the first second of execution.
+		progress, exist, err := s.GetExecuteSQLProgressByExecuteSQLId(executeSQL.ID)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			sqlRes.ElapsedSeconds = progress.ElapsedSeconds
+			sqlRes.State = progress.State
+			sqlRes.RowsExamined = progress.RowsExamined
+			sqlRes.RowsAffected = progress.RowsAffected
+		}
+		onlineDDL, exist, err := s.GetOnlineDDLExecutionByExecuteSQLId(executeSQL.ID)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			sqlRes.OnlineDDL = convertOnlineDDLExecutionToRes(onlineDDL)
+		}
+		res.SQLs = append(res.SQLs, sqlRes)
+	}
+	return res, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/actiontech/sqle/sqle/api/controller"
 	v1 "github.com/actiontech/sqle/sqle/api/controller/v1"
+	"github.com/actiontech/sqle/sqle/dms"
 	"github.com/actiontech/sqle/sqle/model"
 
 	"github.com/labstack/echo/v4"
@@ -38,13 +39,25 @@ type AuditTaskSQLResV2 struct {
 	RollbackSQL   string         `json:"rollback_sql,omitempty"`
 	Description   string         `json:"description"`
 	SQLType       string         `json:"sql_type"`
+	// the result of checking the replica lag before the sql is executed
+	ReplicaLagGuardResult string `json:"replica_lag_guard_result"`
+	// the sessions blocking the DDL found before the sql is executed
+	MetadataLockCheckResult string `json:"metadata_lock_check_result"`
 }
 
 type AuditResult struct {
-	Level    string `json:"level" example:"warn"`
-	Message  string `json:"message" example:"避免使用不必要的内置函数md5()"`
-	RuleName string `json:"rule_name"`
-	DbType   string `json:"db_type"`
+	Level         string         `json:"level" example:"warn"`
+	Message       string         `json:"message" example:"避免使用不必要的内置函数md5()"`
+	RuleName      string         `json:"rule_name"`
+	DbType        string         `json:"db_type"`
+	FixSuggestion *FixSuggestion `json:"fix_suggestion,omitempty"`
+	Suppressed    bool           `json:"suppressed,omitempty"`
+	SuppressedBy  string         `json:"suppressed_by,omitempty"`
+}
+
+type FixSuggestion struct {
+	Description  string `json:"description" example:"为DELETE语句添加LIMIT"`
+	RewrittenSQL string `json:"rewritten_sql" example:"DELETE FROM t1 WHERE id > 1 LIMIT 1000"`
 }
 
 // @Summary 获取指定扫描任务的SQLs信息
@@ -53,7 +66,7 @@ type AuditResult struct {
 // @Id getAuditTaskSQLsV2
 // @Security ApiKeyAuth
 // @Param task_id path string true "task id"
-// @Param filter_exec_status query string false "filter: exec status of task sql" Enums(initialized,doing,succeeded,failed,manually_executed,terminating,terminate_succeeded,terminate_failed)
+// @Param filter_exec_status query string false "filter: exec status of task sql" Enums(initialized,doing,succeeded,failed,manually_executed,terminating,terminate_succeeded,terminate_failed,skipped,waiting_for_confirm)
 // @Param filter_audit_status query string false "filter: audit status of task sql" Enums(initialized,doing,finished)
 // @Param filter_audit_level query string false "filter: audit level of task sql" Enums(normal,notice,warn,error)
 // @Param no_duplicate query boolean false "select unique (fingerprint and audit result) for task sql"
@@ -97,6 +110,14 @@ func GetTaskSQLs(c echo.Context) error {
 		return controller.JSONBaseErrorReq(c, err)
 	}
 
+	userNames := map[string]string{}
+	getUserName := func(userId string) string {
+		if _, ok := userNames[userId]; !ok {
+			userNames[userId] = dms.GetUserNameWithDelTag(userId)
+		}
+		return userNames[userId]
+	}
+
 	taskSQLsRes := make([]*AuditTaskSQLResV2, 0, len(taskSQLs))
 	for _, taskSQL := range taskSQLs {
 		taskSQLRes := &AuditTaskSQLResV2{
@@ -111,15 +132,29 @@ func GetTaskSQLs(c echo.Context) error {
 			ExecStatus:    taskSQL.ExecStatus,
 			RollbackSQL:   taskSQL.RollbackSQL.String,
 			SQLType:       taskSQL.SQLType.String,
+
+			ReplicaLagGuardResult:   taskSQL.ReplicaLagGuardResult,
+			MetadataLockCheckResult: taskSQL.MetadataLockCheckResult,
 		}
 		for i := range taskSQL.AuditResults {
 			ar := taskSQL.AuditResults[i]
-			taskSQLRes.AuditResult = append(taskSQLRes.AuditResult, &AuditResult{
-				Level:    ar.Level,
-				Message:  ar.Message,
-				RuleName: ar.RuleName,
-				DbType:   task.DBType,
-			})
+			auditResult := &AuditResult{
+				Level:      ar.Level,
+				Message:    ar.Message,
+				RuleName:   ar.RuleName,
+				DbType:     task.DBType,
+				Suppressed: ar.Suppressed,
+			}
+			if ar.SuppressedBy != "" {
+				auditResult.SuppressedBy = getUserName(ar.SuppressedBy)
+			}
+			if ar.FixSuggestion != nil {
+				auditResult.FixSuggestion = &FixSuggestion{
+					Description:  ar.FixSuggestion.Description,
+					RewrittenSQL: ar.FixSuggestion.RewrittenSQL,
+				}
+			}
+			taskSQLRes.AuditResult = append(taskSQLRes.AuditResult, auditResult)
 		}
 
 		taskSQLsRes = append(taskSQLsRes, taskSQLRes)
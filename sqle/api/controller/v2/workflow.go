@@ -28,12 +28,12 @@ var errTaskHasBeenUsed = errors.New(errors.DataConflict, fmt.Errorf("task has be
 type WorkflowStepResV2 struct {
 	Id            uint       `json:"workflow_step_id,omitempty"`
 	Number        uint       `json:"number"`
-	Type          string     `json:"type" enums:"create_workflow,update_workflow,sql_review,sql_execute"`
+	Type          string     `json:"type" enums:"create_workflow,update_workflow,sql_review,sql_execute,auto_rollback"`
 	Desc          string     `json:"desc,omitempty"`
 	Users         []string   `json:"assignee_user_name_list,omitempty"`
 	OperationUser string     `json:"operation_user_name,omitempty"`
 	OperationTime *time.Time `json:"operation_time,omitempty"`
-	State         string     `json:"state,omitempty" enums:"initialized,approved,rejected"`
+	State         string     `json:"state,omitempty" enums:"initialized,approved,rejected,succeeded,failed"`
 	Reason        string     `json:"reason,omitempty"`
 }
 
@@ -569,6 +569,14 @@ type CreateWorkflowReqV2 struct {
 	Subject string `json:"workflow_subject" form:"workflow_subject" valid:"required,name"`
 	Desc    string `json:"desc" form:"desc"`
 	TaskIds []uint `json:"task_ids" form:"task_ids" valid:"required"`
+	// the execution order of tasks, only for the workflow of different sqls
+	TaskDependencies []*TaskDependencyReqV2 `json:"task_dependencies" form:"task_dependencies" valid:"dive"`
+}
+
+type TaskDependencyReqV2 struct {
+	TaskId uint `json:"task_id" form:"task_id" valid:"required"`
+	// the task is executed only after all the upstream tasks succeed
+	DependsOnTaskIds []uint `json:"depends_on_task_ids" form:"depends_on_task_ids" valid:"required"`
 }
 
 type CreateWorkflowResV2 struct {
@@ -730,7 +738,11 @@ func CreateWorkflowV2(c echo.Context) error {
 		return err
 	}
 
-	err = s.CreateWorkflowV2(req.Subject, workflowId, req.Desc, user, tasks, stepTemplates, model.ProjectUID(projectUid), func(tasks []*model.Task) (auditWorkflowUsers, canExecUser [][]*model.User) {
+	taskDependencies := model.TaskDependencies{}
+	for _, dependency := range req.TaskDependencies {
+		taskDependencies[dependency.TaskId] = utils.RemoveDuplicateUint(dependency.DependsOnTaskIds)
+	}
+	err = s.CreateWorkflowV2(req.Subject, workflowId, req.Desc, user, tasks, taskDependencies, stepTemplates, model.ProjectUID(projectUid), func(tasks []*model.Task) (auditWorkflowUsers, canExecUser [][]*model.User) {
 		auditWorkflowUsers = make([][]*model.User, len(tasks))
 		executorWorkflowUsers := make([][]*model.User, len(tasks))
 		for i, task := range tasks {
@@ -761,6 +773,8 @@ func CreateWorkflowV2(c echo.Context) error {
 
 	go im.CreateApprove(string(workflow.ProjectId), workflow.WorkflowId)
 
+	go server.RehearseWorkflowTasks(projectUid, workflowTemplate, tasks)
+
 	return c.JSON(http.StatusOK, &CreateWorkflowResV2{
 		BaseRes: controller.NewBaseReq(nil),
 		Data: &CreateWorkflowResV2Data{
@@ -913,6 +927,8 @@ func UpdateWorkflowV2(c echo.Context) error {
 
 	go im.CreateApprove(string(workflow.ProjectId), workflow.WorkflowId)
 
+	go server.RehearseWorkflowTasks(projectUid, template, tasks)
+
 	return c.JSON(http.StatusOK, controller.NewBaseReq(nil))
 }
 
@@ -1069,7 +1085,8 @@ type GetWorkflowResV2 struct {
 }
 
 type WorkflowTaskItem struct {
-	Id uint `json:"task_id"`
+	Id               uint   `json:"task_id"`
+	DependsOnTaskIds []uint `json:"depends_on_task_ids,omitempty"`
 }
 
 type WorkflowRecordResV2 struct {
@@ -1144,6 +1161,9 @@ func GetWorkflowV2(c echo.Context) error {
 		return controller.JSONBaseErrorReq(c, err)
 	}
 	workflow.RecordHistory = history
+	if err := s.FillWorkflowAutoRollbacks(append([]*model.WorkflowRecord{workflow.Record}, history...)); err != nil {
+		return controller.JSONBaseErrorReq(c, err)
+	}
 	// createUser, err := dms.GetUser(c.Request().Context(), workflow.CreateUserId, controller.GetDMSServerAddress())
 	// if err != nil {
 	// 	return controller.JSONBaseErrorReq(c, err)
@@ -1203,6 +1223,15 @@ func convertWorkflowRecordToRes(workflow *model.Workflow, record *model.Workflow
 		stepRes := convertWorkflowStepToRes(step)
 		steps = append(steps, stepRes)
 	}
+	// the automatic rollbacks after execution failed
+	for _, rollback := range record.AutoRollbacks {
+		steps = append(steps, &WorkflowStepResV2{
+			Type:          model.WorkflowStepTypeAutoRollback,
+			OperationTime: &rollback.CreatedAt,
+			State:         rollback.State,
+			Reason:        rollback.Reason,
+		})
+	}
 	// fill step number
 	var currentStepNum uint
 	for i, step := range steps {
@@ -1215,7 +1244,7 @@ func convertWorkflowRecordToRes(workflow *model.Workflow, record *model.Workflow
 
 	tasksRes := make([]*WorkflowTaskItem, len(record.InstanceRecords))
 	for i, inst := range record.InstanceRecords {
-		tasksRes[i] = &WorkflowTaskItem{Id: inst.TaskId}
+		tasksRes[i] = &WorkflowTaskItem{Id: inst.TaskId, DependsOnTaskIds: inst.GetDependsOnTaskIds()}
 	}
 
 	return &WorkflowRecordResV2{
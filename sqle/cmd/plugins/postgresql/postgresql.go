@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/actiontech/sqle/sqle/driver/postgresql"
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/pkg/driver"
+)
+
+func main() {
+	b := driver.NewDriverBuilder(&driver.PostgresDialector{})
+	b.Meta.DatabaseDefaultPort = 5432
+	b.SetEnableOptionalModule(
+		driverV2.OptionalModuleGenRollbackSQL,
+		driverV2.OptionalModuleQuery,
+		driverV2.OptionalModuleExplain,
+		driverV2.OptionalModuleGetTableMeta,
+		driverV2.OptionalModuleExtractTableFromSQL,
+		driverV2.OptionalModuleEstimateSQLAffectRows,
+		driverV2.OptionalModuleKillProcess,
+	)
+	postgresql.RegisterRules(b)
+	b.Serve(postgresql.NewDriverImpl)
+}
@@ -4,11 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/actiontech/sqle/sqle/cmd/scannerd/scanners/mybatis"
 	"github.com/actiontech/sqle/sqle/cmd/scannerd/scanners/supervisor"
-	"github.com/actiontech/sqle/sqle/pkg/scanner"
 
 	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
@@ -33,14 +31,18 @@ var (
 				SkipAudit:      skipAudit,
 			}
 			log := logrus.WithField("scanner", "mybatis")
-			client := scanner.NewSQLEClient(time.Second*time.Duration(rootCmdFlags.timeout), rootCmdFlags.host, rootCmdFlags.port).WithToken(rootCmdFlags.token).WithProject(rootCmdFlags.project)
+			client, err := newSQLEClient(log)
+			if err != nil {
+				fmt.Println(color.RedString(err.Error()))
+				os.Exit(1)
+			}
 			scanner, err := mybatis.New(param, log, client)
 			if err != nil {
 				fmt.Println(color.RedString(err.Error()))
 				os.Exit(1)
 			}
 
-			err = supervisor.Start(context.TODO(), scanner, 30, 1024)
+			err = supervisor.Start(context.TODO(), scanner, rootCmdFlags.pushIntervalSecond, rootCmdFlags.pushBufferSize)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(1)
@@ -3,9 +3,11 @@ package cmd
 import (
 	"fmt"
 	"context"
+	"time"
 
 	pkgScanner "github.com/actiontech/sqle/sqle/pkg/scanner"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +19,13 @@ var (
 		project       string
 		auditPlanName string
 		timeout       int
+
+		pushIntervalSecond int
+		pushBufferSize     int
+		uploadBatchSize    int
+		gzip               bool
+		retryAttempts      uint
+		spoolDir           string
 	}
 
 	rootCmd = &cobra.Command{
@@ -33,6 +42,12 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&rootCmdFlags.token, "token", "A", "", "sqle token")
 	rootCmd.PersistentFlags().IntVarP(&rootCmdFlags.timeout, "timeout", "T", pkgScanner.DefaultTimeoutNum, "request sqle timeout in seconds")
 	rootCmd.PersistentFlags().StringVarP(&rootCmdFlags.project, "project", "J", "default", "project name")
+	rootCmd.PersistentFlags().IntVarP(&rootCmdFlags.pushIntervalSecond, "push-interval", "", 30, "upload collected sql at least every N seconds")
+	rootCmd.PersistentFlags().IntVarP(&rootCmdFlags.pushBufferSize, "push-buffer-size", "", 1024, "upload collected sql once N sql are buffered")
+	rootCmd.PersistentFlags().IntVarP(&rootCmdFlags.uploadBatchSize, "upload-batch-size", "", 0, "max number of sql in one upload request, 0 means no limit. The full upload in batches is not atomic, the sql uploaded before a failed batch are kept")
+	rootCmd.PersistentFlags().BoolVarP(&rootCmdFlags.gzip, "gzip", "", false, "compress upload request with gzip")
+	rootCmd.PersistentFlags().UintVarP(&rootCmdFlags.retryAttempts, "retry", "", pkgScanner.DefaultUploadRetryAttempts, "upload attempts with backoff when request sqle failed")
+	rootCmd.PersistentFlags().StringVarP(&rootCmdFlags.spoolDir, "spool-dir", "", "", "directory to save sql failed to upload, they will be uploaded in order later")
 	_ = rootCmd.MarkPersistentFlagRequired("name")
 	_ = rootCmd.MarkPersistentFlagRequired("token")
 }
@@ -41,3 +56,26 @@ func Execute(ctx context.Context) error {
 	rootCmd.SetVersionTemplate(fmt.Sprintln(ctx.Value(VersionKey)))
 	return rootCmd.Execute()
 }
+
+// newSQLEClient creates the SQLE client by the root flags, and uploads the SQLs left
+// in the spool dir by the last run first.
+func newSQLEClient(log *logrus.Entry) (*pkgScanner.Client, error) {
+	client := pkgScanner.NewSQLEClient(time.Second*time.Duration(rootCmdFlags.timeout), rootCmdFlags.host, rootCmdFlags.port).
+		WithToken(rootCmdFlags.token).
+		WithProject(rootCmdFlags.project).
+		WithUploadBatchSize(rootCmdFlags.uploadBatchSize).
+		WithGzip(rootCmdFlags.gzip).
+		WithUploadRetry(rootCmdFlags.retryAttempts, pkgScanner.DefaultUploadRetryDelay)
+	if rootCmdFlags.spoolDir == "" {
+		return client, nil
+	}
+
+	client, err := client.WithSpoolDir(rootCmdFlags.spoolDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.FlushSpool(); err != nil {
+		log.Warnf("upload the sql in spool dir failed, they will be uploaded later, error: %v", err)
+	}
+	return client, nil
+}
@@ -4,11 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/actiontech/sqle/sqle/cmd/scannerd/scanners/slowquery"
 	"github.com/actiontech/sqle/sqle/cmd/scannerd/scanners/supervisor"
-	"github.com/actiontech/sqle/sqle/pkg/scanner"
 
 	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
@@ -35,14 +33,18 @@ var (
 				ExcludeSchemas: excludeSchemas,
 			}
 			log := logrus.WithField("scanner", "slowquery")
-			client := scanner.NewSQLEClient(time.Second*time.Duration(rootCmdFlags.timeout), rootCmdFlags.host, rootCmdFlags.port).WithToken(rootCmdFlags.token).WithProject(rootCmdFlags.project)
+			client, err := newSQLEClient(log)
+			if err != nil {
+				fmt.Println(color.RedString(err.Error()))
+				os.Exit(1)
+			}
 			scanner, err := slowquery.New(param, log, client)
 			if err != nil {
 				fmt.Println(color.RedString(err.Error()))
 				os.Exit(1)
 			}
 
-			err = supervisor.Start(context.TODO(), scanner, 30, 1024)
+			err = supervisor.Start(context.TODO(), scanner, rootCmdFlags.pushIntervalSecond, rootCmdFlags.pushBufferSize)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(1)
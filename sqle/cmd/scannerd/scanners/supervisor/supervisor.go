@@ -2,9 +2,11 @@ package supervisor
 
 import (
 	"context"
+	stdErrors "errors"
 	"time"
 
 	"github.com/actiontech/sqle/sqle/cmd/scannerd/scanners"
+	"github.com/actiontech/sqle/sqle/pkg/scanner"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -38,7 +40,7 @@ func Start(ctx context.Context, scanner scanners.Scanner, leastPushSecond, pushB
 		case sql, ok := <-sqlCh:
 			if !ok {
 				if len(batch) != 0 {
-					err := scanner.Upload(context.TODO(), batch)
+					err := upload(scanner, batch)
 					if err != nil {
 						return errors.Wrap(err, "failed to upload sql")
 					}
@@ -57,7 +59,7 @@ func Start(ctx context.Context, scanner scanners.Scanner, leastPushSecond, pushB
 			}
 		}
 		logrus.StandardLogger().Infof("start uploading %d sql\n", len(batch))
-		err := scanner.Upload(context.TODO(), batch)
+		err := upload(scanner, batch)
 		if err != nil {
 			return errors.Wrap(err, "failed to upload sql")
 		}
@@ -65,3 +67,14 @@ func Start(ctx context.Context, scanner scanners.Scanner, leastPushSecond, pushB
 		batch = make([]scanners.SQL, 0, pushBufferSize)
 	}
 }
+
+// upload uploads the sqls by scanner. It is not an error that the sqls are saved in
+// the spool dir, because they will be uploaded later.
+func upload(s scanners.Scanner, sqls []scanners.SQL) error {
+	err := s.Upload(context.TODO(), sqls)
+	if stdErrors.Is(err, scanner.ErrUploadSpooled) {
+		logrus.StandardLogger().Warnf("sql are saved in spool dir and will be uploaded later, error: %v", err)
+		return nil
+	}
+	return err
+}
@@ -1605,6 +1605,70 @@ var doc = `{
                 }
             }
         },
+        "/v1/projects/{project_name}/audit_plans/{audit_plan_name}/sql_metrics": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the metrics trend of the SQL fingerprint of audit plan, the metrics are aggregated per hour",
+                "tags": [
+                    "audit_plan"
+                ],
+                "summary": "获取扫描任务指定SQL的指标趋势",
+                "operationId": "getAuditPlanSQLMetricsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project name",
+                        "name": "project_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "audit plan name",
+                        "name": "audit_plan_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sql fingerprint",
+                        "name": "fingerprint",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "schema name",
+                        "name": "schema",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter start time, RFC3339 format, default is 24 hours before the end time",
+                        "name": "filter_start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter end time, RFC3339 format, default is now",
+                        "name": "filter_end_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.GetAuditPlanSQLMetricsResV1"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/projects/{project_name}/audit_plans/{audit_plan_name}/sqls": {
             "get": {
                 "security": [
@@ -4228,19 +4292,22 @@ var doc = `{
                 }
             }
         },
-        "/v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/terminate": {
+        "/v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/confirm": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "execute one task on workflow",
+                "description": "confirm to execute the DDL which is paused because it is blocked by other sessions, terminate the task to cancel it",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "workflow"
                 ],
-                "summary": "终止单个上线任务",
-                "operationId": "terminateSingleTaskByWorkflowV1",
+                "summary": "确认执行被阻塞的 DDL",
+                "operationId": "confirmTaskSQLOnWorkflowV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -4274,113 +4341,68 @@ var doc = `{
                 }
             }
         },
-        "/v1/projects/{project_name}/workflows/{workflow_name}/": {
+        "/v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/execution_progress": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get workflow detail",
+                "description": "stream the progress of the executing SQLs of task by server-sent events, the event \"progress\" is sent every second until the task is not executing",
+                "produces": [
+                    "text/event-stream"
+                ],
                 "tags": [
                     "workflow"
                 ],
-                "summary": "获取工单详情",
-                "operationId": "getWorkflowV1",
-                "deprecated": true,
+                "summary": "以 SSE 推送工单数据源任务正在执行的 SQL 的进度",
+                "operationId": "streamTaskExecutionProgressV1",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "workflow name",
-                        "name": "workflow_name",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "project name",
                         "name": "project_name",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/v1.GetWorkflowResV1"
-                        }
-                    }
-                }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update workflow when it is rejected to creator.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "workflow"
-                ],
-                "summary": "更新工单（驳回后才可更新）",
-                "operationId": "updateWorkflowV1",
-                "deprecated": true,
-                "parameters": [
+                    },
                     {
                         "type": "string",
-                        "description": "workflow name",
-                        "name": "workflow_name",
+                        "description": "workflow id",
+                        "name": "workflow_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "project name",
-                        "name": "project_name",
+                        "description": "task id",
+                        "name": "task_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "update workflow request",
-                        "name": "instance",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UpdateWorkflowReqV1"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controller.BaseRes"
+                            "$ref": "#/definitions/v1.TaskExecutionProgressResV1"
                         }
                     }
                 }
             }
         },
-        "/v1/projects/{project_name}/workflows/{workflow_name}/cancel": {
-            "post": {
+        "/v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/online_ddl": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "cancel workflow",
+                "description": "get the progress and control of the online DDL executed by the SQLs of task",
                 "tags": [
                     "workflow"
                 ],
-                "summary": "审批关闭（中止）",
-                "operationId": "cancelWorkflowV1",
-                "deprecated": true,
+                "summary": "获取工单数据源任务中 online DDL 的执行进度",
+                "operationId": "getTaskOnlineDDLExecutionsV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -4391,8 +4413,15 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "workflow name",
-                        "name": "workflow_name",
+                        "description": "workflow id",
+                        "name": "workflow_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "task id",
+                        "name": "task_id",
                         "in": "path",
                         "required": true
                     }
@@ -4401,45 +4430,44 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controller.BaseRes"
+                            "$ref": "#/definitions/v1.GetTaskOnlineDDLExecutionsResV1"
                         }
                     }
                 }
             }
         },
-        "/v1/projects/{project_name}/workflows/{workflow_name}/steps/{workflow_step_id}/approve": {
-            "post": {
+        "/v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/rehearsal": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "approve workflow",
+                "description": "get the rehearsal report of task on the sandbox instance",
                 "tags": [
                     "workflow"
                 ],
-                "summary": "审批通过",
-                "operationId": "approveWorkflowV1",
-                "deprecated": true,
+                "summary": "获取工单数据源任务在沙箱实例上的预演报告",
+                "operationId": "getTaskRehearsalV1",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "workflow name",
-                        "name": "workflow_name",
+                        "description": "project name",
+                        "name": "project_name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "workflow step id",
-                        "name": "workflow_step_id",
+                        "description": "workflow id",
+                        "name": "workflow_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "project name",
-                        "name": "project_name",
+                        "description": "task id",
+                        "name": "task_id",
                         "in": "path",
                         "required": true
                     }
@@ -4448,31 +4476,36 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controller.BaseRes"
+                            "$ref": "#/definitions/v1.GetTaskRehearsalResV1"
                         }
                     }
                 }
             }
         },
-        "/v1/projects/{project_name}/workflows/{workflow_name}/steps/{workflow_step_id}/reject": {
+        "/v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/resume": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "reject workflow",
+                "description": "resume the failed task from the failed SQL, the SQLs executed successfully are not executed again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "workflow"
                 ],
-                "summary": "审批驳回",
-                "operationId": "rejectWorkflowV1",
-                "deprecated": true,
+                "summary": "恢复上线失败的任务",
+                "operationId": "resumeTaskOnWorkflowV1",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "workflow name",
-                        "name": "workflow_name",
+                        "description": "workflow id",
+                        "name": "workflow_id",
                         "in": "path",
                         "required": true
                     },
@@ -4485,18 +4518,18 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "workflow step id",
-                        "name": "workflow_step_id",
+                        "description": "task id",
+                        "name": "task_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "workflow approve request",
-                        "name": "workflow_approve",
+                        "description": "resume task request",
+                        "name": "instance",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.RejectWorkflowReqV1"
+                            "$ref": "#/definitions/v1.ResumeTaskReqV1"
                         }
                     }
                 ],
@@ -4510,32 +4543,45 @@ var doc = `{
                 }
             }
         },
-        "/v1/projects/{project_name}/workflows/{workflow_name}/tasks": {
+        "/v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/sqls/{number}/chunks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get summary of workflow instance tasks",
+                "description": "get the chunks of the DML executed in chunks by the SQL of task",
                 "tags": [
                     "workflow"
                 ],
-                "summary": "获取工单数据源任务概览",
-                "operationId": "getSummaryOfInstanceTasksV1",
-                "deprecated": true,
+                "summary": "获取工单数据源任务中分批执行的 DML 的批次",
+                "operationId": "getTaskSQLChunksV1",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "workflow name",
-                        "name": "workflow_name",
+                        "description": "project name",
+                        "name": "project_name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "project name",
-                        "name": "project_name",
+                        "description": "workflow id",
+                        "name": "workflow_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "task id",
+                        "name": "task_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sql number",
+                        "name": "number",
                         "in": "path",
                         "required": true
                     }
@@ -4544,40 +4590,65 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.GetWorkflowTasksResV1"
+                            "$ref": "#/definitions/v1.GetTaskSQLChunksResV1"
                         }
                     }
                 }
             }
         },
-        "/v1/projects/{project_name}/workflows/{workflow_name}/tasks/execute": {
-            "post": {
+        "/v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/sqls/{number}/online_ddl": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "execute tasks on workflow",
+                "description": "pause, resume or throttle the online DDL executed by the SQL of task",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
                     "workflow"
                 ],
-                "summary": "多数据源批量上线",
-                "operationId": "executeTasksOnWorkflowV1",
-                "deprecated": true,
+                "summary": "暂停、恢复或调整正在执行的 online DDL",
+                "operationId": "updateOnlineDDLExecutionV1",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "workflow name",
-                        "name": "workflow_name",
+                        "description": "project name",
+                        "name": "project_name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "project name",
-                        "name": "project_name",
+                        "description": "workflow id",
+                        "name": "workflow_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "task id",
+                        "name": "task_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sql number",
+                        "name": "number",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "control of online DDL",
+                        "name": "instance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateOnlineDDLExecutionReqV1"
+                        }
                     }
                 ],
                 "responses": {
@@ -4590,7 +4661,7 @@ var doc = `{
                 }
             }
         },
-        "/v1/projects/{project_name}/workflows/{workflow_name}/tasks/{task_id}/execute": {
+        "/v1/projects/{project_name}/workflows/{workflow_id}/tasks/{task_id}/terminate": {
             "post": {
                 "security": [
                     {
@@ -4601,8 +4672,370 @@ var doc = `{
                 "tags": [
                     "workflow"
                 ],
-                "summary": "工单提交单个数据源上线",
-                "operationId": "executeOneTaskOnWorkflowV1",
+                "summary": "终止单个上线任务",
+                "operationId": "terminateSingleTaskByWorkflowV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workflow id",
+                        "name": "workflow_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "project name",
+                        "name": "project_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "task id",
+                        "name": "task_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.BaseRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{project_name}/workflows/{workflow_name}/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get workflow detail",
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "获取工单详情",
+                "operationId": "getWorkflowV1",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workflow name",
+                        "name": "workflow_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "project name",
+                        "name": "project_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.GetWorkflowResV1"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update workflow when it is rejected to creator.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "更新工单（驳回后才可更新）",
+                "operationId": "updateWorkflowV1",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workflow name",
+                        "name": "workflow_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "project name",
+                        "name": "project_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update workflow request",
+                        "name": "instance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateWorkflowReqV1"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.BaseRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{project_name}/workflows/{workflow_name}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "cancel workflow",
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "审批关闭（中止）",
+                "operationId": "cancelWorkflowV1",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project name",
+                        "name": "project_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "workflow name",
+                        "name": "workflow_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.BaseRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{project_name}/workflows/{workflow_name}/steps/{workflow_step_id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "approve workflow",
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "审批通过",
+                "operationId": "approveWorkflowV1",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workflow name",
+                        "name": "workflow_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "workflow step id",
+                        "name": "workflow_step_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "project name",
+                        "name": "project_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.BaseRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{project_name}/workflows/{workflow_name}/steps/{workflow_step_id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "reject workflow",
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "审批驳回",
+                "operationId": "rejectWorkflowV1",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workflow name",
+                        "name": "workflow_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "project name",
+                        "name": "project_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "workflow step id",
+                        "name": "workflow_step_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "workflow approve request",
+                        "name": "workflow_approve",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.RejectWorkflowReqV1"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.BaseRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{project_name}/workflows/{workflow_name}/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get summary of workflow instance tasks",
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "获取工单数据源任务概览",
+                "operationId": "getSummaryOfInstanceTasksV1",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workflow name",
+                        "name": "workflow_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "project name",
+                        "name": "project_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.GetWorkflowTasksResV1"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{project_name}/workflows/{workflow_name}/tasks/execute": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "execute tasks on workflow",
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "多数据源批量上线",
+                "operationId": "executeTasksOnWorkflowV1",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "workflow name",
+                        "name": "workflow_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "project name",
+                        "name": "project_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.BaseRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{project_name}/workflows/{workflow_name}/tasks/{task_id}/execute": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "execute one task on workflow",
+                "tags": [
+                    "workflow"
+                ],
+                "summary": "工单提交单个数据源上线",
+                "operationId": "executeOneTaskOnWorkflowV1",
                 "deprecated": true,
                 "parameters": [
                     {
@@ -7352,7 +7785,9 @@ var doc = `{
                             "manually_executed",
                             "terminating",
                             "terminate_succeeded",
-                            "terminate_failed"
+                            "terminate_failed",
+                            "skipped",
+                            "waiting_for_confirm"
                         ],
                         "type": "string",
                         "description": "filter: exec status of task sql",
@@ -7645,6 +8080,31 @@ var doc = `{
                 }
             }
         },
+        "v1.AuditPlanSQLMetricResV1": {
+            "type": "object",
+            "properties": {
+                "bucket_time": {
+                    "type": "string",
+                    "example": "2023-08-01T10:00:00+08:00"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "query_time_avg": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "query_time_max": {
+                    "type": "number",
+                    "example": 1.2
+                },
+                "rows_examined": {
+                    "type": "integer",
+                    "example": 1000
+                }
+            }
+        },
         "v1.AuditPlanSQLReqV1": {
             "type": "object",
             "properties": {
@@ -7723,6 +8183,7 @@ var doc = `{
                         "Oracle",
                         "TiDB",
                         "OceanBase For MySQL",
+                        "PostgreSQL",
                         ""
                     ]
                 },
@@ -7835,6 +8296,24 @@ var doc = `{
                         ""
                     ]
                 },
+                "auto_rollback_status": {
+                    "description": "the status of rolling back the task automatically after its execution fails or is terminated",
+                    "type": "string",
+                    "enum": [
+                        "rolling_back",
+                        "succeeded",
+                        "failed"
+                    ]
+                },
+                "binlog_rollback_status": {
+                    "description": "the status of generating rollback SQLs by binlog after execution, it is empty if they are not generated by binlog",
+                    "type": "string",
+                    "enum": [
+                        "generating",
+                        "succeeded",
+                        "failed"
+                    ]
+                },
                 "exec_end_time": {
                     "type": "string"
                 },
@@ -7915,6 +8394,12 @@ var doc = `{
                 "exec_status": {
                     "type": "string"
                 },
+                "fix_suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.FixSuggestionResV1"
+                    }
+                },
                 "number": {
                     "type": "integer"
                 },
@@ -7937,12 +8422,37 @@ var doc = `{
                 "audit_whitelist_id": {
                     "type": "integer"
                 },
+                "create_user": {
+                    "type": "string"
+                },
                 "desc": {
                     "type": "string"
                 },
+                "expired_at": {
+                    "type": "string"
+                },
+                "instance_name": {
+                    "type": "string"
+                },
+                "instance_schema": {
+                    "type": "string"
+                },
                 "match_type": {
                     "type": "string"
                 },
+                "rule_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "enabled",
+                        "disabled"
+                    ]
+                },
                 "value": {
                     "type": "string"
                 }
@@ -8165,14 +8675,37 @@ var doc = `{
                     "type": "string",
                     "example": "used for rapid release"
                 },
+                "expired_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00+08:00"
+                },
+                "instance_name": {
+                    "type": "string",
+                    "example": "inst_1"
+                },
+                "instance_schema": {
+                    "type": "string",
+                    "example": "db1"
+                },
                 "match_type": {
                     "type": "string",
                     "enum": [
                         "exact_match",
-                        "fp_match"
+                        "fp_match",
+                        "regex_match",
+                        "table_match"
                     ],
                     "example": "exact_match"
                 },
+                "rule_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ddl_check_index_count"
+                    ]
+                },
                 "value": {
                     "type": "string",
                     "example": "create table"
@@ -8531,6 +9064,17 @@ var doc = `{
                 }
             }
         },
+        "v1.FixSuggestionResV1": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "rewritten_sql": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.FullSyncAuditPlanSQLsReqV1": {
             "type": "object",
             "properties": {
@@ -8696,6 +9240,25 @@ var doc = `{
                 }
             }
         },
+        "v1.GetAuditPlanSQLMetricsResV1": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.AuditPlanSQLMetricResV1"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
         "v1.GetAuditPlanSQLsResV1": {
             "type": "object",
             "properties": {
@@ -9652,6 +10215,61 @@ var doc = `{
                 }
             }
         },
+        "v1.GetTaskOnlineDDLExecutionsResV1": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.OnlineDDLExecutionResV1"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "v1.GetTaskRehearsalResV1": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/v1.TaskRehearsalResV1"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "v1.GetTaskSQLChunksResV1": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.TaskSQLChunkResV1"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
         "v1.GetUserTipsResV1": {
             "type": "object",
             "properties": {
@@ -10184,22 +10802,63 @@ var doc = `{
                         "$ref": "#/definitions/v1.Table"
                     }
                 },
-                "message": {
-                    "type": "string",
-                    "example": "ok"
-                }
-            }
-        },
-        "v1.MaintenanceTimeResV1": {
-            "type": "object",
-            "properties": {
-                "maintenance_start_time": {
-                    "type": "object",
-                    "$ref": "#/definitions/v1.TimeResV1"
+                "message": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "v1.MaintenanceTimeResV1": {
+            "type": "object",
+            "properties": {
+                "maintenance_start_time": {
+                    "type": "object",
+                    "$ref": "#/definitions/v1.TimeResV1"
+                },
+                "maintenance_stop_time": {
+                    "type": "object",
+                    "$ref": "#/definitions/v1.TimeResV1"
+                }
+            }
+        },
+        "v1.OnlineDDLExecutionResV1": {
+            "type": "object",
+            "properties": {
+                "chunk_size": {
+                    "type": "integer"
+                },
+                "eta_seconds": {
+                    "type": "integer"
+                },
+                "max_lag_millis": {
+                    "type": "integer"
+                },
+                "nice_ratio": {
+                    "type": "number"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "progress_pct": {
+                    "type": "number"
+                },
+                "rows_copied": {
+                    "type": "integer"
+                },
+                "rows_estimate": {
+                    "type": "integer"
                 },
-                "maintenance_stop_time": {
-                    "type": "object",
-                    "$ref": "#/definitions/v1.TimeResV1"
+                "running": {
+                    "type": "boolean"
+                },
+                "throttle_reason": {
+                    "type": "string"
+                },
+                "throttled": {
+                    "type": "boolean"
                 }
             }
         },
@@ -10363,6 +11022,34 @@ var doc = `{
                 }
             }
         },
+        "v1.RehearsalSQLResV1": {
+            "type": "object",
+            "properties": {
+                "exec_duration_ms": {
+                    "type": "integer"
+                },
+                "exec_result": {
+                    "type": "string"
+                },
+                "exec_status": {
+                    "type": "string",
+                    "enum": [
+                        "initialized",
+                        "succeeded",
+                        "failed"
+                    ]
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "row_affects": {
+                    "type": "integer"
+                },
+                "sql": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.RejectWorkflowReqV1": {
             "type": "object",
             "properties": {
@@ -10371,6 +11058,27 @@ var doc = `{
                 }
             }
         },
+        "v1.ResumeTaskReqV1": {
+            "type": "object",
+            "properties": {
+                "operation": {
+                    "description": "the failed SQL is executed again by retry and edit, and is not executed by skip",
+                    "type": "string",
+                    "enum": [
+                        "retry",
+                        "edit",
+                        "skip"
+                    ]
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "sql": {
+                    "description": "the SQL to replace the failed SQL, it is required by edit",
+                    "type": "string"
+                }
+            }
+        },
         "v1.RiskAuditPlan": {
             "type": "object",
             "properties": {
@@ -10707,6 +11415,33 @@ var doc = `{
                 }
             }
         },
+        "v1.SQLExecutionProgressResV1": {
+            "type": "object",
+            "properties": {
+                "elapsed_seconds": {
+                    "type": "integer"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "online_ddl": {
+                    "type": "object",
+                    "$ref": "#/definitions/v1.OnlineDDLExecutionResV1"
+                },
+                "rows_affected": {
+                    "type": "integer"
+                },
+                "rows_examined": {
+                    "type": "integer"
+                },
+                "sql": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.SQLExplain": {
             "type": "object",
             "properties": {
@@ -10948,6 +11683,9 @@ var doc = `{
         "v1.SystemVariablesResV1": {
             "type": "object",
             "properties": {
+                "audit_plan_sql_metric_expired_hours": {
+                    "type": "integer"
+                },
                 "operation_record_expired_hours": {
                     "type": "integer"
                 },
@@ -11057,6 +11795,104 @@ var doc = `{
                 }
             }
         },
+        "v1.TaskExecutionProgressResV1": {
+            "type": "object",
+            "properties": {
+                "sql_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.SQLExecutionProgressResV1"
+                    }
+                },
+                "task_status": {
+                    "type": "string",
+                    "enum": [
+                        "initialized",
+                        "audited",
+                        "executing",
+                        "exec_succeeded",
+                        "exec_failed",
+                        "manually_executed",
+                        "terminating",
+                        "terminate_succeeded",
+                        "terminate_failed"
+                    ]
+                }
+            }
+        },
+        "v1.TaskRehearsalResV1": {
+            "type": "object",
+            "properties": {
+                "end_at": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "instance_name": {
+                    "type": "string"
+                },
+                "sql_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.RehearsalSQLResV1"
+                    }
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ]
+                }
+            }
+        },
+        "v1.TaskSQLChunkResV1": {
+            "type": "object",
+            "properties": {
+                "exec_result": {
+                    "type": "string"
+                },
+                "exec_status": {
+                    "type": "string",
+                    "enum": [
+                        "initialized",
+                        "doing",
+                        "succeeded",
+                        "failed",
+                        "terminating",
+                        "terminate_succeeded",
+                        "terminate_failed",
+                        "waiting_for_confirm"
+                    ]
+                },
+                "lower_bound": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "rollback_description": {
+                    "type": "string"
+                },
+                "rollback_sql": {
+                    "type": "string"
+                },
+                "row_affects": {
+                    "type": "integer"
+                },
+                "sql": {
+                    "type": "string"
+                },
+                "upper_bound": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.TestAuditPlanNotifyConfigResDataV1": {
             "type": "object",
             "properties": {
@@ -11257,14 +12093,45 @@ var doc = `{
                     "type": "string",
                     "example": "used for rapid release"
                 },
+                "expired_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00+08:00"
+                },
+                "instance_name": {
+                    "type": "string",
+                    "example": "inst_1"
+                },
+                "instance_schema": {
+                    "type": "string",
+                    "example": "db1"
+                },
                 "match_type": {
                     "type": "string",
                     "enum": [
                         "exact_match",
-                        "fp_match"
+                        "fp_match",
+                        "regex_match",
+                        "table_match"
                     ],
                     "example": "exact_match"
                 },
+                "rule_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ddl_check_index_count"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "enabled",
+                        "disabled"
+                    ],
+                    "example": "enabled"
+                },
                 "value": {
                     "type": "string",
                     "example": "create table"
@@ -11347,6 +12214,24 @@ var doc = `{
                 }
             }
         },
+        "v1.UpdateOnlineDDLExecutionReqV1": {
+            "type": "object",
+            "properties": {
+                "chunk_size": {
+                    "type": "integer"
+                },
+                "max_lag_millis": {
+                    "type": "integer"
+                },
+                "nice_ratio": {
+                    "type": "number"
+                },
+                "paused": {
+                    "description": "pause or resume the row copy of online DDL, the binlog is still applied while paused",
+                    "type": "boolean"
+                }
+            }
+        },
         "v1.UpdateProjectRuleTemplateReqV1": {
             "type": "object",
             "properties": {
@@ -11397,6 +12282,10 @@ var doc = `{
         "v1.UpdateSystemVariablesReqV1": {
             "type": "object",
             "properties": {
+                "audit_plan_sql_metric_expired_hours": {
+                    "type": "integer",
+                    "example": 720
+                },
                 "operation_record_expired_hours": {
                     "type": "integer",
                     "example": 2160
@@ -11445,6 +12334,17 @@ var doc = `{
                 "desc": {
                     "type": "string"
                 },
+                "enable_auto_rollback": {
+                    "description": "roll back the task automatically when its execution fails or is terminated",
+                    "type": "boolean"
+                },
+                "rehearsal_instance_name": {
+                    "type": "string"
+                },
+                "task_execution_timeout_seconds": {
+                    "description": "the task is terminated if its execution exceeds the timeout, 0 means no timeout",
+                    "type": "integer"
+                },
                 "workflow_step_template_list": {
                     "type": "array",
                     "items": {
@@ -11866,6 +12766,15 @@ var doc = `{
                 "desc": {
                     "type": "string"
                 },
+                "enable_auto_rollback": {
+                    "type": "boolean"
+                },
+                "rehearsal_instance_name": {
+                    "type": "string"
+                },
+                "task_execution_timeout_seconds": {
+                    "type": "integer"
+                },
                 "update_time": {
                     "type": "string"
                 },
@@ -12027,6 +12936,10 @@ var doc = `{
                 "db_type": {
                     "type": "string"
                 },
+                "fix_suggestion": {
+                    "type": "object",
+                    "$ref": "#/definitions/v2.FixSuggestion"
+                },
                 "level": {
                     "type": "string",
                     "example": "warn"
@@ -12037,6 +12950,12 @@ var doc = `{
                 },
                 "rule_name": {
                     "type": "string"
+                },
+                "suppressed": {
+                    "type": "boolean"
+                },
+                "suppressed_by": {
+                    "type": "string"
                 }
             }
         },
@@ -12087,9 +13006,17 @@ var doc = `{
                 "exec_status": {
                     "type": "string"
                 },
+                "metadata_lock_check_result": {
+                    "description": "the sessions blocking the DDL found before the sql is executed",
+                    "type": "string"
+                },
                 "number": {
                     "type": "integer"
                 },
+                "replica_lag_guard_result": {
+                    "description": "the result of checking the replica lag before the sql is executed",
+                    "type": "string"
+                },
                 "rollback_sql": {
                     "type": "string"
                 },
@@ -12132,6 +13059,13 @@ var doc = `{
                 "desc": {
                     "type": "string"
                 },
+                "task_dependencies": {
+                    "description": "the execution order of tasks, only for the workflow of different sqls",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.TaskDependencyReqV2"
+                    }
+                },
                 "task_ids": {
                     "type": "array",
                     "items": {
@@ -12262,6 +13196,19 @@ var doc = `{
                 }
             }
         },
+        "v2.FixSuggestion": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "为DELETE语句添加LIMIT"
+                },
+                "rewritten_sql": {
+                    "type": "string",
+                    "example": "DELETE FROM t1 WHERE id \u003e 1 LIMIT 1000"
+                }
+            }
+        },
         "v2.FullSyncAuditPlanSQLsReqV2": {
             "type": "object",
             "properties": {
@@ -12729,6 +13676,21 @@ var doc = `{
                 }
             }
         },
+        "v2.TaskDependencyReqV2": {
+            "type": "object",
+            "properties": {
+                "depends_on_task_ids": {
+                    "description": "the task is executed only after all the upstream tasks succeed",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "v2.UpdateWorkflowReqV2": {
             "type": "object",
             "properties": {
@@ -12846,7 +13808,9 @@ var doc = `{
                     "enum": [
                         "initialized",
                         "approved",
-                        "rejected"
+                        "rejected",
+                        "succeeded",
+                        "failed"
                     ]
                 },
                 "type": {
@@ -12855,7 +13819,8 @@ var doc = `{
                         "create_workflow",
                         "update_workflow",
                         "sql_review",
-                        "sql_execute"
+                        "sql_execute",
+                        "auto_rollback"
                     ]
                 },
                 "workflow_step_id": {
@@ -12866,6 +13831,12 @@ var doc = `{
         "v2.WorkflowTaskItem": {
             "type": "object",
             "properties": {
+                "depends_on_task_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "task_id": {
                     "type": "integer"
                 }
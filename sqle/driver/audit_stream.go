@@ -0,0 +1,61 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+)
+
+// DefaultAuditStreamBatchSize is the number of SQLs audited in one batch by AuditStream.
+const DefaultAuditStreamBatchSize = 500
+
+// AuditStreamSource returns the next batch of SQLs to be audited by AuditStream, the stream
+// is finished if the batch is empty. The batch is built when it is required, so the caller
+// does not have to keep all SQLs in memory.
+type AuditStreamSource func() ([]string, error)
+
+// AuditStreamHandler handles the audit results of the batch returned by the source last.
+type AuditStreamHandler func(results []*driverV2.AuditResults) error
+
+// NewAuditStreamSource returns the source which splits the SQLs into batches.
+func NewAuditStreamSource(sqls []string, batchSize int) AuditStreamSource {
+	if batchSize <= 0 {
+		batchSize = DefaultAuditStreamBatchSize
+	}
+	offset := 0
+	return func() ([]string, error) {
+		end := offset + batchSize
+		if end > len(sqls) {
+			end = len(sqls)
+		}
+		batch := sqls[offset:end]
+		offset = end
+		return batch, nil
+	}
+}
+
+// AuditInBatches implements AuditStream by calling audit batch by batch,
+// it is used by the plugins which have no streaming audit api.
+func AuditInBatches(ctx context.Context, audit func(context.Context, []string) ([]*driverV2.AuditResults, error),
+	source AuditStreamSource, handler AuditStreamHandler) error {
+	for {
+		sqls, err := source()
+		if err != nil {
+			return err
+		}
+		if len(sqls) == 0 {
+			return nil
+		}
+		results, err := audit(ctx, sqls)
+		if err != nil {
+			return err
+		}
+		if len(results) != len(sqls) {
+			return fmt.Errorf("audit results [%d] does not match the number of SQL [%d]", len(results), len(sqls))
+		}
+		if err := handler(results); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockAudit(ctx context.Context, sqls []string) ([]*driverV2.AuditResults, error) {
+	results := make([]*driverV2.AuditResults, 0, len(sqls))
+	for _, sql := range sqls {
+		result := driverV2.NewAuditResults()
+		result.Add(driverV2.RuleLevelNotice, "mock_rule", sql)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func TestAuditInBatches(t *testing.T) {
+	sqls := []string{"sql1", "sql2", "sql3", "sql4", "sql5"}
+
+	sizes := []int{}
+	messages := []string{}
+	err := AuditInBatches(context.TODO(), mockAudit, NewAuditStreamSource(sqls, 2), func(results []*driverV2.AuditResults) error {
+		sizes = append(sizes, len(results))
+		for _, result := range results {
+			messages = append(messages, result.Results[0].Message)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+	assert.Equal(t, sqls, messages)
+
+	// handler error stops the following batches
+	handlerErr := errors.New("handler error")
+	count := 0
+	err = AuditInBatches(context.TODO(), mockAudit, NewAuditStreamSource(sqls, 2), func(results []*driverV2.AuditResults) error {
+		count++
+		return handlerErr
+	})
+	assert.Equal(t, handlerErr, err)
+	assert.Equal(t, 1, count)
+
+	// source error stops the stream
+	sourceErr := errors.New("source error")
+	err = AuditInBatches(context.TODO(), mockAudit, func() ([]string, error) {
+		return nil, sourceErr
+	}, func(results []*driverV2.AuditResults) error {
+		return nil
+	})
+	assert.Equal(t, sourceErr, err)
+
+	// results count mismatch
+	err = AuditInBatches(context.TODO(), func(ctx context.Context, sqls []string) ([]*driverV2.AuditResults, error) {
+		return nil, nil
+	}, NewAuditStreamSource(sqls, 0), func(results []*driverV2.AuditResults) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package driver
+
+import (
+	"context"
+)
+
+// BinlogPosition is the position of event in MySQL binlog.
+type BinlogPosition struct {
+	File string
+	Pos  int64
+}
+
+// BinlogRollbacker is implemented by the plugin which generates the rollback SQL of DML from
+// the row events of binlog after execution, now only the built-in MySQL plugin implements it.
+// The rollback SQL generated from binlog is exact for the non-deterministic DML and the DML
+// affecting too many rows to be selected before execution.
+type BinlogRollbacker interface {
+	// IsBinlogRollbackEnabled returns true if the rollback SQL of DML should be generated from binlog.
+	IsBinlogRollbackEnabled() bool
+
+	// GetBinlogPosition returns the current binlog position of instance and the id of the
+	// connection used by Exec and Tx, the events written by Exec and Tx are filtered by the id.
+	GetBinlogPosition(ctx context.Context) (pos *BinlogPosition, connectionId string, err error)
+
+	// GenRollbackSQLByBinlog reads the row events written by the connection between start and end,
+	// and returns the inverse SQLs in the reverse order of events.
+	GenRollbackSQLByBinlog(ctx context.Context, start, end *BinlogPosition, connectionId string) ([]string, error)
+}
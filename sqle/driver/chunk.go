@@ -0,0 +1,38 @@
+package driver
+
+import (
+	"context"
+)
+
+// DMLChunkConfig is the config of executing DML in chunks.
+type DMLChunkConfig struct {
+	ChunkSize   int64
+	SleepMillis int64
+	// MaxReplicaLagSeconds is 0 if the replica lag is not checked between chunks.
+	MaxReplicaLagSeconds int64
+}
+
+// DMLChunk is the part of DML limited by a range of primary key, the range is
+// (LowerBound, UpperBound]. The first chunk has no lower bound and the last chunk
+// has no upper bound.
+type DMLChunk struct {
+	SQL           string
+	HasLowerBound bool
+	LowerBound    string
+	HasUpperBound bool
+	UpperBound    string
+}
+
+// DMLChunker is implemented by the plugin which is able to rewrite the DML affecting too many
+// rows into chunks ranged by primary key, so that the DML does not lock the table for long.
+// Now only the built-in MySQL plugin implements it.
+type DMLChunker interface {
+	// GetDMLChunkConfig returns nil if the DML should be executed as a whole.
+	GetDMLChunkConfig(ctx context.Context, sql string) (*DMLChunkConfig, error)
+
+	// NextDMLChunk returns the chunk next to the previous chunk, prev is nil for the first chunk.
+	NextDMLChunk(ctx context.Context, sql string, chunkSize int64, prev *DMLChunk) (*DMLChunk, error)
+
+	// GetReplicaLag returns the max lag of the replicas of instance, ok is false if there is no replica.
+	GetReplicaLag(ctx context.Context) (lagSeconds int64, ok bool, err error)
+}
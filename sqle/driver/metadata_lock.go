@@ -0,0 +1,48 @@
+package driver
+
+import (
+	"context"
+)
+
+// MetadataLockCheckPolicy decides what to do if the DDL is blocked by other sessions.
+type MetadataLockCheckPolicy string
+
+const (
+	// MetadataLockCheckPolicyRefuse refuses to execute the DDL.
+	MetadataLockCheckPolicyRefuse MetadataLockCheckPolicy = "refuse"
+	// MetadataLockCheckPolicyWait waits for the blocking sessions to finish, the DDL is refused
+	// if they do not finish in time.
+	MetadataLockCheckPolicyWait MetadataLockCheckPolicy = "wait"
+	// MetadataLockCheckPolicyPrompt pauses the DDL until the executor confirms to execute it or
+	// terminates the execution.
+	MetadataLockCheckPolicyPrompt MetadataLockCheckPolicy = "prompt"
+)
+
+type MetadataLockCheckConfig struct {
+	Policy         MetadataLockCheckPolicy
+	MaxWaitSeconds int64
+}
+
+// MetadataLockBlocker is a session which holds the metadata lock of the table altered by DDL, or a
+// long-running transaction which may touch the table. The DDL waits for the metadata lock and
+// blocks all the following queries on the table until the session finishes.
+type MetadataLockBlocker struct {
+	ConnectionId string
+	User         string
+	Host         string
+	// Table is empty if it is unknown which table the transaction touches.
+	Table          string
+	LockType       string
+	ElapsedSeconds int64
+	SQL            string
+}
+
+// MetadataLockChecker is implemented by the plugin which is able to find the sessions blocking
+// the DDL before it is executed. Now only the built-in MySQL plugin implements it.
+type MetadataLockChecker interface {
+	// GetMetadataLockCheckConfig returns nil if the metadata lock is not checked.
+	GetMetadataLockCheckConfig(ctx context.Context) (*MetadataLockCheckConfig, error)
+
+	// GetMetadataLockBlockers returns nil if the SQL is not DDL or is not blocked.
+	GetMetadataLockBlockers(ctx context.Context, sql string) ([]*MetadataLockBlocker, error)
+}
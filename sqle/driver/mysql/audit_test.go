@@ -1191,6 +1191,48 @@ func TestCheckSelectAll(t *testing.T) {
 	)
 }
 
+func TestFixSuggestion(t *testing.T) {
+	auditFix := func(rule driverV2.Rule, sql string) *driverV2.FixSuggestion {
+		i := DefaultMysqlInspect()
+		i.rules = []*driverV2.Rule{&rule}
+		results, err := i.Audit(context.TODO(), []string{sql})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		for _, result := range results[0].Results {
+			if result.RuleName == rule.Name {
+				return result.FixSuggestion
+			}
+		}
+		return nil
+	}
+
+	limitRule := rulepkg.RuleHandlerMap[rulepkg.DMLCheckLimitMustExist].Rule
+	fix := auditFix(limitRule, "delete from exist_db.exist_tb_1 where v1 = 'a'")
+	assert.NotNil(t, fix)
+	assert.Equal(t, "DELETE FROM `exist_db`.`exist_tb_1` WHERE `v1`='a' LIMIT 1000", fix.RewrittenSQL)
+	fix = auditFix(limitRule, "update exist_db.exist_tb_1 set v2 = 'b' where v1 = 'a'")
+	assert.NotNil(t, fix)
+	assert.Equal(t, "UPDATE `exist_db`.`exist_tb_1` SET `v2`='b' WHERE `v1`='a' LIMIT 1000", fix.RewrittenSQL)
+	assert.Nil(t, auditFix(limitRule, "delete t1 from exist_db.exist_tb_1 t1 join exist_db.exist_tb_2 t2 on t1.id = t2.id"))
+
+	selectAllRule := rulepkg.RuleHandlerMap[rulepkg.DMLDisableSelectAllColumn].Rule
+	fix = auditFix(selectAllRule, "select * from exist_db.exist_tb_1 where id = 1")
+	assert.NotNil(t, fix)
+	assert.Equal(t, "SELECT `id`,`v1`,`v2` FROM `exist_db`.`exist_tb_1` WHERE `id`=1", fix.RewrittenSQL)
+	fix = auditFix(selectAllRule, "select t1.*, t2.v1 from exist_db.exist_tb_1 t1 join exist_db.exist_tb_2 t2 on t1.id = t2.id")
+	assert.NotNil(t, fix)
+	assert.Equal(t, "SELECT `t1`.`id`,`t1`.`v1`,`t1`.`v2`,`t2`.`v1` FROM `exist_db`.`exist_tb_1` AS `t1` JOIN `exist_db`.`exist_tb_2` AS `t2` ON `t1`.`id`=`t2`.`id`", fix.RewrittenSQL)
+	assert.Nil(t, auditFix(selectAllRule, "select * from exist_db.not_exist_tb_1"))
+
+	pkRule := rulepkg.RuleHandlerMap[rulepkg.DDLCheckPKNotExist].Rule
+	fix = auditFix(pkRule, "create table exist_db.not_exist_tb_1 (v1 varchar(255))")
+	assert.NotNil(t, fix)
+	assert.Equal(t, "CREATE TABLE `exist_db`.`not_exist_tb_1` (`id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,`v1` VARCHAR(255))", fix.RewrittenSQL)
+	fix = auditFix(pkRule, "create table exist_db.not_exist_tb_1 (id bigint unsigned not null, v1 varchar(255))")
+	assert.NotNil(t, fix)
+	assert.Equal(t, "CREATE TABLE `exist_db`.`not_exist_tb_1` (`id` BIGINT UNSIGNED NOT NULL,`v1` VARCHAR(255),PRIMARY KEY(`id`))", fix.RewrittenSQL)
+}
+
 func TestCheckWhereInvalid(t *testing.T) {
 	runDefaultRulesInspectCase(t, "select_count: has where condition", DefaultMysqlInspect(),
 		"select count(*) from exist_db.exist_tb_1 where id = 1",
@@ -0,0 +1,302 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/driver"
+
+	"github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/pkg/errors"
+)
+
+func (i *MysqlDriverImpl) IsBinlogRollbackEnabled() bool {
+	return !i.IsOfflineAudit() && i.cnf.dmlRollbackByBinlog
+}
+
+func (i *MysqlDriverImpl) GetBinlogPosition(ctx context.Context) (*driver.BinlogPosition, string, error) {
+	conn, err := i.getDbConn()
+	if err != nil {
+		return nil, "", err
+	}
+	records, err := conn.Db.Query("SHOW MASTER STATUS")
+	if err != nil {
+		// SHOW MASTER STATUS is removed since MySQL 8.4, it is replaced by SHOW BINARY LOG STATUS.
+		records, err = conn.Db.Query("SHOW BINARY LOG STATUS")
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if len(records) == 0 {
+		return nil, "", fmt.Errorf("binlog is not enabled")
+	}
+	pos, err := strconv.ParseInt(records[0]["Position"].String, 10, 64)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "parse binlog position")
+	}
+	return &driver.BinlogPosition{
+		File: records[0]["File"].String,
+		Pos:  pos,
+	}, conn.Db.GetConnectionID(), nil
+}
+
+// GenRollbackSQLByBinlog reads binlog as a replication client. The row events of a transaction are
+// selected if the BEGIN query event of transaction is written by the connection. It requires
+// binlog_format=ROW and binlog_row_image=FULL.
+func (i *MysqlDriverImpl) GenRollbackSQLByBinlog(ctx context.Context, start, end *driver.BinlogPosition, connectionId string) ([]string, error) {
+	// nothing is written between the positions, the binlog sync will wait for the next event forever.
+	if *start == *end {
+		return nil, nil
+	}
+	port, err := strconv.ParseUint(i.inst.Port, 10, 16)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse port")
+	}
+	location, err := i.getSessionLocation()
+	if err != nil {
+		return nil, err
+	}
+	syncer := replication.NewBinlogSyncer(replication.BinlogSyncerConfig{
+		// the server id should be unique in the replication topology.
+		ServerID:   uint32(rand.Int31n(1<<30)) + 1<<30,
+		Flavor:     mysql.MySQLFlavor,
+		Host:       i.inst.Host,
+		Port:       uint16(port),
+		User:       i.inst.User,
+		Password:   i.inst.Password,
+		UseDecimal: true,
+		// TIMESTAMP is stored as UTC in binlog, it is formatted in the time zone of session,
+		// otherwise the local time zone of SQLE is used and the rollback SQL is wrong.
+		TimestampStringLocation: location,
+	})
+	defer syncer.Close()
+
+	streamer, err := syncer.StartSync(mysql.Position{Name: start.File, Pos: uint32(start.Pos)})
+	if err != nil {
+		return nil, errors.Wrap(err, "start binlog sync")
+	}
+	endPos := mysql.Position{Name: end.File, Pos: uint32(end.Pos)}
+	currentFile := start.File
+	selected := false
+	tables := map[string]*binlogTable{}
+	sqls := []string{}
+	for {
+		ev, err := streamer.GetEvent(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "read binlog event")
+		}
+		switch e := ev.Event.(type) {
+		case *replication.RotateEvent:
+			currentFile = string(e.NextLogName)
+		case *replication.QueryEvent:
+			if string(e.Query) == "BEGIN" {
+				selected = strconv.FormatUint(uint64(e.SlaveProxyID), 10) == connectionId
+			}
+		case *replication.XIDEvent:
+			selected = false
+		case *replication.RowsEvent:
+			if !selected {
+				break
+			}
+			key := fmt.Sprintf("%s.%s", e.Table.Schema, e.Table.Table)
+			table, ok := tables[key]
+			if !ok {
+				table, err = i.getBinlogTable(string(e.Table.Schema), string(e.Table.Table))
+				if err != nil {
+					return nil, err
+				}
+				tables[key] = table
+			}
+			rollbackSQLs, err := table.genRollbackSQLByRowsEvent(ev.Header.EventType, e.Rows)
+			if err != nil {
+				return nil, err
+			}
+			sqls = append(sqls, rollbackSQLs...)
+		}
+		// the fake rotate event sent at the beginning has no position.
+		if ev.Header.LogPos > 0 && (mysql.Position{Name: currentFile, Pos: ev.Header.LogPos}).Compare(endPos) >= 0 {
+			break
+		}
+	}
+
+	// revert the events in reverse order
+	for l, r := 0, len(sqls)-1; l < r; l, r = l+1, r-1 {
+		sqls[l], sqls[r] = sqls[r], sqls[l]
+	}
+	return sqls, nil
+}
+
+// getSessionLocation returns the time zone of session by its offset to UTC, the rollback SQL is
+// executed in a session with the same time zone.
+func (i *MysqlDriverImpl) getSessionLocation() (*time.Location, error) {
+	conn, err := i.getDbConn()
+	if err != nil {
+		return nil, err
+	}
+	records, err := conn.Db.Query("SELECT TIME_TO_SEC(TIMEDIFF(NOW(), UTC_TIMESTAMP())) AS offset")
+	if err != nil {
+		return nil, errors.Wrap(err, "get time zone of session")
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("time zone of session not found")
+	}
+	offset, err := strconv.ParseFloat(records[0]["offset"].String, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse time zone of session")
+	}
+	return time.FixedZone("", int(offset)), nil
+}
+
+// binlogTable is the table definition used to generate SQL from row event, the row event
+// only contains the values of columns in the order of the table definition.
+type binlogTable struct {
+	name    string
+	columns []string
+	// dataTypes is used to convert the signed value of unsigned integer column in row event.
+	dataTypes []string
+	unsigned  []bool
+	pk        []int
+}
+
+func (i *MysqlDriverImpl) getBinlogTable(schema, table string) (*binlogTable, error) {
+	conn, err := i.getDbConn()
+	if err != nil {
+		return nil, err
+	}
+	records, err := conn.Db.Query("SELECT COLUMN_NAME, DATA_TYPE, COLUMN_TYPE, COLUMN_KEY FROM information_schema.COLUMNS "+
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION", schema, table)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("table %s.%s not exist", schema, table)
+	}
+	t := &binlogTable{name: fmt.Sprintf("`%s`.`%s`", schema, table)}
+	for n, record := range records {
+		t.columns = append(t.columns, record["COLUMN_NAME"].String)
+		t.dataTypes = append(t.dataTypes, strings.ToLower(record["DATA_TYPE"].String))
+		t.unsigned = append(t.unsigned, strings.Contains(strings.ToLower(record["COLUMN_TYPE"].String), "unsigned"))
+		if record["COLUMN_KEY"].String == "PRI" {
+			t.pk = append(t.pk, n)
+		}
+	}
+	return t, nil
+}
+
+func (t *binlogTable) genRollbackSQLByRowsEvent(eventType replication.EventType, rows [][]interface{}) ([]string, error) {
+	for _, row := range rows {
+		if len(row) != len(t.columns) {
+			return nil, fmt.Errorf("the columns of row event in table %s do not match the table definition, binlog_row_image should be FULL", t.name)
+		}
+	}
+	sqls := []string{}
+	switch eventType {
+	case replication.WRITE_ROWS_EVENTv0, replication.WRITE_ROWS_EVENTv1, replication.WRITE_ROWS_EVENTv2:
+		for _, row := range rows {
+			sqls = append(sqls, fmt.Sprintf("DELETE FROM %s WHERE %s LIMIT 1;", t.name, t.where(row)))
+		}
+	case replication.DELETE_ROWS_EVENTv0, replication.DELETE_ROWS_EVENTv1, replication.DELETE_ROWS_EVENTv2:
+		for _, row := range rows {
+			sqls = append(sqls, fmt.Sprintf("INSERT INTO %s (`%s`) VALUES (%s);", t.name,
+				strings.Join(t.columns, "`, `"), strings.Join(t.values(row), ", ")))
+		}
+	case replication.UPDATE_ROWS_EVENTv0, replication.UPDATE_ROWS_EVENTv1, replication.UPDATE_ROWS_EVENTv2:
+		// the rows of update event are the pairs of before image and after image.
+		for n := 0; n+1 < len(rows); n += 2 {
+			before, after := rows[n], rows[n+1]
+			values := t.values(before)
+			sets := []string{}
+			for c, name := range t.columns {
+				sets = append(sets, fmt.Sprintf("`%s` = %s", name, values[c]))
+			}
+			sqls = append(sqls, fmt.Sprintf("UPDATE %s SET %s WHERE %s LIMIT 1;", t.name,
+				strings.Join(sets, ", "), t.where(after)))
+		}
+	}
+	return sqls, nil
+}
+
+// where returns the condition of row by primary key, all the columns are used if there is no primary key.
+func (t *binlogTable) where(row []interface{}) string {
+	columns := t.pk
+	if len(columns) == 0 {
+		for c := range t.columns {
+			columns = append(columns, c)
+		}
+	}
+	values := t.values(row)
+	conditions := []string{}
+	for _, c := range columns {
+		if row[c] == nil {
+			conditions = append(conditions, fmt.Sprintf("`%s` IS NULL", t.columns[c]))
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf("`%s` = %s", t.columns[c], values[c]))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+func (t *binlogTable) values(row []interface{}) []string {
+	values := make([]string, 0, len(row))
+	for c, v := range row {
+		values = append(values, binlogValueFormat(v, t.dataTypes[c], t.unsigned[c]))
+	}
+	return values
+}
+
+var integerBits = map[string]uint{
+	"tinyint":   8,
+	"smallint":  16,
+	"mediumint": 24,
+	"int":       32,
+	"bigint":    64,
+}
+
+// binlogValueFormat formats the value of row event to SQL literal. The integer is decoded
+// as signed value from row event, so the value of unsigned column should be converted.
+func binlogValueFormat(v interface{}, dataType string, unsigned bool) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case int8, int16, int32, int64:
+		i := signedInt(v)
+		if bits, ok := integerBits[dataType]; ok && unsigned && i < 0 {
+			if bits == 64 {
+				return strconv.FormatUint(uint64(i), 10)
+			}
+			return strconv.FormatInt(i+1<<bits, 10)
+		}
+		return strconv.FormatInt(i, 10)
+	case uint8, uint16, uint32, uint64, int, uint, float32, float64:
+		return fmt.Sprint(v)
+	case []byte:
+		return quoteString(string(v))
+	default:
+		return quoteString(fmt.Sprint(v))
+	}
+}
+
+func signedInt(v interface{}) int64 {
+	switch v := v.(type) {
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	}
+	return 0
+}
+
+func quoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return fmt.Sprintf("'%s'", s)
+}
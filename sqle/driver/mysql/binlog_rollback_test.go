@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"bytes"
+	"encoding/binary"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinlogTable_GenRollbackSQLByRowsEvent(t *testing.T) {
+	table := &binlogTable{
+		name:      "`exist_db`.`exist_tb_1`",
+		columns:   []string{"id", "v1", "v2"},
+		dataTypes: []string{"bigint", "varchar", "int"},
+		unsigned:  []bool{true, false, true},
+		pk:        []int{0},
+	}
+
+	sqls, err := table.genRollbackSQLByRowsEvent(replication.WRITE_ROWS_EVENTv2, [][]interface{}{
+		{int64(1), "a", int32(1)},
+		{int64(-1), []byte("it's"), nil},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"DELETE FROM `exist_db`.`exist_tb_1` WHERE `id` = 1 LIMIT 1;",
+		"DELETE FROM `exist_db`.`exist_tb_1` WHERE `id` = 18446744073709551615 LIMIT 1;",
+	}, sqls)
+
+	sqls, err = table.genRollbackSQLByRowsEvent(replication.DELETE_ROWS_EVENTv2, [][]interface{}{
+		{int64(1), []byte("it's"), int32(-1)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"INSERT INTO `exist_db`.`exist_tb_1` (`id`, `v1`, `v2`) VALUES (1, 'it\\'s', 4294967295);",
+	}, sqls)
+
+	sqls, err = table.genRollbackSQLByRowsEvent(replication.UPDATE_ROWS_EVENTv2, [][]interface{}{
+		{int64(1), "a", nil}, {int64(2), "b", int32(2)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"UPDATE `exist_db`.`exist_tb_1` SET `id` = 1, `v1` = 'a', `v2` = NULL WHERE `id` = 2 LIMIT 1;",
+	}, sqls)
+
+	// the table without primary key is matched by all columns
+	table.pk = nil
+	sqls, err = table.genRollbackSQLByRowsEvent(replication.WRITE_ROWS_EVENTv1, [][]interface{}{
+		{int64(1), "a", nil},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"DELETE FROM `exist_db`.`exist_tb_1` WHERE `id` = 1 AND `v1` = 'a' AND `v2` IS NULL LIMIT 1;",
+	}, sqls)
+
+	// binlog_row_image is not FULL
+	_, err = table.genRollbackSQLByRowsEvent(replication.DELETE_ROWS_EVENTv2, [][]interface{}{{int64(1)}})
+	assert.Error(t, err)
+}
+
+// binlogEvent returns the binlog event with header, the event is written by server 1.
+func binlogEvent(eventType replication.EventType, body []byte) []byte {
+	header := make([]byte, replication.EventHeaderSize)
+	header[4] = byte(eventType)
+	binary.LittleEndian.PutUint32(header[5:], 1)
+	binary.LittleEndian.PutUint32(header[9:], uint32(replication.EventHeaderSize+len(body)))
+	return append(header, body...)
+}
+
+func TestBinlogTable_GenRollbackSQLByRowsEventWithTimestamp(t *testing.T) {
+	inspect, handler := newRollbackMockInspect(t)
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT TIME_TO_SEC(TIMEDIFF(NOW(), UTC_TIMESTAMP())) AS offset")).
+		WillReturnRows(sqlmock.NewRows([]string{"offset"}).AddRow("28800"))
+	location, err := inspect.getSessionLocation()
+	assert.NoError(t, err)
+	assert.NoError(t, handler.ExpectationsWereMet())
+
+	parser := replication.NewBinlogParser()
+	parser.SetTimestampStringLocation(location)
+
+	// the format description event of MySQL 5.0 without checksum, the post header of
+	// rows events is 8 bytes, so the table id is 6 bytes.
+	format := []byte{4, 0}
+	format = append(format, make([]byte, 50)...)
+	copy(format[2:], "5.0.0")
+	format = append(format, 0, 0, 0, 0, replication.EventHeaderSize)
+	format = append(format, bytes.Repeat([]byte{8}, 40)...)
+	_, err = parser.Parse(binlogEvent(replication.FORMAT_DESCRIPTION_EVENT, format))
+	assert.NoError(t, err)
+
+	// CREATE TABLE db1.t1 (id INT, created_at TIMESTAMP)
+	tableMap := []byte{1, 0, 0, 0, 0, 0, 0, 0}
+	tableMap = append(tableMap, 3, 'd', 'b', '1', 0, 2, 't', '1', 0)
+	tableMap = append(tableMap, 2, mysql.MYSQL_TYPE_LONG, mysql.MYSQL_TYPE_TIMESTAMP2, 1, 0, 0)
+	_, err = parser.Parse(binlogEvent(replication.TABLE_MAP_EVENT, tableMap))
+	assert.NoError(t, err)
+
+	// INSERT INTO db1.t1 VALUES (1, '2024-01-01 08:00:00') in time zone +08:00
+	rows := []byte{1, 0, 0, 0, 0, 0, 1, 0, 2, 0, 2, 0x03, 0x00, 1, 0, 0, 0}
+	rows = binary.BigEndian.AppendUint32(rows, uint32(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()))
+	ev, err := parser.Parse(binlogEvent(replication.WRITE_ROWS_EVENTv2, rows))
+	assert.NoError(t, err)
+
+	table := &binlogTable{
+		name:      "`db1`.`t1`",
+		columns:   []string{"id", "created_at"},
+		dataTypes: []string{"int", "timestamp"},
+		unsigned:  []bool{false, false},
+	}
+	sqls, err := table.genRollbackSQLByRowsEvent(ev.Header.EventType, ev.Event.(*replication.RowsEvent).Rows)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"DELETE FROM `db1`.`t1` WHERE `id` = 1 AND `created_at` = '2024-01-01 08:00:00' LIMIT 1;",
+	}, sqls)
+}
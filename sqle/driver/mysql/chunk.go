@@ -0,0 +1,206 @@
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/driver/mysql/util"
+
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+	_model "github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/opcode"
+)
+
+// dmlChunkTarget is the single table DML which can be executed in chunks ranged by primary key.
+type dmlChunkTarget struct {
+	stmt      ast.StmtNode
+	tableRefs *ast.TableRefsClause
+	alias     string
+	pk        string
+	where     ast.ExprNode
+	setWhere  func(where ast.ExprNode)
+}
+
+// getDMLChunkTarget returns nil if the DML can not be executed in chunks, only the single table
+// DML without ORDER BY and LIMIT on the table with single column primary key can be executed in
+// chunks, and the primary key can not be updated.
+func (i *MysqlDriverImpl) getDMLChunkTarget(sql string) (*dmlChunkTarget, error) {
+	nodes, err := i.ParseSql(sql)
+	if err != nil {
+		return nil, err
+	}
+	target := &dmlChunkTarget{}
+	var assignments []*ast.Assignment
+	switch stmt := nodes[0].(type) {
+	case *ast.DeleteStmt:
+		if stmt.IsMultiTable || stmt.Order != nil || stmt.Limit != nil {
+			return nil, nil
+		}
+		target.stmt, target.tableRefs, target.where = stmt, stmt.TableRefs, stmt.Where
+		target.setWhere = func(where ast.ExprNode) { stmt.Where = where }
+	case *ast.UpdateStmt:
+		if stmt.Order != nil || stmt.Limit != nil {
+			return nil, nil
+		}
+		target.stmt, target.tableRefs, target.where = stmt, stmt.TableRefs, stmt.Where
+		target.setWhere = func(where ast.ExprNode) { stmt.Where = where }
+		assignments = stmt.List
+	default:
+		return nil, nil
+	}
+	if target.tableRefs == nil || target.tableRefs.TableRefs == nil || target.tableRefs.TableRefs.Right != nil {
+		return nil, nil
+	}
+	tableSources := util.GetTableSources(target.tableRefs.TableRefs)
+	if len(tableSources) != 1 {
+		return nil, nil
+	}
+	table, ok := tableSources[0].Source.(*ast.TableName)
+	if !ok {
+		return nil, nil
+	}
+	target.alias = tableSources[0].AsName.String()
+
+	createTableStmt, exist, err := i.Ctx.GetCreateTableStmt(table)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	pks, hasPk := util.GetPrimaryKey(createTableStmt)
+	if !hasPk || len(pks) != 1 {
+		return nil, nil
+	}
+	for pk := range pks {
+		target.pk = pk
+	}
+	for _, assignment := range assignments {
+		if assignment.Column.Name.L == target.pk {
+			return nil, nil
+		}
+	}
+	return target, nil
+}
+
+func (t *dmlChunkTarget) pkColumn() *ast.ColumnNameExpr {
+	return &ast.ColumnNameExpr{Name: &ast.ColumnName{
+		Table: _model.NewCIStr(t.alias),
+		Name:  _model.NewCIStr(t.pk),
+	}}
+}
+
+// chunkWhere returns the where condition of DML limited by the range (lower, upper].
+func (t *dmlChunkTarget) chunkWhere(chunk *driver.DMLChunk) ast.ExprNode {
+	exprs := []ast.ExprNode{}
+	if t.where != nil {
+		exprs = append(exprs, &ast.ParenthesesExpr{Expr: t.where})
+	}
+	if chunk.HasLowerBound {
+		exprs = append(exprs, &ast.BinaryOperationExpr{Op: opcode.GT, L: t.pkColumn(), R: ast.NewValueExpr(chunk.LowerBound, "", "")})
+	}
+	if chunk.HasUpperBound {
+		exprs = append(exprs, &ast.BinaryOperationExpr{Op: opcode.LE, L: t.pkColumn(), R: ast.NewValueExpr(chunk.UpperBound, "", "")})
+	}
+	if len(exprs) == 0 {
+		return nil
+	}
+	where := exprs[0]
+	for _, expr := range exprs[1:] {
+		where = &ast.BinaryOperationExpr{Op: opcode.LogicAnd, L: where, R: expr}
+	}
+	return where
+}
+
+func restoreNode(node ast.Node) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, buf)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (i *MysqlDriverImpl) GetDMLChunkConfig(ctx context.Context, sql string) (*driver.DMLChunkConfig, error) {
+	if i.IsOfflineAudit() || !i.cnf.dmlChunkEnabled || i.cnf.dmlChunkSize <= 0 {
+		return nil, nil
+	}
+	target, err := i.getDMLChunkTarget(sql)
+	if err != nil || target == nil {
+		return nil, err
+	}
+	conn, err := i.getDbConn()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := util.GetAffectedRowNum(ctx, sql, conn)
+	if err != nil {
+		return nil, err
+	}
+	if rows <= i.cnf.dmlChunkMinRows {
+		return nil, nil
+	}
+	return &driver.DMLChunkConfig{
+		ChunkSize:            i.cnf.dmlChunkSize,
+		SleepMillis:          i.cnf.dmlChunkSleepMillis,
+		MaxReplicaLagSeconds: i.cnf.dmlChunkMaxReplicaLag,
+	}, nil
+}
+
+// NextDMLChunk selects the primary key of the last row of next chunk as the upper bound, the
+// chunk is the last chunk if there are not enough rows.
+func (i *MysqlDriverImpl) NextDMLChunk(ctx context.Context, sql string, chunkSize int64, prev *driver.DMLChunk) (*driver.DMLChunk, error) {
+	target, err := i.getDMLChunkTarget(sql)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, fmt.Errorf("the DML can not be executed in chunks")
+	}
+	chunk := &driver.DMLChunk{}
+	if prev != nil {
+		if !prev.HasUpperBound {
+			return nil, fmt.Errorf("the previous chunk is the last chunk")
+		}
+		chunk.HasLowerBound, chunk.LowerBound = true, prev.UpperBound
+	}
+
+	pk, err := restoreNode(target.pkColumn())
+	if err != nil {
+		return nil, err
+	}
+	tableRefs, err := restoreNode(target.tableRefs)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("SELECT %s AS chunk_bound FROM %s", pk, tableRefs)
+	if where := target.chunkWhere(chunk); where != nil {
+		cond, err := restoreNode(where)
+		if err != nil {
+			return nil, err
+		}
+		query = fmt.Sprintf("%s WHERE %s", query, cond)
+	}
+	query = fmt.Sprintf("%s ORDER BY %s LIMIT 1 OFFSET %d", query, pk, chunkSize-1)
+
+	conn, err := i.getDbConn()
+	if err != nil {
+		return nil, err
+	}
+	records, err := conn.Db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 {
+		chunk.HasUpperBound, chunk.UpperBound = true, records[0]["chunk_bound"].String
+	}
+
+	target.setWhere(target.chunkWhere(chunk))
+	chunk.SQL, err = restoreNode(target.stmt)
+	if err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDMLChunkTarget(t *testing.T) {
+	inspect := DefaultMysqlInspect()
+	for _, sql := range []string{
+		"DELETE FROM exist_db.exist_tb_1 WHERE v1 = 'a'",
+		"UPDATE exist_db.exist_tb_1 AS t SET v2 = 'b' WHERE v1 = 'a'",
+	} {
+		target, err := inspect.getDMLChunkTarget(sql)
+		assert.NoError(t, err)
+		assert.NotNil(t, target, sql)
+		assert.Equal(t, "id", target.pk)
+	}
+	for _, sql := range []string{
+		"DELETE FROM exist_db.exist_tb_1 WHERE v1 = 'a' LIMIT 10",
+		"DELETE FROM exist_db.exist_tb_1 WHERE v1 = 'a' ORDER BY id",
+		"UPDATE exist_db.exist_tb_1 SET id = id + 1 WHERE v1 = 'a'",
+		"UPDATE exist_db.exist_tb_1 AS t1 JOIN exist_db.exist_tb_2 AS t2 ON t1.id = t2.id SET t1.v1 = 'a'",
+		"DELETE t1 FROM exist_db.exist_tb_1 AS t1 JOIN exist_db.exist_tb_2 AS t2 ON t1.id = t2.id",
+		"INSERT INTO exist_db.exist_tb_1 VALUES (1, 'a', 'b')",
+	} {
+		target, err := inspect.getDMLChunkTarget(sql)
+		assert.NoError(t, err)
+		assert.Nil(t, target, sql)
+	}
+}
+
+func TestNextDMLChunk(t *testing.T) {
+	inspect, handler := newRollbackMockInspect(t)
+	sql := "DELETE FROM exist_db.exist_tb_1 WHERE v1 = 'a'"
+
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT `id` AS chunk_bound FROM `exist_db`.`exist_tb_1` WHERE (`v1`='a') ORDER BY `id` LIMIT 1 OFFSET 99")).
+		WillReturnRows(sqlmock.NewRows([]string{"chunk_bound"}).AddRow("100"))
+	first, err := inspect.NextDMLChunk(context.TODO(), sql, 100, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM `exist_db`.`exist_tb_1` WHERE (`v1`='a') AND `id`<='100'", first.SQL)
+	assert.False(t, first.HasLowerBound)
+	assert.True(t, first.HasUpperBound)
+
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT `id` AS chunk_bound FROM `exist_db`.`exist_tb_1` WHERE (`v1`='a') AND `id`>'100' ORDER BY `id` LIMIT 1 OFFSET 99")).
+		WillReturnRows(sqlmock.NewRows([]string{"chunk_bound"}))
+	last, err := inspect.NextDMLChunk(context.TODO(), sql, 100, first)
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM `exist_db`.`exist_tb_1` WHERE (`v1`='a') AND `id`>'100'", last.SQL)
+	assert.True(t, last.HasLowerBound)
+	assert.False(t, last.HasUpperBound)
+
+	_, err = inspect.NextDMLChunk(context.TODO(), sql, 100, last)
+	assert.Error(t, err)
+	assert.NoError(t, handler.ExpectationsWereMet())
+}
+
+func TestNextDMLChunkWithAlias(t *testing.T) {
+	inspect, handler := newRollbackMockInspect(t)
+	sql := "UPDATE exist_db.exist_tb_1 AS t SET t.v2 = 'b'"
+
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT `t`.`id` AS chunk_bound FROM `exist_db`.`exist_tb_1` AS `t` ORDER BY `t`.`id` LIMIT 1 OFFSET 9")).
+		WillReturnRows(sqlmock.NewRows([]string{"chunk_bound"}).AddRow("10"))
+	chunk, err := inspect.NextDMLChunk(context.TODO(), sql, 10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `exist_db`.`exist_tb_1` AS `t` SET `t`.`v2`='b' WHERE `t`.`id`<='10'", chunk.SQL)
+	assert.NoError(t, handler.ExpectationsWereMet())
+}
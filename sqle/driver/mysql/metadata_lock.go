@@ -0,0 +1,160 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/actiontech/sqle/sqle/driver"
+
+	"github.com/pingcap/parser/ast"
+)
+
+const (
+	paramKeyMetadataLockCheckPolicy         = "mdl_check_policy"
+	paramKeyMetadataLockCheckMaxWaitSeconds = "mdl_check_max_wait_seconds"
+	paramKeyMetadataLockCheckMinTrxSeconds  = "mdl_check_min_trx_seconds"
+)
+
+// GetMetadataLockCheckConfig reads the config from the additional params of instance, the metadata
+// lock is not checked if the policy is empty.
+func (i *MysqlDriverImpl) GetMetadataLockCheckConfig(ctx context.Context) (*driver.MetadataLockCheckConfig, error) {
+	if i.IsOfflineAudit() {
+		return nil, nil
+	}
+	policy := driver.MetadataLockCheckPolicy(i.inst.AdditionalParams.GetParam(paramKeyMetadataLockCheckPolicy).String())
+	switch policy {
+	case "":
+		return nil, nil
+	case driver.MetadataLockCheckPolicyRefuse, driver.MetadataLockCheckPolicyWait, driver.MetadataLockCheckPolicyPrompt:
+	default:
+		return nil, fmt.Errorf("invalid %s %q, it should be %s, %s or %s", paramKeyMetadataLockCheckPolicy, policy,
+			driver.MetadataLockCheckPolicyRefuse, driver.MetadataLockCheckPolicyWait, driver.MetadataLockCheckPolicyPrompt)
+	}
+	return &driver.MetadataLockCheckConfig{
+		Policy:         policy,
+		MaxWaitSeconds: int64(i.inst.AdditionalParams.GetParam(paramKeyMetadataLockCheckMaxWaitSeconds).Int()),
+	}, nil
+}
+
+// getDDLTargetTables returns the tables which the DDL acquires exclusive metadata lock on.
+func getDDLTargetTables(node ast.Node) []*ast.TableName {
+	switch stmt := node.(type) {
+	case *ast.AlterTableStmt:
+		return []*ast.TableName{stmt.Table}
+	case *ast.DropTableStmt:
+		return stmt.Tables
+	case *ast.TruncateTableStmt:
+		return []*ast.TableName{stmt.Table}
+	case *ast.RenameTableStmt:
+		tables := []*ast.TableName{}
+		for _, t := range stmt.TableToTables {
+			tables = append(tables, t.OldTable)
+		}
+		return tables
+	case *ast.CreateIndexStmt:
+		return []*ast.TableName{stmt.Table}
+	case *ast.DropIndexStmt:
+		return []*ast.TableName{stmt.Table}
+	default:
+		return nil
+	}
+}
+
+// GetMetadataLockBlockers finds the sessions holding the metadata lock of tables by
+// performance_schema.metadata_locks, the sessions which are running for less than the min
+// seconds are ignored. If performance_schema is not available or the metadata lock instrument
+// is not enabled, which is the default of MySQL 5.7, the long-running transactions in
+// information_schema.innodb_trx are considered as blockers, since it is unknown which tables
+// they touch.
+func (i *MysqlDriverImpl) GetMetadataLockBlockers(ctx context.Context, sql string) ([]*driver.MetadataLockBlocker, error) {
+	if i.IsOfflineAudit() {
+		return nil, nil
+	}
+	nodes, err := i.ParseSql(sql)
+	if err != nil {
+		return nil, err
+	}
+	tables := getDDLTargetTables(nodes[0])
+	if len(tables) == 0 {
+		return nil, nil
+	}
+	minSeconds := int64(i.inst.AdditionalParams.GetParam(paramKeyMetadataLockCheckMinTrxSeconds).Int())
+	enabled, err := i.isMetadataLockInstrumentEnabled(ctx)
+	if err != nil {
+		i.log.Warnf("check metadata lock instrument error: %v, check long-running transactions instead", err)
+		return i.getLongRunningTrxs(ctx, minSeconds)
+	}
+	if !enabled {
+		i.log.Warnf("instrument %s is not enabled, check long-running transactions instead", metadataLockInstrument)
+		return i.getLongRunningTrxs(ctx, minSeconds)
+	}
+
+	blockers := []*driver.MetadataLockBlocker{}
+	for _, table := range tables {
+		schema := i.Ctx.GetSchemaName(table)
+		records, err := i.query(ctx, "SELECT t.PROCESSLIST_ID AS connection_id, t.PROCESSLIST_USER AS user, "+
+			"t.PROCESSLIST_HOST AS host, ml.LOCK_TYPE AS lock_type, t.PROCESSLIST_INFO AS info, "+
+			"COALESCE(TIMESTAMPDIFF(SECOND, trx.trx_started, NOW()), t.PROCESSLIST_TIME) AS elapsed_seconds "+
+			"FROM performance_schema.metadata_locks AS ml "+
+			"JOIN performance_schema.threads AS t ON ml.OWNER_THREAD_ID = t.THREAD_ID "+
+			"LEFT JOIN information_schema.innodb_trx AS trx ON trx.trx_mysql_thread_id = t.PROCESSLIST_ID "+
+			"WHERE ml.OBJECT_TYPE = 'TABLE' AND ml.LOCK_STATUS = 'GRANTED' AND ml.OBJECT_SCHEMA = ? "+
+			"AND ml.OBJECT_NAME = ? AND t.PROCESSLIST_ID <> CONNECTION_ID()", schema, table.Name.String())
+		if err != nil {
+			i.log.Warnf("get metadata locks from performance_schema error: %v, check long-running transactions instead", err)
+			return i.getLongRunningTrxs(ctx, minSeconds)
+		}
+		for _, record := range records {
+			elapsed := parseNullInt64(record["elapsed_seconds"])
+			if elapsed < minSeconds {
+				continue
+			}
+			blockers = append(blockers, &driver.MetadataLockBlocker{
+				ConnectionId:   record["connection_id"].String,
+				User:           record["user"].String,
+				Host:           record["host"].String,
+				Table:          fmt.Sprintf("%s.%s", schema, table.Name.String()),
+				LockType:       record["lock_type"].String,
+				ElapsedSeconds: elapsed,
+				SQL:            record["info"].String,
+			})
+		}
+	}
+	return blockers, nil
+}
+
+// metadataLockInstrument is the instrument of performance_schema which records the metadata locks
+// in performance_schema.metadata_locks, it is disabled by default before MySQL 8.0.
+const metadataLockInstrument = "wait/lock/metadata/sql/mdl"
+
+func (i *MysqlDriverImpl) isMetadataLockInstrumentEnabled(ctx context.Context) (bool, error) {
+	records, err := i.query(ctx, "SELECT ENABLED AS enabled FROM performance_schema.setup_instruments WHERE NAME = ?",
+		metadataLockInstrument)
+	if err != nil {
+		return false, err
+	}
+	return len(records) > 0 && strings.EqualFold(records[0]["enabled"].String, "YES"), nil
+}
+
+func (i *MysqlDriverImpl) getLongRunningTrxs(ctx context.Context, minSeconds int64) ([]*driver.MetadataLockBlocker, error) {
+	records, err := i.query(ctx, "SELECT trx.trx_mysql_thread_id AS connection_id, p.USER AS user, p.HOST AS host, "+
+		"p.INFO AS info, TIMESTAMPDIFF(SECOND, trx.trx_started, NOW()) AS elapsed_seconds "+
+		"FROM information_schema.innodb_trx AS trx "+
+		"LEFT JOIN information_schema.PROCESSLIST AS p ON p.ID = trx.trx_mysql_thread_id "+
+		"WHERE trx.trx_mysql_thread_id <> CONNECTION_ID() AND trx.trx_started <= NOW() - INTERVAL ? SECOND", minSeconds)
+	if err != nil {
+		return nil, err
+	}
+	blockers := make([]*driver.MetadataLockBlocker, 0, len(records))
+	for _, record := range records {
+		blockers = append(blockers, &driver.MetadataLockBlocker{
+			ConnectionId:   record["connection_id"].String,
+			User:           record["user"].String,
+			Host:           record["host"].String,
+			ElapsedSeconds: parseNullInt64(record["elapsed_seconds"]),
+			SQL:            record["info"].String,
+		})
+	}
+	return blockers, nil
+}
@@ -0,0 +1,70 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/pkg/params"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetadataLockBlockers(t *testing.T) {
+	inspect, handler := newRollbackMockInspect(t)
+	inspect.inst.AdditionalParams = params.Params{
+		{Key: paramKeyMetadataLockCheckMinTrxSeconds, Value: "10", Type: params.ParamTypeInt},
+	}
+
+	// DML is not checked
+	blockers, err := inspect.GetMetadataLockBlockers(context.TODO(), "UPDATE exist_db.exist_tb_1 SET v1 = 'a'")
+	assert.NoError(t, err)
+	assert.Nil(t, blockers)
+
+	handler.ExpectQuery(regexp.QuoteMeta("FROM performance_schema.setup_instruments")).
+		WithArgs(metadataLockInstrument).
+		WillReturnRows(sqlmock.NewRows([]string{"enabled"}).AddRow("YES"))
+	handler.ExpectQuery(regexp.QuoteMeta("FROM performance_schema.metadata_locks AS ml")).
+		WithArgs("exist_db", "exist_tb_1").
+		WillReturnRows(sqlmock.NewRows([]string{"connection_id", "user", "host", "lock_type", "info", "elapsed_seconds"}).
+			AddRow("10", "app", "127.0.0.1", "SHARED_READ", nil, "100").
+			AddRow("11", "app", "127.0.0.1", "SHARED_WRITE", "UPDATE exist_tb_1 SET v1 = 'b'", "1"))
+	blockers, err = inspect.GetMetadataLockBlockers(context.TODO(), "ALTER TABLE exist_db.exist_tb_1 ADD COLUMN v3 INT")
+	assert.NoError(t, err)
+	assert.Len(t, blockers, 1)
+	assert.Equal(t, "10", blockers[0].ConnectionId)
+	assert.Equal(t, "exist_db.exist_tb_1", blockers[0].Table)
+	assert.Equal(t, int64(100), blockers[0].ElapsedSeconds)
+
+	// check long-running transactions if performance_schema is not available
+	handler.ExpectQuery(regexp.QuoteMeta("FROM performance_schema.setup_instruments")).
+		WithArgs(metadataLockInstrument).
+		WillReturnRows(sqlmock.NewRows([]string{"enabled"}).AddRow("YES"))
+	handler.ExpectQuery(regexp.QuoteMeta("FROM performance_schema.metadata_locks AS ml")).
+		WillReturnError(errors.New("performance_schema is disabled"))
+	handler.ExpectQuery(regexp.QuoteMeta("FROM information_schema.innodb_trx AS trx")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"connection_id", "user", "host", "info", "elapsed_seconds"}).
+			AddRow("12", "app", "127.0.0.1", nil, "3600"))
+	blockers, err = inspect.GetMetadataLockBlockers(context.TODO(), "DROP TABLE exist_db.exist_tb_1")
+	assert.NoError(t, err)
+	assert.Len(t, blockers, 1)
+	assert.Equal(t, "12", blockers[0].ConnectionId)
+	assert.Equal(t, "", blockers[0].Table)
+
+	// check long-running transactions if the metadata lock instrument is disabled, e.g. MySQL 5.7
+	handler.ExpectQuery(regexp.QuoteMeta("FROM performance_schema.setup_instruments")).
+		WithArgs(metadataLockInstrument).
+		WillReturnRows(sqlmock.NewRows([]string{"enabled"}).AddRow("NO"))
+	handler.ExpectQuery(regexp.QuoteMeta("FROM information_schema.innodb_trx AS trx")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"connection_id", "user", "host", "info", "elapsed_seconds"}).
+			AddRow("13", "app", "127.0.0.1", nil, "3600"))
+	blockers, err = inspect.GetMetadataLockBlockers(context.TODO(), "DROP TABLE exist_db.exist_tb_1")
+	assert.NoError(t, err)
+	assert.Len(t, blockers, 1)
+	assert.Equal(t, "13", blockers[0].ConnectionId)
+	assert.NoError(t, handler.ExpectationsWereMet())
+}
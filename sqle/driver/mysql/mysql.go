@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	_driver "database/sql/driver"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/actiontech/sqle/sqle/driver"
 	"github.com/actiontech/sqle/sqle/driver/mysql/executor"
@@ -46,6 +48,19 @@ type MysqlDriverImpl struct {
 	isConnected bool
 	// isOfflineAudit represent Audit without instance.
 	isOfflineAudit bool
+
+	// ghostMutex protects ghostExecutor.
+	ghostMutex sync.Mutex
+	// ghostExecutor is the running gh-ost, it is nil if no gh-ost running.
+	ghostExecutor *onlineddl.Executor
+
+	// monitorMutex protects monitorConn and replicaConns, as well as dbConn and isConnected
+	// which are read by monitor when they are changed by execution.
+	monitorMutex sync.Mutex
+	// monitorConn is used to get the progress of the statement running on dbConn.
+	monitorConn *executor.Executor
+	// replicaConns is used to get the lag of replicas, the key is the address of replica.
+	replicaConns map[string]*executor.Executor
 }
 
 func NewInspect(log *logrus.Entry, cfg *driverV2.Config) (*MysqlDriverImpl, error) {
@@ -103,6 +118,16 @@ func NewInspect(log *logrus.Entry, cfg *driverV2.Config) (*MysqlDriverImpl, erro
 		if rule.Name == rulepkg.ConfigSQLIsExecuted {
 			inspect.cnf.isExecutedSQL = true
 		}
+		if rule.Name == rulepkg.ConfigDMLRollbackByBinlog {
+			inspect.cnf.dmlRollbackByBinlog = true
+		}
+		if rule.Name == rulepkg.ConfigDMLChunkExecution {
+			inspect.cnf.dmlChunkEnabled = true
+			inspect.cnf.dmlChunkMinRows = int64(rule.Params.GetParam(rulepkg.DefaultMultiParamsFirstKeyName).Int())
+			inspect.cnf.dmlChunkSize = int64(rule.Params.GetParam(rulepkg.DefaultMultiParamsSecondKeyName).Int())
+			inspect.cnf.dmlChunkSleepMillis = int64(rule.Params.GetParam(rulepkg.DefaultMultiParamsThirdKeyName).Int())
+			inspect.cnf.dmlChunkMaxReplicaLag = int64(rule.Params.GetParam(rulepkg.DefaultMultiParamsFourthKeyName).Int())
+		}
 	}
 
 	return inspect, nil
@@ -137,6 +162,10 @@ func (i *MysqlDriverImpl) executeByGhost(ctx context.Context, query string, isDr
 		if err != nil {
 			return err
 		}
+		if !dryRun {
+			i.setGhostExecutor(executor)
+			defer i.setGhostExecutor(nil)
+		}
 
 		err = executor.Execute(ctx, dryRun)
 		if err != nil {
@@ -180,6 +209,9 @@ func (i *MysqlDriverImpl) Exec(ctx context.Context, query string) (_driver.Resul
 	if err != nil {
 		return nil, err
 	}
+	if err := i.setSessionTimeouts(conn); err != nil {
+		return nil, err
+	}
 	return conn.Db.Exec(query)
 }
 
@@ -214,6 +246,9 @@ func (i *MysqlDriverImpl) Tx(ctx context.Context, queries ...string) ([]_driver.
 	if err != nil {
 		return nil, err
 	}
+	if err := i.setSessionTimeouts(conn); err != nil {
+		return nil, err
+	}
 	return conn.Db.Transact(queries...)
 }
 
@@ -300,6 +335,10 @@ func (i *MysqlDriverImpl) Audit(ctx context.Context, sqls []string) ([]*driverV2
 	return results, nil
 }
 
+func (i *MysqlDriverImpl) AuditStream(ctx context.Context, source driver.AuditStreamSource, handler driver.AuditStreamHandler) error {
+	return driver.AuditInBatches(ctx, i.Audit, source, handler)
+}
+
 func (i *MysqlDriverImpl) audit(ctx context.Context, sql string) (*driverV2.AuditResults, error) {
 	i.result = driverV2.NewAuditResults()
 
@@ -449,6 +488,7 @@ func (i *MysqlDriverImpl) GenRollbackSQL(ctx context.Context, sql string) (strin
 
 func (i *MysqlDriverImpl) Close(ctx context.Context) {
 	i.closeDbConn()
+	i.closeMonitorConn()
 }
 
 func (i *MysqlDriverImpl) Ping(ctx context.Context) error {
@@ -474,6 +514,31 @@ func (i *MysqlDriverImpl) Schemas(ctx context.Context) ([]string, error) {
 	return conn.ShowDatabases(true)
 }
 
+func (i *MysqlDriverImpl) ExtractTableFromSQL(ctx context.Context, sql string) ([]*driverV2.Table, error) {
+	node, err := util.ParseOneSql(sql)
+	if err != nil {
+		return nil, err
+	}
+	extractor := util.TableNameExtractor{TableNames: map[string]*ast.TableName{}}
+	node.Accept(&extractor)
+
+	tables := make([]*driverV2.Table, 0, len(extractor.TableNames))
+	for _, table := range extractor.TableNames {
+		schema := table.Schema.O
+		if schema == "" && i.Ctx != nil {
+			schema = i.Ctx.CurrentSchema()
+		}
+		tables = append(tables, &driverV2.Table{
+			Name:   table.Name.O,
+			Schema: schema,
+		})
+	}
+	sort.Slice(tables, func(a, b int) bool {
+		return tables[a].Name < tables[b].Name
+	})
+	return tables, nil
+}
+
 func (i *MysqlDriverImpl) EstimateSQLAffectRows(ctx context.Context, sql string) (*driverV2.EstimatedAffectRows, error) {
 	if i.IsOfflineAudit() {
 		return nil, nil
@@ -507,6 +572,12 @@ type Config struct {
 	compositeIndexMaxColumn  int
 	indexSelectivityMinValue float64
 	isExecutedSQL            bool
+	dmlRollbackByBinlog      bool
+	dmlChunkEnabled          bool
+	dmlChunkMinRows          int64
+	dmlChunkSize             int64
+	dmlChunkSleepMillis      int64
+	dmlChunkMaxReplicaLag    int64
 }
 
 func (i *MysqlDriverImpl) Context() *session.Context {
@@ -540,8 +611,10 @@ func (i *MysqlDriverImpl) getDbConn() (*executor.Executor, error) {
 	}
 	conn, err := executor.NewExecutor(i.log, i.inst, i.Ctx.CurrentSchema())
 	if err == nil {
-		i.isConnected = true
+		i.monitorMutex.Lock()
 		i.dbConn = conn
+		i.isConnected = true
+		i.monitorMutex.Unlock()
 	}
 	return conn, err
 }
@@ -557,8 +630,10 @@ func (i *MysqlDriverImpl) GetDSN() *driverV2.DSN {
 // closeDbConn close db conn and just close once.
 func (i *MysqlDriverImpl) closeDbConn() {
 	if i.isConnected {
-		i.dbConn.Db.Close()
+		i.monitorMutex.Lock()
 		i.isConnected = false
+		i.monitorMutex.Unlock()
+		i.dbConn.Db.Close()
 	}
 }
 
@@ -597,11 +672,66 @@ func (p *PluginProcessor) GetDriverMetas() (*driverV2.DriverMetas, error) {
 		allRules[i] = &rulepkg.RuleHandlers[i].Rule
 	}
 	return &driverV2.DriverMetas{
-		PluginName:               driverV2.DriverTypeMySQL,
-		DatabaseDefaultPort:      3306,
-		Logo:                     logo,
-		Rules:                    allRules,
-		DatabaseAdditionalParams: params.Params{},
+		PluginName:          driverV2.DriverTypeMySQL,
+		DatabaseDefaultPort: 3306,
+		Logo:                logo,
+		Rules:               allRules,
+		DatabaseAdditionalParams: params.Params{
+			&params.Param{
+				Key:   paramKeyReplicaDSNs,
+				Value: "",
+				Desc:  "从库地址，格式为 host:port，多个地址以逗号分隔，使用数据源的用户连接；为空时通过 SHOW REPLICAS 获取从库",
+				Type:  params.ParamTypeString,
+			},
+			&params.Param{
+				Key:   paramKeyMaxReplicaLagSeconds,
+				Value: "0",
+				Desc:  "上线前及语句之间允许的从库最大延迟（秒），为 0 时不检查",
+				Type:  params.ParamTypeInt,
+			},
+			&params.Param{
+				Key:   paramKeyReplicaLagAction,
+				Value: replicaLagActionPause,
+				Desc:  "从库延迟超过阈值时的处理方式，pause 为暂停上线直到延迟恢复，fail 为上线失败",
+				Type:  params.ParamTypeString,
+			},
+			&params.Param{
+				Key:   paramKeyMaxExecutionTime,
+				Value: "0",
+				Desc:  "上线时 SELECT 语句的最大执行时间（毫秒），为 0 时使用数据库的默认值",
+				Type:  params.ParamTypeInt,
+			},
+			&params.Param{
+				Key:   paramKeyLockWaitTimeout,
+				Value: "0",
+				Desc:  "上线时等待元数据锁的超时时间（秒），为 0 时使用数据库的默认值",
+				Type:  params.ParamTypeInt,
+			},
+			&params.Param{
+				Key:   paramKeyInnodbLockWaitTimeout,
+				Value: "0",
+				Desc:  "上线时等待 InnoDB 行锁的超时时间（秒），为 0 时使用数据库的默认值",
+				Type:  params.ParamTypeInt,
+			},
+			&params.Param{
+				Key:   paramKeyMetadataLockCheckPolicy,
+				Value: "",
+				Desc:  "上线 DDL 前检查阻塞元数据锁的会话，refuse 为存在阻塞时拒绝执行，wait 为等待阻塞会话结束，prompt 为暂停执行并等待执行人确认，为空时不检查",
+				Type:  params.ParamTypeString,
+			},
+			&params.Param{
+				Key:   paramKeyMetadataLockCheckMaxWaitSeconds,
+				Value: "60",
+				Desc:  "等待阻塞元数据锁的会话结束的最长时间（秒），超时后拒绝执行 DDL",
+				Type:  params.ParamTypeInt,
+			},
+			&params.Param{
+				Key:   paramKeyMetadataLockCheckMinTrxSeconds,
+				Value: "10",
+				Desc:  "运行时间超过该值（秒）的会话才被认为会阻塞 DDL",
+				Type:  params.ParamTypeInt,
+			},
+		},
 		EnabledOptionalModule: []driverV2.OptionalModule{
 			driverV2.OptionalModuleGenRollbackSQL,
 			driverV2.OptionalModuleQuery,
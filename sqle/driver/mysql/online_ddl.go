@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/driver/mysql/onlineddl"
+)
+
+func (i *MysqlDriverImpl) setGhostExecutor(executor *onlineddl.Executor) {
+	i.ghostMutex.Lock()
+	defer i.ghostMutex.Unlock()
+	i.ghostExecutor = executor
+}
+
+func (i *MysqlDriverImpl) getGhostExecutor() *onlineddl.Executor {
+	i.ghostMutex.Lock()
+	defer i.ghostMutex.Unlock()
+	return i.ghostExecutor
+}
+
+func (i *MysqlDriverImpl) ControlOnlineDDL(ctx context.Context, control *driver.OnlineDDLControl) (bool, error) {
+	executor := i.getGhostExecutor()
+	if executor == nil {
+		return false, nil
+	}
+	executor.Control(&onlineddl.Control{
+		Paused:       control.Paused,
+		NiceRatio:    control.NiceRatio,
+		MaxLagMillis: control.MaxLagMillis,
+		ChunkSize:    control.ChunkSize,
+	})
+	return true, nil
+}
+
+func (i *MysqlDriverImpl) GetOnlineDDLProgress(ctx context.Context) (*driver.OnlineDDLProgress, error) {
+	executor := i.getGhostExecutor()
+	if executor == nil {
+		return nil, nil
+	}
+	progress := executor.Progress()
+	return &driver.OnlineDDLProgress{
+		RowsCopied:     progress.RowsCopied,
+		RowsEstimate:   progress.RowsEstimate,
+		ProgressPct:    progress.ProgressPct,
+		ETASeconds:     progress.ETASeconds,
+		Throttled:      progress.Throttled,
+		ThrottleReason: progress.ThrottleReason,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package onlineddl
+
+import (
+	"sync/atomic"
+)
+
+// Control is the runtime control of gh-ost, it is the same as the interactive
+// commands of gh-ost. The zero value of numeric field means no change.
+type Control struct {
+	Paused       bool
+	NiceRatio    *float64
+	MaxLagMillis int64
+	ChunkSize    int64
+}
+
+// Progress is the status of the row copy of gh-ost.
+type Progress struct {
+	RowsCopied     int64
+	RowsEstimate   int64
+	ProgressPct    float64
+	ETASeconds     int64
+	Throttled      bool
+	ThrottleReason string
+}
+
+// Control applies the control to the running migration, pausing a migration is the same
+// as the "throttle" command of gh-ost, the migration keeps the binlog applied while paused.
+func (e *Executor) Control(control *Control) {
+	if control.Paused {
+		atomic.StoreInt64(&e.mc.ThrottleCommandedByUser, 1)
+	} else {
+		atomic.StoreInt64(&e.mc.ThrottleCommandedByUser, 0)
+	}
+	if control.NiceRatio != nil {
+		e.mc.SetNiceRatio(*control.NiceRatio)
+	}
+	if control.MaxLagMillis > 0 {
+		e.mc.SetMaxLagMillisecondsThrottleThreshold(control.MaxLagMillis)
+	}
+	if control.ChunkSize > 0 {
+		e.mc.SetChunkSize(control.ChunkSize)
+	}
+}
+
+func (e *Executor) Progress() *Progress {
+	throttled, reason, _ := e.mc.IsThrottled()
+	return &Progress{
+		RowsCopied:     e.mc.GetTotalRowsCopied(),
+		RowsEstimate:   atomic.LoadInt64(&e.mc.RowsEstimate) + atomic.LoadInt64(&e.mc.RowsDeltaEstimate),
+		ProgressPct:    e.mc.GetProgressPct(),
+		ETASeconds:     e.mc.GetETASeconds(),
+		Throttled:      throttled,
+		ThrottleReason: reason,
+	}
+}
@@ -0,0 +1,45 @@
+package onlineddl
+
+import (
+	"testing"
+
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/log"
+	"github.com/github/gh-ost/go/base"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutorControl(t *testing.T) {
+	e, err := NewExecutor(log.NewEntry(), &driverV2.DSN{Host: "127.0.0.1", Port: "3306"}, "db1", "alter table t1 add column a int")
+	assert.NoError(t, err)
+
+	chunkSize := e.mc.ChunkSize
+	niceRatio := 0.5
+	e.Control(&Control{Paused: true, NiceRatio: &niceRatio, MaxLagMillis: 3000})
+	assert.Equal(t, int64(1), e.mc.ThrottleCommandedByUser)
+	assert.Equal(t, 0.5, e.mc.GetNiceRatio())
+	assert.Equal(t, int64(3000), e.mc.MaxLagMillisecondsThrottleThreshold)
+	// the zero value means no change
+	assert.Equal(t, chunkSize, e.mc.ChunkSize)
+
+	throttled, _, _ := e.mc.IsThrottled()
+	assert.False(t, throttled)
+	e.mc.SetThrottled(true, "commanded by user", base.UserCommandThrottleReasonHint)
+	e.mc.RowsEstimate = 100
+	e.mc.TotalRowsCopied = 20
+	progress := e.Progress()
+	assert.True(t, progress.Throttled)
+	assert.Equal(t, "commanded by user", progress.ThrottleReason)
+	assert.Equal(t, int64(20), progress.RowsCopied)
+	assert.Equal(t, int64(100), progress.RowsEstimate)
+
+	e.Control(&Control{ChunkSize: 500})
+	assert.Equal(t, int64(0), e.mc.ThrottleCommandedByUser)
+	assert.Equal(t, int64(500), e.mc.ChunkSize)
+	assert.Equal(t, 0.5, e.mc.GetNiceRatio())
+
+	// the nice ratio can be reset to 0
+	niceRatio = 0
+	e.Control(&Control{NiceRatio: &niceRatio})
+	assert.Equal(t, float64(0), e.mc.GetNiceRatio())
+}
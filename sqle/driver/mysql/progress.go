@@ -0,0 +1,102 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/driver/mysql/executor"
+)
+
+func (i *MysqlDriverImpl) getMonitorConn() (*executor.Executor, error) {
+	i.monitorMutex.Lock()
+	defer i.monitorMutex.Unlock()
+	if i.monitorConn != nil {
+		return i.monitorConn, nil
+	}
+	conn, err := executor.NewExecutor(i.log, i.inst, i.inst.DatabaseName)
+	if err != nil {
+		return nil, err
+	}
+	i.monitorConn = conn
+	return conn, nil
+}
+
+func (i *MysqlDriverImpl) closeMonitorConn() {
+	i.monitorMutex.Lock()
+	defer i.monitorMutex.Unlock()
+	if i.monitorConn != nil {
+		i.monitorConn.Db.Close()
+		i.monitorConn = nil
+	}
+	for addr, conn := range i.replicaConns {
+		conn.Db.Close()
+		delete(i.replicaConns, addr)
+	}
+}
+
+// getExecutingConnID returns the connection ID of dbConn, it is empty if dbConn is not connected.
+func (i *MysqlDriverImpl) getExecutingConnID() string {
+	i.monitorMutex.Lock()
+	defer i.monitorMutex.Unlock()
+	if !i.isConnected {
+		return ""
+	}
+	return i.dbConn.Db.GetConnectionID()
+}
+
+func parseNullInt64(s sql.NullString) int64 {
+	v, _ := strconv.ParseInt(s.String, 10, 64)
+	return v
+}
+
+// GetStatementProgress gets the progress from information_schema.PROCESSLIST, the rows examined
+// is only in the PROCESSLIST of Percona Server and MariaDB, it is got from performance_schema
+// for MySQL, as well as the rows affected.
+func (i *MysqlDriverImpl) GetStatementProgress(ctx context.Context) (*driver.StatementProgress, error) {
+	if i.IsOfflineAudit() {
+		return nil, nil
+	}
+	connID := i.getExecutingConnID()
+	if connID == "" {
+		return nil, nil
+	}
+	conn, err := i.getMonitorConn()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := conn.Db.Query("SELECT * FROM information_schema.PROCESSLIST WHERE ID = ?", connID)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || records[0]["COMMAND"].String == "Sleep" {
+		return nil, nil
+	}
+	record := records[0]
+	progress := &driver.StatementProgress{
+		State:          record["STATE"].String,
+		ElapsedSeconds: parseNullInt64(record["TIME"]),
+	}
+	if v, ok := record["ROWS_EXAMINED"]; ok {
+		progress.RowsExamined = parseNullInt64(v)
+	} else if v, ok := record["EXAMINED_ROWS"]; ok {
+		progress.RowsExamined = parseNullInt64(v)
+	}
+
+	// performance_schema may be disabled, the progress from PROCESSLIST is still useful.
+	records, err = conn.Db.Query("SELECT s.ROWS_EXAMINED, s.ROWS_AFFECTED FROM performance_schema.events_statements_current s "+
+		"JOIN performance_schema.threads t ON s.THREAD_ID = t.THREAD_ID WHERE t.PROCESSLIST_ID = ?", connID)
+	if err != nil {
+		i.log.Warnf("get statement progress from performance_schema error: %v", err)
+		return progress, nil
+	}
+	if len(records) > 0 {
+		if progress.RowsExamined == 0 {
+			progress.RowsExamined = parseNullInt64(records[0]["ROWS_EXAMINED"])
+		}
+		progress.RowsAffected = parseNullInt64(records[0]["ROWS_AFFECTED"])
+	}
+	return progress, nil
+}
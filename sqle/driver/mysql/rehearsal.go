@@ -0,0 +1,91 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/actiontech/sqle/sqle/driver/mysql/util"
+	"github.com/actiontech/sqle/sqle/utils"
+)
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+}
+
+func (i *MysqlDriverImpl) ShowCreateTable(ctx context.Context, schema, table string) (string, bool, error) {
+	conn, err := i.getDbConn()
+	if err != nil {
+		return "", false, err
+	}
+	// the view is not recreated on sandbox instance.
+	records, err := conn.Db.Query("SELECT TABLE_NAME FROM information_schema.TABLES "+
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND TABLE_TYPE = 'BASE TABLE'", schema, table)
+	if err != nil {
+		return "", false, err
+	}
+	if len(records) == 0 {
+		return "", false, nil
+	}
+	createTableSQL, err := conn.ShowCreateTable(utils.SupplementalQuotationMarks(schema), utils.SupplementalQuotationMarks(table))
+	if err != nil {
+		return "", false, err
+	}
+	return createTableSQL, true, nil
+}
+
+func (i *MysqlDriverImpl) CreateSchemaWithTables(ctx context.Context, schema string, createTableSQLs []string) error {
+	conn, err := i.getDbConn()
+	if err != nil {
+		return err
+	}
+	records, err := conn.Db.Query("SELECT SCHEMA_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = ?", schema)
+	if err != nil {
+		return err
+	}
+	if len(records) > 0 {
+		return fmt.Errorf("schema %s already exists on the instance", schema)
+	}
+
+	if _, err := conn.Db.Exec(fmt.Sprintf("CREATE DATABASE %s", quoteIdentifier(schema))); err != nil {
+		return err
+	}
+	// the tables referenced by foreign key may be created after the referencing table.
+	qs := []string{fmt.Sprintf("USE %s", quoteIdentifier(schema)), "SET FOREIGN_KEY_CHECKS = 0"}
+	qs = append(qs, createTableSQLs...)
+	qs = append(qs, "SET FOREIGN_KEY_CHECKS = 1")
+	for _, query := range qs {
+		if _, err := conn.Db.Exec(query); err != nil {
+			if dropErr := i.DropSchema(ctx, schema); dropErr != nil {
+				i.log.Errorf("drop schema %s error: %v", schema, dropErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *MysqlDriverImpl) DropSchema(ctx context.Context, schema string) error {
+	conn, err := i.getDbConn()
+	if err != nil {
+		return err
+	}
+	_, err = conn.Db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", quoteIdentifier(schema)))
+	return err
+}
+
+func (i *MysqlDriverImpl) ExtractSchemasFromSQL(ctx context.Context, sql string) ([]string, error) {
+	node, err := util.ParseOneSql(sql)
+	if err != nil {
+		return nil, err
+	}
+	extractor := util.SchemaNameExtractor{SchemaNames: map[string]struct{}{}}
+	node.Accept(&extractor)
+	schemas := make([]string, 0, len(extractor.SchemaNames))
+	for schema := range extractor.SchemaNames {
+		schemas = append(schemas, schema)
+	}
+	sort.Strings(schemas)
+	return schemas, nil
+}
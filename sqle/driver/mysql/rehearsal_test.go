@@ -0,0 +1,24 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractSchemasFromSQL(t *testing.T) {
+	i := &MysqlDriverImpl{}
+	for sql, expected := range map[string][]string{
+		"update t1 set a=1":                          {},
+		"insert into db1.t1 select * from db2.t2":    {"db1", "db2"},
+		"create database db3":                        {"db3"},
+		"drop database if exists db3":                {"db3"},
+		"alter database db3 default charset utf8mb4": {"db3"},
+		"use db4": {"db4"},
+	} {
+		schemas, err := i.ExtractSchemasFromSQL(context.TODO(), sql)
+		assert.NoError(t, err, sql)
+		assert.Equal(t, expected, schemas, sql)
+	}
+}
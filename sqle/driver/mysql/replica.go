@@ -0,0 +1,178 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/driver/mysql/executor"
+)
+
+const (
+	paramKeyReplicaDSNs          = "replica_dsns"
+	paramKeyMaxReplicaLagSeconds = "max_replica_lag_seconds"
+	paramKeyReplicaLagAction     = "replica_lag_action"
+
+	replicaLagActionPause = "pause"
+	replicaLagActionFail  = "fail"
+)
+
+type replicaAddress struct {
+	host string
+	port string
+}
+
+func (r replicaAddress) String() string {
+	return net.JoinHostPort(r.host, r.port)
+}
+
+// GetReplicaLagGuardConfig reads the config from the additional params of instance.
+func (i *MysqlDriverImpl) GetReplicaLagGuardConfig(ctx context.Context) (*driver.ReplicaLagGuardConfig, error) {
+	if i.IsOfflineAudit() {
+		return nil, nil
+	}
+	maxLag := i.inst.AdditionalParams.GetParam(paramKeyMaxReplicaLagSeconds).Int()
+	if maxLag <= 0 {
+		return nil, nil
+	}
+	action := i.inst.AdditionalParams.GetParam(paramKeyReplicaLagAction).String()
+	switch action {
+	case "", replicaLagActionPause:
+		return &driver.ReplicaLagGuardConfig{MaxLagSeconds: int64(maxLag), PauseOnLag: true}, nil
+	case replicaLagActionFail:
+		return &driver.ReplicaLagGuardConfig{MaxLagSeconds: int64(maxLag), PauseOnLag: false}, nil
+	default:
+		return nil, fmt.Errorf("invalid %s %q, it should be %s or %s", paramKeyReplicaLagAction, action,
+			replicaLagActionPause, replicaLagActionFail)
+	}
+}
+
+// parseReplicaDSNs parses the comma separated addresses of replica, the port is 3306 by default.
+func parseReplicaDSNs(dsns string) []replicaAddress {
+	addresses := []replicaAddress{}
+	for _, dsn := range strings.Split(dsns, ",") {
+		dsn = strings.TrimSpace(dsn)
+		if dsn == "" {
+			continue
+		}
+		host, port, err := net.SplitHostPort(dsn)
+		if err != nil {
+			// the port is omitted
+			host, port = strings.Trim(dsn, "[]"), "3306"
+		}
+		addresses = append(addresses, replicaAddress{host: host, port: port})
+	}
+	return addresses
+}
+
+// getReplicaAddresses returns the replicas configured in the additional params of instance, or
+// the replicas registered on the instance by SHOW REPLICAS. The replica which does not set
+// report_host is skipped.
+func (i *MysqlDriverImpl) getReplicaAddresses() ([]replicaAddress, error) {
+	if dsns := i.inst.AdditionalParams.GetParam(paramKeyReplicaDSNs).String(); dsns != "" {
+		return parseReplicaDSNs(dsns), nil
+	}
+	conn, err := i.getMonitorConn()
+	if err != nil {
+		return nil, err
+	}
+	replicas, err := queryWithFallback(conn, "SHOW REPLICAS", "SHOW SLAVE HOSTS")
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]replicaAddress, 0, len(replicas))
+	for _, replica := range replicas {
+		if replica["Host"].String == "" {
+			i.log.Warnf("skip the replica of server id %s without report_host", replica["Server_id"].String)
+			continue
+		}
+		addresses = append(addresses, replicaAddress{host: replica["Host"].String, port: replica["Port"].String})
+	}
+	return addresses, nil
+}
+
+// getReplicaConn returns the cached connection of replica, the replica is connected by the
+// user of instance.
+func (i *MysqlDriverImpl) getReplicaConn(host, port string) (*executor.Executor, error) {
+	i.monitorMutex.Lock()
+	defer i.monitorMutex.Unlock()
+	addr := net.JoinHostPort(host, port)
+	if conn, ok := i.replicaConns[addr]; ok {
+		return conn, nil
+	}
+	dsn := *i.inst
+	dsn.Host, dsn.Port, dsn.DatabaseName = host, port, ""
+	conn, err := executor.NewExecutor(i.log, &dsn, "")
+	if err != nil {
+		return nil, err
+	}
+	if i.replicaConns == nil {
+		i.replicaConns = map[string]*executor.Executor{}
+	}
+	i.replicaConns[addr] = conn
+	return conn, nil
+}
+
+// queryWithFallback runs the query introduced by MySQL 8.0.22 and falls back to the deprecated one.
+func queryWithFallback(conn *executor.Executor, query, deprecatedQuery string) ([]map[string]sql.NullString, error) {
+	records, err := conn.Db.Query(query)
+	if err != nil {
+		return conn.Db.Query(deprecatedQuery)
+	}
+	return records, nil
+}
+
+// GetReplicaLag gets the lag of the replicas by SHOW REPLICA STATUS on each replica.
+func (i *MysqlDriverImpl) GetReplicaLag(ctx context.Context) (int64, bool, error) {
+	if i.IsOfflineAudit() {
+		return 0, false, nil
+	}
+	replicas, err := i.getReplicaAddresses()
+	if err != nil {
+		return 0, false, err
+	}
+
+	var maxLag int64
+	for _, replica := range replicas {
+		replicaConn, err := i.getReplicaConn(replica.host, replica.port)
+		if err != nil {
+			return 0, false, fmt.Errorf("connect to replica %s: %v", replica, err)
+		}
+		lag, err := getReplicationLag(replicaConn)
+		if err != nil {
+			return 0, false, fmt.Errorf("get lag of replica %s: %v", replica, err)
+		}
+		if lag > maxLag {
+			maxLag = lag
+		}
+	}
+	return maxLag, len(replicas) > 0, nil
+}
+
+// getReplicationLag returns error if the replication is not running, the lag is unknown then.
+func getReplicationLag(conn *executor.Executor) (int64, error) {
+	records, err := queryWithFallback(conn, "SHOW REPLICA STATUS", "SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 {
+		return 0, fmt.Errorf("the instance is not a replica")
+	}
+	var maxLag int64
+	for _, record := range records {
+		lag, ok := record["Seconds_Behind_Source"]
+		if !ok {
+			lag = record["Seconds_Behind_Master"]
+		}
+		if !lag.Valid {
+			return 0, fmt.Errorf("the replication is not running")
+		}
+		if v := parseNullInt64(lag); v > maxLag {
+			maxLag = v
+		}
+	}
+	return maxLag, nil
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"github.com/actiontech/sqle/sqle/errors"
 
 	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
 	_model "github.com/pingcap/parser/model"
 )
 
@@ -67,12 +69,22 @@ func (i *MysqlDriverImpl) GenerateDMLStmtRollbackSql(node ast.Node) (rollbackSql
 const (
 	NotSupportStatementRollback               = "暂不支持回滚该类型的语句"
 	NotSupportMultiTableStatementRollback     = "暂不支持回滚多表的 DML 语句"
-	NotSupportOnDuplicatStatementRollback     = "暂不支持回滚 ON DUPLICATE 语句"
 	NotSupportSubQueryStatementRollback       = "暂不支持回滚带子查询的语句"
 	NotSupportNoPrimaryKeyTableRollback       = "不支持回滚没有主键的表的DML语句"
 	NotSupportInsertWithoutPrimaryKeyRollback = "不支持回滚 INSERT 没有指定主键的语句"
 	NotSupportParamMarkerStatementRollback    = "不支持回滚包含指纹的语句"
 	NotSupportExceedMaxRowsRollback           = "预计影响行数超过配置的最大值，不生成回滚语句"
+	NotSupportAmbiguousColumnRollback         = "不支持回滚无法确定所属表的列的多表 UPDATE 语句"
+	NotSupportUpdatePrimaryKeyRollback        = "暂不支持回滚修改主键的多表 UPDATE 或 ON DUPLICATE 语句"
+)
+
+// The lossy reasons are returned with the rollback SQL, the rollback SQL only restores the
+// table definition, the data lost by the statement can not be recovered.
+const (
+	LossyDropPartitionRollback     = "有损回滚：DROP PARTITION 删除的分区数据无法恢复，回滚语句仅恢复分区定义"
+	LossyTruncatePartitionRollback = "有损回滚：TRUNCATE PARTITION 清空的分区数据无法恢复，不生成回滚语句"
+	LossyAddPartitionRollback      = "有损回滚：回滚语句会删除新增的分区，执行后写入该分区的数据将被删除"
+	LossyConvertCharsetRollback    = "有损回滚：CONVERT TO CHARACTER SET 会转换已有数据，无法用新字符集表示的字符在回滚后无法恢复"
 )
 
 // generateAlterTableRollbackSql generate alter table SQL for alter table.
@@ -264,8 +276,215 @@ func (i *MysqlDriverImpl) generateAlterTableRollbackSql(stmt *ast.AlterTableStmt
 		}
 	}
 
-	rollbackSql := util.AlterTableStmtFormat(rollbackStmt)
-	return rollbackSql, "", nil
+	// rename column need rename back
+	for _, spec := range util.GetAlterTableSpecByTp(stmt.Specs, ast.AlterTableRenameColumn) {
+		rollbackStmt.Specs = append(rollbackStmt.Specs, &ast.AlterTableSpec{
+			Tp:            ast.AlterTableRenameColumn,
+			OldColumnName: spec.NewColumnName,
+			NewColumnName: spec.OldColumnName,
+		})
+	}
+
+	lossyReasons := []string{}
+	// table options need restore
+	if specs := util.GetAlterTableSpecByTp(stmt.Specs, ast.AlterTableOption); len(specs) > 0 {
+		optionSpecs, lossy := generateTableOptionRollbackSpecs(createTableStmt, specs)
+		rollbackStmt.Specs = append(rollbackStmt.Specs, optionSpecs...)
+		if lossy {
+			lossyReasons = append(lossyReasons, LossyConvertCharsetRollback)
+		}
+	}
+
+	// MySQL allows only one partition operation in an ALTER TABLE statement, the partition operation
+	// is rolled back by a separate statement which is executed before the others, e.g. the column used
+	// by new partitioning should be dropped after the partitioning is removed.
+	rollbackSqls := []string{}
+	for _, spec := range stmt.Specs {
+		partitionSpec, lossyReason := generatePartitionRollbackSpec(createTableStmt, spec)
+		if lossyReason != "" {
+			lossyReasons = append(lossyReasons, lossyReason)
+		}
+		if partitionSpec != nil {
+			rollbackSqls = append(rollbackSqls, util.AlterTableStmtFormat(&ast.AlterTableStmt{
+				Table: rollbackStmt.Table,
+				Specs: []*ast.AlterTableSpec{partitionSpec},
+			}))
+		}
+	}
+
+	if rollbackSql := util.AlterTableStmtFormat(rollbackStmt); rollbackSql != "" {
+		rollbackSqls = append(rollbackSqls, rollbackSql)
+	}
+	return strings.Join(rollbackSqls, "\n"), strings.Join(lossyReasons, "; "), nil
+}
+
+func getTableOption(options []*ast.TableOption, tp ast.TableOptionType) *ast.TableOption {
+	var option *ast.TableOption
+	for _, o := range options {
+		if o.Tp == tp {
+			option = o
+		}
+	}
+	return option
+}
+
+// generateTableOptionRollbackSpecs restores the charset, collation, engine and comment of table by
+// the original CREATE TABLE, the other table options such as AUTO_INCREMENT are not rolled back.
+// It returns lossy if the data of table is converted to another charset.
+func generateTableOptionRollbackSpecs(createTableStmt *ast.CreateTableStmt, specs []*ast.AlterTableSpec) ([]*ast.AlterTableSpec, bool) {
+	changed := map[ast.TableOptionType]bool{}
+	convert := false
+	for _, spec := range specs {
+		for _, option := range spec.Options {
+			changed[option.Tp] = true
+			if option.Tp == ast.TableOptionCharset && option.UintValue == ast.TableOptionCharsetWithConvertTo {
+				convert = true
+			}
+		}
+	}
+
+	rollbackSpecs := []*ast.AlterTableSpec{}
+	if changed[ast.TableOptionCharset] || changed[ast.TableOptionCollate] {
+		options := []*ast.TableOption{}
+		if charset := getTableOption(createTableStmt.Options, ast.TableOptionCharset); charset != nil {
+			option := &ast.TableOption{Tp: ast.TableOptionCharset, StrValue: charset.StrValue}
+			if convert {
+				option.UintValue = ast.TableOptionCharsetWithConvertTo
+			}
+			options = append(options, option)
+		}
+		if collate := getTableOption(createTableStmt.Options, ast.TableOptionCollate); collate != nil {
+			options = append(options, &ast.TableOption{Tp: ast.TableOptionCollate, StrValue: collate.StrValue})
+		}
+		if len(options) > 0 {
+			rollbackSpecs = append(rollbackSpecs, &ast.AlterTableSpec{Tp: ast.AlterTableOption, Options: options})
+		}
+		// CONVERT TO changes the charset of all the character columns, the columns with
+		// their own charset need to be modified back.
+		if convert {
+			for _, col := range createTableStmt.Cols {
+				if col.Tp != nil && (col.Tp.Charset != "" || col.Tp.Collate != "") {
+					rollbackSpecs = append(rollbackSpecs, &ast.AlterTableSpec{
+						Tp:         ast.AlterTableModifyColumn,
+						NewColumns: []*ast.ColumnDef{col},
+					})
+				}
+			}
+		}
+	}
+	if changed[ast.TableOptionEngine] {
+		if engine := getTableOption(createTableStmt.Options, ast.TableOptionEngine); engine != nil {
+			rollbackSpecs = append(rollbackSpecs, &ast.AlterTableSpec{
+				Tp:      ast.AlterTableOption,
+				Options: []*ast.TableOption{{Tp: ast.TableOptionEngine, StrValue: engine.StrValue}},
+			})
+		}
+	}
+	if changed[ast.TableOptionComment] {
+		comment := ""
+		if option := getTableOption(createTableStmt.Options, ast.TableOptionComment); option != nil {
+			comment = option.StrValue
+		}
+		rollbackSpecs = append(rollbackSpecs, &ast.AlterTableSpec{
+			Tp:      ast.AlterTableOption,
+			Options: []*ast.TableOption{{Tp: ast.TableOptionComment, StrValue: comment}},
+		})
+	}
+	return rollbackSpecs, convert
+}
+
+// generatePartitionRollbackSpec generates the inverse partition operation by the partitions of the
+// original CREATE TABLE. The lossy reason is returned if the data of partition can not be recovered.
+func generatePartitionRollbackSpec(createTableStmt *ast.CreateTableStmt, spec *ast.AlterTableSpec) (*ast.AlterTableSpec, string) {
+	partition := createTableStmt.Partition
+	switch spec.Tp {
+	case ast.AlterTableAddPartitions:
+		// ADD PARTITION PARTITIONS n of HASH or KEY partitioning
+		if len(spec.PartDefinitions) == 0 {
+			return &ast.AlterTableSpec{Tp: ast.AlterTableCoalescePartitions, Num: spec.Num}, ""
+		}
+		names := make([]_model.CIStr, 0, len(spec.PartDefinitions))
+		for _, definition := range spec.PartDefinitions {
+			names = append(names, definition.Name)
+		}
+		return &ast.AlterTableSpec{Tp: ast.AlterTableDropPartition, PartitionNames: names}, LossyAddPartitionRollback
+	case ast.AlterTableCoalescePartitions:
+		return &ast.AlterTableSpec{Tp: ast.AlterTableAddPartitions, Num: spec.Num}, ""
+	case ast.AlterTableTruncatePartition:
+		return nil, LossyTruncatePartitionRollback
+	case ast.AlterTableDropPartition:
+		if partition == nil {
+			return nil, LossyDropPartitionRollback
+		}
+		dropped := map[string]bool{}
+		for _, name := range spec.PartitionNames {
+			dropped[name.L] = true
+		}
+		first := -1
+		definitions := []*ast.PartitionDefinition{}
+		remained := []_model.CIStr{}
+		for n, definition := range partition.Definitions {
+			if dropped[definition.Name.L] {
+				if first < 0 {
+					first = n
+				}
+				definitions = append(definitions, definition)
+			} else if first >= 0 {
+				remained = append(remained, definition.Name)
+			}
+		}
+		if first < 0 {
+			return nil, LossyDropPartitionRollback
+		}
+		// the range of dropped partition is merged into the next partition of RANGE partitioning,
+		// the partitions after the first dropped partition need to be reorganized.
+		if partition.Tp == _model.PartitionTypeRange && len(remained) > 0 {
+			return &ast.AlterTableSpec{
+				Tp:              ast.AlterTableReorganizePartition,
+				PartitionNames:  remained,
+				PartDefinitions: partition.Definitions[first:],
+			}, LossyDropPartitionRollback
+		}
+		return &ast.AlterTableSpec{Tp: ast.AlterTableAddPartitions, PartDefinitions: definitions}, LossyDropPartitionRollback
+	case ast.AlterTableReorganizePartition:
+		if partition == nil || len(spec.PartitionNames) == 0 {
+			return nil, ""
+		}
+		definitions := []*ast.PartitionDefinition{}
+		for _, name := range spec.PartitionNames {
+			for _, definition := range partition.Definitions {
+				if definition.Name.L == name.L {
+					definitions = append(definitions, definition)
+				}
+			}
+		}
+		if len(definitions) != len(spec.PartitionNames) {
+			return nil, ""
+		}
+		names := make([]_model.CIStr, 0, len(spec.PartDefinitions))
+		for _, definition := range spec.PartDefinitions {
+			names = append(names, definition.Name)
+		}
+		return &ast.AlterTableSpec{
+			Tp:              ast.AlterTableReorganizePartition,
+			PartitionNames:  names,
+			PartDefinitions: definitions,
+		}, ""
+	case ast.AlterTablePartition:
+		if partition == nil {
+			return &ast.AlterTableSpec{Tp: ast.AlterTableRemovePartitioning}, ""
+		}
+		return &ast.AlterTableSpec{Tp: ast.AlterTablePartition, Partition: partition}, ""
+	case ast.AlterTableRemovePartitioning:
+		if partition == nil {
+			return nil, ""
+		}
+		return &ast.AlterTableSpec{Tp: ast.AlterTablePartition, Partition: partition}, ""
+	case ast.AlterTableExchangePartition:
+		// exchange the partition with the same table again
+		return spec, ""
+	}
+	return nil, ""
 }
 
 // generateCreateSchemaRollbackSql generate drop database SQL for create database.
@@ -368,9 +587,6 @@ func (i *MysqlDriverImpl) generateInsertRollbackSql(stmt *ast.InsertStmt) (strin
 	if len(tables) != 1 {
 		return "", NotSupportMultiTableStatementRollback, nil
 	}
-	if stmt.OnDuplicate != nil {
-		return "", NotSupportOnDuplicatStatementRollback, nil
-	}
 	table := tables[0]
 	createTableStmt, exist, err := i.Ctx.GetCreateTableStmt(table)
 	if err != nil {
@@ -387,6 +603,9 @@ func (i *MysqlDriverImpl) generateInsertRollbackSql(stmt *ast.InsertStmt) (strin
 	if !hasPk {
 		return "", NotSupportNoPrimaryKeyTableRollback, nil
 	}
+	if stmt.OnDuplicate != nil || stmt.IsReplace {
+		return i.generateUpsertRollbackSql(stmt, table, createTableStmt, pkColumnsName)
+	}
 
 	rollbackSql := ""
 
@@ -451,16 +670,14 @@ func (i *MysqlDriverImpl) generateInsertRollbackSql(stmt *ast.InsertStmt) (strin
 
 // generateDeleteRollbackSql generate insert SQL for delete.
 func (i *MysqlDriverImpl) generateDeleteRollbackSql(stmt *ast.DeleteStmt) (string, string, error) {
-	// not support multi-table syntax
-	if stmt.IsMultiTable {
-		i.Logger().Infof("not support generate rollback sql with multi-delete statement")
-		return "", NotSupportMultiTableStatementRollback, nil
-	}
 	// sub query statement
 	if util.WhereStmtHasSubQuery(stmt.Where) {
 		i.Logger().Infof("not support generate rollback sql with sub query")
 		return "", NotSupportSubQueryStatementRollback, nil
 	}
+	if stmt.IsMultiTable {
+		return i.generateMultiDeleteRollbackSql(stmt)
+	}
 	var err error
 	tables := util.GetTables(stmt.TableRefs.TableRefs)
 	table := tables[0]
@@ -494,6 +711,13 @@ func (i *MysqlDriverImpl) generateDeleteRollbackSql(stmt *ast.DeleteStmt) (strin
 	if err != nil {
 		return "", "", err
 	}
+	return i.generateInsertSqlByRecords(table, createTableStmt, records), "", nil
+}
+
+// generateInsertSqlByRecords generate insert SQL which inserts the records back to table.
+// It returns empty string if there is no records or the records do not match the table.
+func (i *MysqlDriverImpl) generateInsertSqlByRecords(table *ast.TableName, createTableStmt *ast.CreateTableStmt,
+	records []map[string]sql.NullString) string {
 	values := []string{}
 
 	columnsName := []string{}
@@ -502,15 +726,11 @@ func (i *MysqlDriverImpl) generateDeleteRollbackSql(stmt *ast.DeleteStmt) (strin
 	}
 	for _, record := range records {
 		if len(record) != len(columnsName) {
-			return "", "", nil
+			return ""
 		}
 		vs := []string{}
 		for _, name := range columnsName {
-			v := "NULL"
-			if record[name].Valid {
-				v = fmt.Sprintf("'%s'", record[name].String)
-			}
-			vs = append(vs, v)
+			vs = append(vs, recordValueFormat(record[name]))
 		}
 		values = append(values, fmt.Sprintf("(%s)", strings.Join(vs, ", ")))
 	}
@@ -520,22 +740,37 @@ func (i *MysqlDriverImpl) generateDeleteRollbackSql(stmt *ast.DeleteStmt) (strin
 			i.getTableNameWithQuote(table), strings.Join(columnsName, "`, `"),
 			strings.Join(values, ", "))
 	}
-	return rollbackSql, "", nil
+	return rollbackSql
+}
+
+// valueExprFormat restores the value expression with single quoted string literal.
+func valueExprFormat(expr ast.ExprNode) string {
+	buf := new(bytes.Buffer)
+	if err := expr.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, buf)); err != nil {
+		return util.ExprFormat(expr)
+	}
+	return buf.String()
+}
+
+func recordValueFormat(v sql.NullString) string {
+	if !v.Valid {
+		return "NULL"
+	}
+	return fmt.Sprintf("'%s'", v.String)
 }
 
 // generateUpdateRollbackSql generate update SQL for update.
 func (i *MysqlDriverImpl) generateUpdateRollbackSql(stmt *ast.UpdateStmt) (string, string, error) {
 	tableSources := util.GetTableSources(stmt.TableRefs.TableRefs)
-	// multi table syntax
-	if len(tableSources) != 1 {
-		i.Logger().Infof("not support generate rollback sql with multi-update statement")
-		return "", NotSupportMultiTableStatementRollback, nil
-	}
 	// sub query statement
 	if util.WhereStmtHasSubQuery(stmt.Where) {
 		i.Logger().Infof("not support generate rollback sql with sub query")
 		return "", NotSupportSubQueryStatementRollback, nil
 	}
+	// multi table syntax
+	if len(tableSources) != 1 {
+		return i.generateMultiUpdateRollbackSql(stmt, tableSources)
+	}
 	var (
 		table      *ast.TableName
 		tableAlias string
@@ -620,7 +855,7 @@ func (i *MysqlDriverImpl) generateUpdateRollbackSql(stmt *ast.UpdateStmt) (strin
 				if isPkChanged {
 					where = append(where, fmt.Sprintf("%s = '%s'", name, pkValue))
 				} else {
-					where = append(where, fmt.Sprintf("%s = %s", name, v))
+					where = append(where, fmt.Sprintf("`%s` = %s", name, v))
 
 				}
 			}
@@ -631,6 +866,368 @@ func (i *MysqlDriverImpl) generateUpdateRollbackSql(stmt *ast.UpdateStmt) (strin
 	return rollbackSql, "", nil
 }
 
+// multiTableTarget is the table modified by multi-table DELETE/UPDATE.
+type multiTableTarget struct {
+	table *ast.TableName
+	// qualifier is the alias or the quoted name used to refer the table in the statement.
+	qualifier       string
+	createTableStmt *ast.CreateTableStmt
+	pkColumnsName   map[string]struct{}
+	assignments     []*ast.Assignment
+}
+
+// findTableSource returns the table source referred by the name, the name is the alias
+// of table source or the table name if the table source has no alias.
+func (i *MysqlDriverImpl) findTableSource(tableSources []*ast.TableSource, schema, name _model.CIStr) *ast.TableSource {
+	for _, source := range tableSources {
+		table, ok := source.Source.(*ast.TableName)
+		if !ok {
+			continue
+		}
+		if source.AsName.L != "" {
+			if schema.L == "" && source.AsName.L == name.L {
+				return source
+			}
+			continue
+		}
+		if table.Name.L == name.L && (schema.L == "" ||
+			i.Ctx.GetSchemaName(table) == i.Ctx.GetSchemaName(&ast.TableName{Schema: schema, Name: name})) {
+			return source
+		}
+	}
+	return nil
+}
+
+// newMultiTableTarget returns nil target with the reason if the table can not be rolled back.
+func (i *MysqlDriverImpl) newMultiTableTarget(source *ast.TableSource) (*multiTableTarget, string, error) {
+	table, ok := source.Source.(*ast.TableName)
+	if !ok {
+		return nil, NotSupportSubQueryStatementRollback, nil
+	}
+	createTableStmt, exist, err := i.Ctx.GetCreateTableStmt(table)
+	if err != nil {
+		return nil, "", err
+	}
+	// if table not exist, the statement will failed.
+	if !exist {
+		return nil, "", nil
+	}
+	pkColumnsName, hasPk, err := i.getPrimaryKey(createTableStmt)
+	if err != nil {
+		return nil, "", err
+	}
+	if !hasPk {
+		return nil, NotSupportNoPrimaryKeyTableRollback, nil
+	}
+	qualifier := i.getTableNameWithQuote(table)
+	if source.AsName.L != "" {
+		qualifier = fmt.Sprintf("`%s`", source.AsName.O)
+	}
+	return &multiTableTarget{
+		table:           table,
+		qualifier:       qualifier,
+		createTableStmt: createTableStmt,
+		pkColumnsName:   pkColumnsName,
+	}, "", nil
+}
+
+// getMultiTableRecords select the distinct records of target table which are joined by
+// the multi-table DELETE/UPDATE. The records are nil if the count exceeds the max rows.
+func (i *MysqlDriverImpl) getMultiTableRecords(target *multiTableTarget, tableRefs *ast.Join,
+	where ast.ExprNode) ([]map[string]sql.NullString, error) {
+	conn, err := i.getDbConn()
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err := tableRefs.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, buf)); err != nil {
+		return nil, err
+	}
+	recordSql := fmt.Sprintf("SELECT DISTINCT %s.* FROM %s", target.qualifier, buf.String())
+	if where != nil {
+		recordSql = fmt.Sprintf("%s WHERE %s", recordSql, util.ExprFormat(where))
+	}
+	recordSql = fmt.Sprintf("%s LIMIT %d;", recordSql, i.cnf.DMLRollbackMaxRows+1)
+	records, err := conn.Db.Query(recordSql)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(records)) > i.cnf.DMLRollbackMaxRows {
+		return nil, nil
+	}
+	return records, nil
+}
+
+// generateMultiDeleteRollbackSql generate insert SQL for each table deleted by multi-table delete,
+// e.g. "DELETE t1, t2 FROM t1 JOIN t2 ON t1.id = t2.t1_id WHERE ...".
+func (i *MysqlDriverImpl) generateMultiDeleteRollbackSql(stmt *ast.DeleteStmt) (string, string, error) {
+	tableSources := util.GetTableSources(stmt.TableRefs.TableRefs)
+	targets := []*multiTableTarget{}
+	for _, table := range stmt.Tables.Tables {
+		source := i.findTableSource(tableSources, table.Schema, table.Name)
+		if source == nil {
+			// mysql will throw error: 1109 (42S02): Unknown table in MULTI DELETE
+			return "", "", nil
+		}
+		target, reason, err := i.newMultiTableTarget(source)
+		if target == nil {
+			return "", reason, err
+		}
+		targets = append(targets, target)
+	}
+
+	var count int64
+	rollbackSqls := []string{}
+	for _, target := range targets {
+		records, err := i.getMultiTableRecords(target, stmt.TableRefs.TableRefs, stmt.Where)
+		if err != nil {
+			return "", "", err
+		}
+		count += int64(len(records))
+		if records == nil || count > i.cnf.DMLRollbackMaxRows {
+			return "", NotSupportExceedMaxRowsRollback, nil
+		}
+		if sql := i.generateInsertSqlByRecords(target.table, target.createTableStmt, records); sql != "" {
+			rollbackSqls = append(rollbackSqls, sql)
+		}
+	}
+	return strings.Join(rollbackSqls, "\n"), "", nil
+}
+
+// generateMultiUpdateRollbackSql generate update SQL for each table updated by multi-table update,
+// e.g. "UPDATE t1 JOIN t2 ON t1.id = t2.t1_id SET t1.v1 = t2.v1, t2.v2 = 'v2' WHERE ...".
+func (i *MysqlDriverImpl) generateMultiUpdateRollbackSql(stmt *ast.UpdateStmt, tableSources []*ast.TableSource) (string, string, error) {
+	targets := []*multiTableTarget{}
+	targetBySource := map[*ast.TableSource]*multiTableTarget{}
+	for _, assignment := range stmt.List {
+		col := assignment.Column
+		var source *ast.TableSource
+		if col.Table.L != "" {
+			source = i.findTableSource(tableSources, col.Schema, col.Table)
+		} else {
+			// the column without qualifier must be unique in all tables, otherwise
+			// mysql will throw error: 1052 (23000): Column is ambiguous
+			for _, s := range tableSources {
+				table, ok := s.Source.(*ast.TableName)
+				if !ok {
+					continue
+				}
+				createTableStmt, exist, err := i.Ctx.GetCreateTableStmt(table)
+				if err != nil {
+					return "", "", err
+				}
+				if !exist || !util.TableExistCol(createTableStmt, col.Name.L) {
+					continue
+				}
+				if source != nil {
+					return "", NotSupportAmbiguousColumnRollback, nil
+				}
+				source = s
+			}
+		}
+		if source == nil {
+			return "", NotSupportAmbiguousColumnRollback, nil
+		}
+		target, ok := targetBySource[source]
+		if !ok {
+			var reason string
+			var err error
+			target, reason, err = i.newMultiTableTarget(source)
+			if target == nil {
+				return "", reason, err
+			}
+			targetBySource[source] = target
+			targets = append(targets, target)
+		}
+		if _, isPk := target.pkColumnsName[col.Name.L]; isPk {
+			return "", NotSupportUpdatePrimaryKeyRollback, nil
+		}
+		target.assignments = append(target.assignments, assignment)
+	}
+
+	var count int64
+	rollbackSql := ""
+	for _, target := range targets {
+		records, err := i.getMultiTableRecords(target, stmt.TableRefs.TableRefs, stmt.Where)
+		if err != nil {
+			return "", "", err
+		}
+		count += int64(len(records))
+		if records == nil || count > i.cnf.DMLRollbackMaxRows {
+			return "", NotSupportExceedMaxRowsRollback, nil
+		}
+		for _, record := range records {
+			if len(record) != len(target.createTableStmt.Cols) {
+				return "", "", nil
+			}
+			where := []string{}
+			value := []string{}
+			for _, col := range target.createTableStmt.Cols {
+				name := col.Name.Name.O
+				for _, assignment := range target.assignments {
+					if col.Name.Name.L == assignment.Column.Name.L {
+						value = append(value, fmt.Sprintf("`%s` = %s", name, recordValueFormat(record[name])))
+						break
+					}
+				}
+				if _, isPk := target.pkColumnsName[col.Name.Name.L]; isPk {
+					where = append(where, fmt.Sprintf("`%s` = %s", name, recordValueFormat(record[name])))
+				}
+			}
+			rollbackSql += fmt.Sprintf("UPDATE %s SET %s WHERE %s;", i.getTableNameWithQuote(target.table),
+				strings.Join(value, ", "), strings.Join(where, " AND "))
+		}
+	}
+	return rollbackSql, "", nil
+}
+
+// getUniqueKeys returns the columns of primary key and unique keys, the primary key is the first.
+// The column names are the same as the table definition.
+func getUniqueKeys(createTableStmt *ast.CreateTableStmt, pkColumnsName map[string]struct{}) [][]string {
+	columnsName := map[string]string{}
+	pk := []string{}
+	for _, col := range createTableStmt.Cols {
+		columnsName[col.Name.Name.L] = col.Name.Name.O
+		if _, isPk := pkColumnsName[col.Name.Name.L]; isPk {
+			pk = append(pk, col.Name.Name.O)
+		}
+	}
+	keys := [][]string{pk}
+	for _, constraint := range createTableStmt.Constraints {
+		switch constraint.Tp {
+		case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+			key := []string{}
+			for _, k := range constraint.Keys {
+				// the functional key part can not be matched by the inserted values
+				if k.Column == nil {
+					key = nil
+					break
+				}
+				if name, ok := columnsName[k.Column.Name.L]; ok {
+					key = append(key, name)
+				}
+			}
+			if len(key) > 0 && len(key) == len(constraint.Keys) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	for _, col := range createTableStmt.Cols {
+		if util.HasOneInOptions(col.Options, ast.ColumnOptionUniqKey) {
+			keys = append(keys, []string{col.Name.Name.O})
+		}
+	}
+	return keys
+}
+
+// generateUpsertRollbackSql generate rollback SQL for "INSERT ... ON DUPLICATE KEY UPDATE" and
+// "REPLACE INTO". The records conflicting with the inserted values on primary key or unique keys
+// are selected before execution, the rollback SQL deletes the inserted rows and the modified rows,
+// and then inserts the selected records back.
+func (i *MysqlDriverImpl) generateUpsertRollbackSql(stmt *ast.InsertStmt, table *ast.TableName,
+	createTableStmt *ast.CreateTableStmt, pkColumnsName map[string]struct{}) (string, string, error) {
+	for _, assignment := range stmt.OnDuplicate {
+		if _, isPk := pkColumnsName[assignment.Column.Name.L]; isPk {
+			return "", NotSupportUpdatePrimaryKeyRollback, nil
+		}
+	}
+
+	columnsName := []string{}
+	rows := [][]ast.ExprNode{}
+	switch {
+	case stmt.Lists != nil:
+		if stmt.Columns != nil {
+			for _, col := range stmt.Columns {
+				columnsName = append(columnsName, col.Name.L)
+			}
+		} else {
+			for _, col := range createTableStmt.Cols {
+				columnsName = append(columnsName, col.Name.Name.L)
+			}
+		}
+		rows = stmt.Lists
+	case stmt.Setlist != nil:
+		row := []ast.ExprNode{}
+		for _, setExpr := range stmt.Setlist {
+			columnsName = append(columnsName, setExpr.Column.Name.L)
+			row = append(row, setExpr.Expr)
+		}
+		rows = append(rows, row)
+	default:
+		return "", NotSupportSubQueryStatementRollback, nil
+	}
+	if int64(len(rows)) > i.cnf.DMLRollbackMaxRows {
+		return "", NotSupportExceedMaxRowsRollback, nil
+	}
+
+	uniqueKeys := getUniqueKeys(createTableStmt, pkColumnsName)
+	deleteConditions := []string{}
+	conflictConditions := []string{}
+	for _, row := range rows {
+		// mysql will throw error: 1136 (21S01): Column count doesn't match value count
+		if len(columnsName) != len(row) {
+			return "", "", nil
+		}
+		values := map[string]string{}
+		for n, name := range columnsName {
+			values[name] = valueExprFormat(row[n])
+		}
+		for n, key := range uniqueKeys {
+			where := []string{}
+			for _, name := range key {
+				if v, ok := values[strings.ToLower(name)]; ok {
+					where = append(where, fmt.Sprintf("`%s` = %s", name, v))
+				}
+			}
+			if len(where) != len(key) {
+				// the inserted row can not be deleted without primary key
+				if n == 0 {
+					return "", NotSupportInsertWithoutPrimaryKeyRollback, nil
+				}
+				continue
+			}
+			if n == 0 {
+				deleteConditions = append(deleteConditions, strings.Join(where, " AND "))
+			}
+			conflictConditions = append(conflictConditions, fmt.Sprintf("(%s)", strings.Join(where, " AND ")))
+		}
+	}
+
+	conn, err := i.getDbConn()
+	if err != nil {
+		return "", "", err
+	}
+	records, err := conn.Db.Query(fmt.Sprintf("SELECT * FROM %s WHERE %s LIMIT %d;", i.getTableNameWithQuote(table),
+		strings.Join(conflictConditions, " OR "), i.cnf.DMLRollbackMaxRows+1))
+	if err != nil {
+		return "", "", err
+	}
+	if int64(len(records)) > i.cnf.DMLRollbackMaxRows {
+		return "", NotSupportExceedMaxRowsRollback, nil
+	}
+	for _, record := range records {
+		where := []string{}
+		for _, name := range uniqueKeys[0] {
+			where = append(where, fmt.Sprintf("`%s` = %s", name, recordValueFormat(record[name])))
+		}
+		deleteConditions = append(deleteConditions, strings.Join(where, " AND "))
+	}
+
+	rollbackSql := ""
+	deleted := map[string]struct{}{}
+	for _, condition := range deleteConditions {
+		if _, ok := deleted[condition]; ok {
+			continue
+		}
+		deleted[condition] = struct{}{}
+		rollbackSql += fmt.Sprintf("DELETE FROM %s WHERE %s;\n", i.getTableNameWithQuote(table), condition)
+	}
+	if sql := i.generateInsertSqlByRecords(table, createTableStmt, records); sql != "" {
+		rollbackSql += sql + "\n"
+	}
+	return rollbackSql, "", nil
+}
+
 // getRecords select all data which will be update or delete.
 func (i *MysqlDriverImpl) getRecords(tableName *ast.TableName, tableAlias string, where ast.ExprNode,
 	order *ast.OrderByClause, limit int64) ([]map[string]sql.NullString, error) {
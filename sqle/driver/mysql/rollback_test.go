@@ -2,9 +2,13 @@ package mysql
 
 import (
 	"context"
+	"regexp"
 	"testing"
 
+	"github.com/actiontech/sqle/sqle/driver/mysql/executor"
 	"github.com/actiontech/sqle/sqle/driver/mysql/util"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -162,6 +166,87 @@ RENAME INDEX old_name TO new_name;`,
 	)
 }
 
+func runLossyRollbackCase(t *testing.T, desc string, sql string, results string, reason string) {
+	rollbackSql, lossyReason, err := DefaultMysqlInspect().GenRollbackSQL(context.TODO(), sql)
+	assert.NoError(t, err)
+	_, err = util.ParseSql(rollbackSql)
+	assert.NoError(t, err)
+	assert.Equal(t, results, rollbackSql, desc)
+	assert.Equal(t, reason, lossyReason, desc)
+}
+
+func TestAlterTableRollbackSqlForColumnAndOption(t *testing.T) {
+	runRollbackCase(t, "rename column need rename back", DefaultMysqlInspect(),
+		`ALTER TABLE exist_db.exist_tb_1
+RENAME COLUMN v1 TO v3;`,
+		"ALTER TABLE `exist_db`.`exist_tb_1`"+"\n"+
+			"RENAME COLUMN `v3` TO `v1`;",
+	)
+	runRollbackCase(t, "default charset need restore", DefaultMysqlInspect(),
+		`ALTER TABLE exist_db.exist_tb_1
+DEFAULT CHARSET = utf8, ENGINE = MyISAM, COMMENT = "new comment";`,
+		"ALTER TABLE `exist_db`.`exist_tb_1`"+"\n"+
+			"DEFAULT CHARACTER SET = UTF8MB4,\n"+
+			"ENGINE = InnoDB,\n"+
+			"COMMENT = 'unit test';",
+	)
+	runLossyRollbackCase(t, "convert to charset need convert back",
+		`ALTER TABLE exist_db.exist_tb_1
+CONVERT TO CHARACTER SET latin1;`,
+		"ALTER TABLE `exist_db`.`exist_tb_1`"+"\n"+
+			"CONVERT TO CHARACTER SET UTF8MB4;",
+		LossyConvertCharsetRollback,
+	)
+}
+
+func TestAlterTableRollbackSqlForPartition(t *testing.T) {
+	runRollbackCase(t, "add partition need drop", DefaultMysqlInspect(),
+		`ALTER TABLE exist_db.exist_tb_3
+ADD PARTITION (PARTITION p4 VALUES IN (10, 11));`,
+		"ALTER TABLE `exist_db`.`exist_tb_3`"+"\n"+
+			"DROP PARTITION `p4`;",
+	)
+	runLossyRollbackCase(t, "drop partition need add",
+		`ALTER TABLE exist_db.exist_tb_3
+DROP PARTITION p1, p3;`,
+		"ALTER TABLE `exist_db`.`exist_tb_3`"+"\n"+
+			"ADD PARTITION (PARTITION `p1` VALUES IN (1, 2, 3), PARTITION `p3` VALUES IN (7, 8, 9));",
+		LossyDropPartitionRollback,
+	)
+	runLossyRollbackCase(t, "truncate partition can not rollback",
+		`ALTER TABLE exist_db.exist_tb_3
+TRUNCATE PARTITION p1;`,
+		"",
+		LossyTruncatePartitionRollback,
+	)
+	runRollbackCase(t, "reorganize partition need reorganize back", DefaultMysqlInspect(),
+		`ALTER TABLE exist_db.exist_tb_3
+REORGANIZE PARTITION p1, p2 INTO (PARTITION p12 VALUES IN (1, 2, 3, 4, 5, 6));`,
+		"ALTER TABLE `exist_db`.`exist_tb_3`"+"\n"+
+			"REORGANIZE PARTITION `p12` INTO (PARTITION `p1` VALUES IN (1, 2, 3), PARTITION `p2` VALUES IN (4, 5, 6));",
+	)
+	runRollbackCase(t, "remove partitioning need partition", DefaultMysqlInspect(),
+		`ALTER TABLE exist_db.exist_tb_3
+REMOVE PARTITIONING;`,
+		"ALTER TABLE `exist_db`.`exist_tb_3`"+"\n"+
+			"PARTITION BY LIST (`v3`) (PARTITION `p1` VALUES IN (1, 2, 3),PARTITION `p2` VALUES IN (4, 5, 6),PARTITION `p3` VALUES IN (7, 8, 9));",
+	)
+	runRollbackCase(t, "partition by need remove partitioning", DefaultMysqlInspect(),
+		`ALTER TABLE exist_db.exist_tb_1
+ADD COLUMN v3 int PARTITION BY HASH(v3) PARTITIONS 4;`,
+		"ALTER TABLE `exist_db`.`exist_tb_1`"+"\n"+
+			"REMOVE PARTITIONING;\n"+
+			"ALTER TABLE `exist_db`.`exist_tb_1`"+"\n"+
+			"DROP COLUMN `v3`;",
+	)
+	runRollbackCase(t, "coalesce partition need add", DefaultMysqlInspect(),
+		`ALTER TABLE exist_db.exist_tb_3
+COALESCE PARTITION 2;`,
+		"ALTER TABLE `exist_db`.`exist_tb_3`"+"\n"+
+			"ADD PARTITION PARTITIONS 2;",
+	)
+}
+
 func TestInsertRollbackSql(t *testing.T) {
 	runRollbackCase(t, "insert into: need delete(1)", DefaultMysqlInspect(),
 		`INSERT INTO exist_db.exist_tb_1 (id,v1,v2) value (10,"v1","v2"),(11,"v1","v2");`,
@@ -178,3 +263,85 @@ func TestInsertRollbackSql(t *testing.T) {
 		"DELETE FROM `exist_db`.`exist_tb_1` WHERE id = '10';\n",
 	)
 }
+
+func newRollbackMockInspect(t *testing.T) (*MysqlDriverImpl, sqlmock.Sqlmock) {
+	e, handler, err := executor.NewMockExecutor()
+	assert.NoError(t, err)
+	inspect := NewMockInspect(e)
+	inspect.isConnected = true
+	return inspect, handler
+}
+
+func TestMultiTableDeleteRollbackSql(t *testing.T) {
+	inspect, handler := newRollbackMockInspect(t)
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `t1`.* FROM `exist_db`.`exist_tb_1` AS `t1` JOIN `exist_db`.`exist_tb_4` AS `t4` ON `t1`.`id`=`t4`.`id` WHERE `t1`.`v1` = \"a\" LIMIT 1001;")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v1", "v2"}).AddRow("1", "a", nil))
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `t4`.* FROM `exist_db`.`exist_tb_1` AS `t1` JOIN `exist_db`.`exist_tb_4` AS `t4` ON `t1`.`id`=`t4`.`id` WHERE `t1`.`v1` = \"a\" LIMIT 1001;")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v1", "v2", "v3"}).AddRow("1", "b", "c", "3"))
+	runRollbackCase(t, "multi-table delete: need insert", inspect,
+		`DELETE t1, t4 FROM exist_db.exist_tb_1 AS t1 JOIN exist_db.exist_tb_4 AS t4 ON t1.id = t4.id WHERE t1.v1 = "a";`,
+		"INSERT INTO `exist_db`.`exist_tb_1` (`id`, `v1`, `v2`) VALUES ('1', 'a', NULL);\n"+
+			"INSERT INTO `exist_db`.`exist_tb_4` (`id`, `v1`, `v2`, `v3`) VALUES ('1', 'b', 'c', '3');",
+	)
+	assert.NoError(t, handler.ExpectationsWereMet())
+
+	_, reason, err := inspect.GenRollbackSQL(context.TODO(),
+		"DELETE t2 FROM exist_db.exist_tb_1 JOIN exist_db.exist_tb_2 AS t2 ON exist_tb_1.id = t2.user_id;")
+	assert.NoError(t, err)
+	assert.Equal(t, NotSupportNoPrimaryKeyTableRollback, reason)
+}
+
+func TestMultiTableUpdateRollbackSql(t *testing.T) {
+	inspect, handler := newRollbackMockInspect(t)
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `t1`.* FROM `exist_db`.`exist_tb_1` AS `t1` JOIN `exist_db`.`exist_tb_4` AS `t4` ON `t1`.`id`=`t4`.`id` WHERE `t4`.`v3` = 1 LIMIT 1001;")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v1", "v2"}).AddRow("1", "a", "b").AddRow("2", "c", nil))
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `t4`.* FROM `exist_db`.`exist_tb_1` AS `t1` JOIN `exist_db`.`exist_tb_4` AS `t4` ON `t1`.`id`=`t4`.`id` WHERE `t4`.`v3` = 1 LIMIT 1001;")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v1", "v2", "v3"}).AddRow("1", "d", "e", "1"))
+	runRollbackCase(t, "multi-table update: need update", inspect,
+		`UPDATE exist_db.exist_tb_1 AS t1 JOIN exist_db.exist_tb_4 AS t4 ON t1.id = t4.id SET t1.v2 = t4.v2, v3 = 2 WHERE t4.v3 = 1;`,
+		"UPDATE `exist_db`.`exist_tb_1` SET `v2` = 'b' WHERE `id` = '1';"+
+			"UPDATE `exist_db`.`exist_tb_1` SET `v2` = NULL WHERE `id` = '2';"+
+			"UPDATE `exist_db`.`exist_tb_4` SET `v3` = '1' WHERE `id` = '1';",
+	)
+	assert.NoError(t, handler.ExpectationsWereMet())
+
+	for sql, expected := range map[string]string{
+		"UPDATE exist_db.exist_tb_1 AS t1 JOIN exist_db.exist_tb_4 AS t4 ON t1.id = t4.id SET v1 = 'a';":    NotSupportAmbiguousColumnRollback,
+		"UPDATE exist_db.exist_tb_1 AS t1 JOIN exist_db.exist_tb_4 AS t4 ON t1.id = t4.id SET t4.id = 10;":  NotSupportUpdatePrimaryKeyRollback,
+		"UPDATE exist_db.exist_tb_1 AS t1 JOIN exist_db.exist_tb_2 AS t2 ON t1.id = t2.id SET t2.v1 = 'a';": NotSupportNoPrimaryKeyTableRollback,
+	} {
+		_, reason, err := inspect.GenRollbackSQL(context.TODO(), sql)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, reason, sql)
+	}
+}
+
+func TestUpsertRollbackSql(t *testing.T) {
+	inspect, handler := newRollbackMockInspect(t)
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `exist_db`.`exist_tb_1` WHERE (`id` = 10) OR (`v1` = 'a' AND `v2` = 'b') OR (`id` = 11) OR (`v1` = 'c' AND `v2` = 'd') LIMIT 1001;")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v1", "v2"}).AddRow("3", "a", "b"))
+	runRollbackCase(t, "insert on duplicate key update: need delete and insert", inspect,
+		`INSERT INTO exist_db.exist_tb_1 (id,v1,v2) VALUES (10,"a","b"),(11,"c","d") ON DUPLICATE KEY UPDATE v2 = "e";`,
+		"DELETE FROM `exist_db`.`exist_tb_1` WHERE `id` = 10;\n"+
+			"DELETE FROM `exist_db`.`exist_tb_1` WHERE `id` = 11;\n"+
+			"DELETE FROM `exist_db`.`exist_tb_1` WHERE `id` = '3';\n"+
+			"INSERT INTO `exist_db`.`exist_tb_1` (`id`, `v1`, `v2`) VALUES ('3', 'a', 'b');\n",
+	)
+
+	handler.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `exist_db`.`exist_tb_4` WHERE (`id` = 10) LIMIT 1001;")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v1", "v2", "v3"}))
+	runRollbackCase(t, "replace into: need delete", inspect,
+		`REPLACE INTO exist_db.exist_tb_4 SET id = 10, v1 = "a";`,
+		"DELETE FROM `exist_db`.`exist_tb_4` WHERE `id` = 10;\n",
+	)
+	assert.NoError(t, handler.ExpectationsWereMet())
+
+	for sql, expected := range map[string]string{
+		`INSERT INTO exist_db.exist_tb_1 (id,v1,v2) VALUES (10,"a","b") ON DUPLICATE KEY UPDATE id = 12;`: NotSupportUpdatePrimaryKeyRollback,
+		`REPLACE INTO exist_db.exist_tb_1 (v1,v2) VALUES ("a","b");`:                                      NotSupportInsertWithoutPrimaryKeyRollback,
+	} {
+		_, reason, err := inspect.GenRollbackSQL(context.TODO(), sql)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, reason, sql)
+	}
+}
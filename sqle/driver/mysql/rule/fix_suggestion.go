@@ -0,0 +1,168 @@
+package rule
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/actiontech/sqle/sqle/driver/mysql/session"
+	"github.com/actiontech/sqle/sqle/driver/mysql/util"
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+	"github.com/pingcap/parser/model"
+)
+
+// DefaultFixSuggestionDMLLimit is the LIMIT used to bound DELETE/UPDATE in fix suggestion.
+const DefaultFixSuggestionDMLLimit = 1000
+
+// addResultWithFix is the same as addResult, and attaches the fix suggestion generated
+// by genFix to the result. genFix is only called when the rule is current rule.
+func addResultWithFix(result *driverV2.AuditResults, currentRule driverV2.Rule, ruleName string, genFix func() *driverV2.FixSuggestion, args ...interface{}) {
+	if ruleName != currentRule.Name {
+		return
+	}
+	addResult(result, currentRule, ruleName, args...)
+	if fix := genFix(); fix != nil {
+		result.SetFixSuggestion(ruleName, fix)
+	}
+}
+
+// rewriteStmt parses the text of node again and rewrites the new AST by rewrite,
+// so that the original AST shared by all the rules will not be modified. It returns
+// nil if the SQL can not be rewritten.
+func rewriteStmt(node ast.Node, description string, rewrite func(stmt ast.StmtNode) bool) *driverV2.FixSuggestion {
+	stmt, err := util.ParseOneSql(node.Text())
+	if err != nil {
+		return nil
+	}
+	if !rewrite(stmt) {
+		return nil
+	}
+	buf := new(bytes.Buffer)
+	if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, buf)); err != nil {
+		return nil
+	}
+	return &driverV2.FixSuggestion{
+		Description:  description,
+		RewrittenSQL: buf.String(),
+	}
+}
+
+// fixDMLWithoutLimit adds LIMIT to the single table DELETE/UPDATE,
+// multi-table DELETE/UPDATE does not support LIMIT.
+func fixDMLWithoutLimit(node ast.Node) *driverV2.FixSuggestion {
+	limit := &ast.Limit{Count: ast.NewValueExpr(DefaultFixSuggestionDMLLimit, "", "")}
+	description := fmt.Sprintf("添加 LIMIT %d, 限制单次变更的行数", DefaultFixSuggestionDMLLimit)
+	return rewriteStmt(node, description, func(stmt ast.StmtNode) bool {
+		switch stmt := stmt.(type) {
+		case *ast.DeleteStmt:
+			if stmt.IsMultiTable || stmt.Limit != nil {
+				return false
+			}
+			stmt.Limit = limit
+		case *ast.UpdateStmt:
+			if stmt.MultipleTable || stmt.Limit != nil {
+				return false
+			}
+			stmt.Limit = limit
+		default:
+			return false
+		}
+		return true
+	})
+}
+
+// fixSelectAllColumn expands the wildcard in the SELECT field list to the columns
+// of the table, the table definition is fetched from session context.
+func fixSelectAllColumn(ctx *session.Context, node ast.Node) *driverV2.FixSuggestion {
+	if ctx == nil {
+		return nil
+	}
+	return rewriteStmt(node, "将 SELECT * 展开为具体的列", func(stmt ast.StmtNode) bool {
+		selectStmt, ok := stmt.(*ast.SelectStmt)
+		if !ok || selectStmt.From == nil || selectStmt.Fields == nil {
+			return false
+		}
+		tableSources := util.GetTableSources(selectStmt.From.TableRefs)
+		if len(tableSources) == 0 {
+			return false
+		}
+		type table struct {
+			name   string
+			create *ast.CreateTableStmt
+		}
+		tables := make([]table, 0, len(tableSources))
+		for _, source := range tableSources {
+			tableName, ok := source.Source.(*ast.TableName)
+			if !ok {
+				return false
+			}
+			create, exist, err := ctx.GetCreateTableStmt(tableName)
+			if err != nil || !exist {
+				return false
+			}
+			name := tableName.Name.O
+			if source.AsName.L != "" {
+				name = source.AsName.O
+			}
+			tables = append(tables, table{name: name, create: create})
+		}
+
+		fields := make([]*ast.SelectField, 0, len(selectStmt.Fields.Fields))
+		for _, field := range selectStmt.Fields.Fields {
+			if field.WildCard == nil {
+				fields = append(fields, field)
+				continue
+			}
+			matched := false
+			for _, t := range tables {
+				if field.WildCard.Table.L != "" && field.WildCard.Table.L != model.NewCIStr(t.name).L {
+					continue
+				}
+				matched = true
+				for _, col := range t.create.Cols {
+					colName := &ast.ColumnName{Name: col.Name.Name}
+					if len(tables) > 1 || field.WildCard.Table.L != "" {
+						colName.Table = model.NewCIStr(t.name)
+					}
+					fields = append(fields, &ast.SelectField{Expr: &ast.ColumnNameExpr{Name: colName}})
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		selectStmt.Fields.Fields = fields
+		return true
+	})
+}
+
+const fixSuggestionPKColumnName = "id"
+
+// fixCreateTableWithoutPK adds an auto increment primary key to the table. If the
+// table already has the column "id", the column will be used as primary key.
+func fixCreateTableWithoutPK(node ast.Node) *driverV2.FixSuggestion {
+	return rewriteStmt(node, "为表添加自增主键", func(stmt ast.StmtNode) bool {
+		createStmt, ok := stmt.(*ast.CreateTableStmt)
+		if !ok || createStmt.ReferTable != nil || createStmt.Select != nil || util.HasPrimaryKey(createStmt) {
+			return false
+		}
+		if util.TableExistCol(createStmt, fixSuggestionPKColumnName) {
+			createStmt.Constraints = append(createStmt.Constraints, &ast.Constraint{
+				Tp: ast.ConstraintPrimaryKey,
+				Keys: []*ast.IndexPartSpecification{
+					{Column: &ast.ColumnName{Name: model.NewCIStr(fixSuggestionPKColumnName)}},
+				},
+			})
+			return true
+		}
+		template, err := util.ParseOneSql(fmt.Sprintf(
+			"CREATE TABLE t (`%s` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY)", fixSuggestionPKColumnName))
+		if err != nil {
+			return false
+		}
+		createStmt.Cols = append(template.(*ast.CreateTableStmt).Cols, createStmt.Cols...)
+		return true
+	})
+}
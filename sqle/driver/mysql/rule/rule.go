@@ -207,6 +207,8 @@ const (
 	ConfigOptimizeIndexEnabled     = "optimize_index_enabled"
 	ConfigDMLExplainPreCheckEnable = "dml_enable_explain_pre_check"
 	ConfigSQLIsExecuted            = "sql_is_executed"
+	ConfigDMLRollbackByBinlog      = "dml_rollback_by_binlog"
+	ConfigDMLChunkExecution        = "dml_chunk_execution"
 )
 
 // 计算单位
@@ -294,6 +296,8 @@ const DefaultSingleParamKeyName = "first_key" // For most of the rules, it is ju
 const (
 	DefaultMultiParamsFirstKeyName  = "multi_params_first_key"
 	DefaultMultiParamsSecondKeyName = "multi_params_second_key"
+	DefaultMultiParamsThirdKeyName  = "multi_params_third_key"
+	DefaultMultiParamsFourthKeyName = "multi_params_fourth_key"
 )
 
 func checkMathComputationOrFuncOnIndex(input *RuleHandlerInput) error {
@@ -1023,7 +1027,10 @@ func checkSelectAll(input *RuleHandlerInput) error {
 		if stmt.Fields != nil && stmt.Fields.Fields != nil {
 			for _, field := range stmt.Fields.Fields {
 				if field.WildCard != nil {
-					addResult(input.Res, input.Rule, DMLDisableSelectAllColumn)
+					addResultWithFix(input.Res, input.Rule, DMLDisableSelectAllColumn, func() *driverV2.FixSuggestion {
+						return fixSelectAllColumn(input.Ctx, stmt)
+					})
+					break
 				}
 			}
 		}
@@ -1231,7 +1238,9 @@ func checkPrimaryKey(input *RuleHandlerInput) error {
 			}
 		}
 		if !hasPk {
-			addResult(input.Res, input.Rule, DDLCheckPKNotExist)
+			addResultWithFix(input.Res, input.Rule, DDLCheckPKNotExist, func() *driverV2.FixSuggestion {
+				return fixCreateTableWithoutPK(stmt)
+			})
 		}
 		if hasPk && pkColumnExist && !pkIsAutoIncrement {
 			addResult(input.Res, input.Rule, DDLCheckPKWithoutAutoIncrement)
@@ -2631,11 +2640,15 @@ func checkDMLLimitExist(input *RuleHandlerInput) error {
 	switch stmt := input.Node.(type) {
 	case *ast.UpdateStmt:
 		if stmt.Limit == nil {
-			addResult(input.Res, input.Rule, DMLCheckLimitMustExist)
+			addResultWithFix(input.Res, input.Rule, DMLCheckLimitMustExist, func() *driverV2.FixSuggestion {
+				return fixDMLWithoutLimit(stmt)
+			})
 		}
 	case *ast.DeleteStmt:
 		if stmt.Limit == nil {
-			addResult(input.Res, input.Rule, DMLCheckLimitMustExist)
+			addResultWithFix(input.Res, input.Rule, DMLCheckLimitMustExist, func() *driverV2.FixSuggestion {
+				return fixDMLWithoutLimit(stmt)
+			})
 		}
 	}
 	return nil
@@ -163,6 +163,52 @@ var RuleHandlers = []RuleHandler{
 		},
 	},
 
+	{
+		Rule: driverV2.Rule{
+			Name:       ConfigDMLRollbackByBinlog,
+			Desc:       "上线后基于 binlog 生成 DML 回滚语句",
+			Annotation: "开启该规则后，上线时会记录 DML 执行前后的 binlog 位点，上线完成后读取该区间内由上线连接产生的行事件，生成精确的逆向语句替换上线前生成的回滚语句，适用于非确定性 DML 和影响行数较大的 DML；要求实例开启 binlog，且 binlog_format=ROW、binlog_row_image=FULL，上线账号需要 REPLICATION SLAVE 和 REPLICATION CLIENT 权限",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeGlobalConfig,
+		},
+	},
+
+	{
+		Rule: driverV2.Rule{
+			Name:       ConfigDMLChunkExecution,
+			Desc:       "DML 预计影响行数超过指定值时按主键分批上线",
+			Annotation: "开启该规则后，预计影响行数超过阈值的单表 UPDATE/DELETE 会按主键范围拆分为多个批次依次执行，每个批次单独提交，批次之间休眠指定时间，并在从库延迟超过阈值时等待从库追上，避免长时间锁表和从库延迟；要求表有单列主键，且语句不包含 ORDER BY 和 LIMIT，UPDATE 不能修改主键；每个批次会在执行前生成回滚语句",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeGlobalConfig,
+			Params: params.Params{
+				&params.Param{
+					Key:   DefaultMultiParamsFirstKeyName,
+					Value: "100000",
+					Desc:  "预计影响行数",
+					Type:  params.ParamTypeInt,
+				},
+				&params.Param{
+					Key:   DefaultMultiParamsSecondKeyName,
+					Value: "1000",
+					Desc:  "每批行数",
+					Type:  params.ParamTypeInt,
+				},
+				&params.Param{
+					Key:   DefaultMultiParamsThirdKeyName,
+					Value: "100",
+					Desc:  "批次间隔（毫秒）",
+					Type:  params.ParamTypeInt,
+				},
+				&params.Param{
+					Key:   DefaultMultiParamsFourthKeyName,
+					Value: "5",
+					Desc:  "从库最大延迟（秒），0 表示不检查",
+					Type:  params.ParamTypeInt,
+				},
+			},
+		},
+	},
+
 	{
 		Rule: driverV2.Rule{
 			Name:       ConfigDDLGhostMinSize,
@@ -0,0 +1,40 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/actiontech/sqle/sqle/driver/mysql/executor"
+)
+
+// the keys of additional params are the names of session variables.
+const (
+	paramKeyMaxExecutionTime      = "max_execution_time"
+	paramKeyLockWaitTimeout       = "lock_wait_timeout"
+	paramKeyInnodbLockWaitTimeout = "innodb_lock_wait_timeout"
+)
+
+var sessionTimeoutParamKeys = []string{
+	paramKeyMaxExecutionTime,
+	paramKeyLockWaitTimeout,
+	paramKeyInnodbLockWaitTimeout,
+}
+
+// setSessionTimeouts applies the timeouts configured in the additional params of instance to the
+// session before executing statements, so that the statement blocked on locks fails instead of
+// hanging. The session variable is kept as the server default if the param is 0.
+func (i *MysqlDriverImpl) setSessionTimeouts(conn *executor.Executor) error {
+	assignments := []string{}
+	for _, key := range sessionTimeoutParamKeys {
+		if v := i.inst.AdditionalParams.GetParam(key).Int(); v > 0 {
+			assignments = append(assignments, fmt.Sprintf("SESSION %s = %d", key, v))
+		}
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+	if _, err := conn.Db.Exec("SET " + strings.Join(assignments, ", ")); err != nil {
+		return fmt.Errorf("set session timeouts: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/pkg/params"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecWithSessionTimeouts(t *testing.T) {
+	inspect, handler := newRollbackMockInspect(t)
+	sql := "UPDATE exist_db.exist_tb_1 SET v1 = 'a'"
+
+	// the session variables are not set by default
+	handler.ExpectExec(regexp.QuoteMeta(sql)).WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := inspect.Exec(context.TODO(), sql)
+	assert.NoError(t, err)
+
+	inspect.inst.AdditionalParams = params.Params{
+		{Key: paramKeyMaxExecutionTime, Value: "0", Type: params.ParamTypeInt},
+		{Key: paramKeyLockWaitTimeout, Value: "10", Type: params.ParamTypeInt},
+		{Key: paramKeyInnodbLockWaitTimeout, Value: "5", Type: params.ParamTypeInt},
+	}
+	handler.ExpectExec(regexp.QuoteMeta("SET SESSION lock_wait_timeout = 10, SESSION innodb_lock_wait_timeout = 5")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	handler.ExpectExec(regexp.QuoteMeta(sql)).WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = inspect.Exec(context.TODO(), sql)
+	assert.NoError(t, err)
+
+	handler.ExpectExec(regexp.QuoteMeta("SET SESSION lock_wait_timeout = 10, SESSION innodb_lock_wait_timeout = 5")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	handler.ExpectBegin()
+	handler.ExpectExec(regexp.QuoteMeta(sql)).WillReturnResult(sqlmock.NewResult(0, 1))
+	handler.ExpectCommit()
+	_, err = inspect.Tx(context.TODO(), sql)
+	assert.NoError(t, err)
+	assert.NoError(t, handler.ExpectationsWereMet())
+}
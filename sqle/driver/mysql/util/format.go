@@ -8,6 +8,7 @@ import (
 	"github.com/actiontech/sqle/sqle/log"
 
 	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
 )
 
 func AlterTableStmtFormat(stmt *ast.AlterTableStmt) string {
@@ -100,6 +101,18 @@ func AlterTableSpecFormat(stmt *ast.AlterTableSpec) string {
 		return fmt.Sprintf("DROP FOREIGN KEY `%s`", stmt.Name)
 	case ast.AlterTableRenameIndex:
 		return fmt.Sprintf("RENAME INDEX `%s` TO `%s`", stmt.FromKey, stmt.ToKey)
+	case ast.AlterTableRenameColumn:
+		return fmt.Sprintf("RENAME COLUMN `%s` TO `%s`", stmt.OldColumnName.Name, stmt.NewColumnName.Name)
+	case ast.AlterTableOption, ast.AlterTableAddPartitions, ast.AlterTableDropPartition, ast.AlterTableTruncatePartition,
+		ast.AlterTableCoalescePartitions, ast.AlterTableReorganizePartition, ast.AlterTablePartition,
+		ast.AlterTableRemovePartitioning, ast.AlterTableExchangePartition:
+		// the table options and partition definitions are restored by parser.
+		sql, err := restoreToSqlWithFlag(format.DefaultRestoreFlags, stmt)
+		if err != nil {
+			log.NewEntry().Errorf("restore alter table spec %d error: %v", stmt.Tp, err)
+			return ""
+		}
+		return sql
 	}
 	return ""
 }
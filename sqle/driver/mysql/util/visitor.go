@@ -116,6 +116,35 @@ func (te *TableNameExtractor) Leave(in ast.Node) (node ast.Node, ok bool) {
 	return in, true
 }
 
+// SchemaNameExtractor extracts the schemas named by the statement explicitly.
+type SchemaNameExtractor struct {
+	SchemaNames map[string]struct{}
+}
+
+func (se *SchemaNameExtractor) Enter(in ast.Node) (node ast.Node, skipChildren bool) {
+	name := ""
+	switch stmt := in.(type) {
+	case *ast.TableName:
+		name = stmt.Schema.O
+	case *ast.CreateDatabaseStmt:
+		name = stmt.Name
+	case *ast.AlterDatabaseStmt:
+		name = stmt.Name
+	case *ast.DropDatabaseStmt:
+		name = stmt.Name
+	case *ast.UseStmt:
+		name = stmt.DBName
+	}
+	if name != "" {
+		se.SchemaNames[name] = struct{}{}
+	}
+	return in, false
+}
+
+func (se *SchemaNameExtractor) Leave(in ast.Node) (node ast.Node, ok bool) {
+	return in, true
+}
+
 type SelectStmtExtractor struct {
 	SelectStmts []*ast.SelectStmt
 }
@@ -0,0 +1,46 @@
+package driver
+
+import (
+	"context"
+)
+
+// OnlineDDLControl is the runtime control of the online DDL. The zero value of numeric
+// field means keeping the current value, except that nil NiceRatio does, as 0 is a valid
+// nice ratio.
+type OnlineDDLControl struct {
+	Paused       bool
+	NiceRatio    *float64
+	MaxLagMillis int64
+	ChunkSize    int64
+}
+
+func (c *OnlineDDLControl) Equal(other *OnlineDDLControl) bool {
+	if c.Paused != other.Paused || c.MaxLagMillis != other.MaxLagMillis || c.ChunkSize != other.ChunkSize {
+		return false
+	}
+	if c.NiceRatio == nil || other.NiceRatio == nil {
+		return c.NiceRatio == nil && other.NiceRatio == nil
+	}
+	return *c.NiceRatio == *other.NiceRatio
+}
+
+type OnlineDDLProgress struct {
+	RowsCopied     int64
+	RowsEstimate   int64
+	ProgressPct    float64
+	ETASeconds     int64
+	Throttled      bool
+	ThrottleReason string
+}
+
+// OnlineDDLController is implemented by the plugin which executes DDL by online DDL tool, such
+// as gh-ost, the running online DDL can be paused, resumed and throttled by it. Now only the
+// built-in MySQL plugin implements it.
+type OnlineDDLController interface {
+	// ControlOnlineDDL applies the control to the running online DDL, running is false if
+	// there is no online DDL running.
+	ControlOnlineDDL(ctx context.Context, control *OnlineDDLControl) (running bool, err error)
+
+	// GetOnlineDDLProgress returns nil if there is no online DDL running.
+	GetOnlineDDLProgress(ctx context.Context) (*OnlineDDLProgress, error)
+}
@@ -172,6 +172,10 @@ func (p *PluginImplV1) Audit(ctx context.Context, sqls []string) ([]*driverV2.Au
 	return resultsV2, nil
 }
 
+func (p *PluginImplV1) AuditStream(ctx context.Context, source AuditStreamSource, handler AuditStreamHandler) error {
+	return AuditInBatches(ctx, p.Audit, source, handler)
+}
+
 func (p *PluginImplV1) GenRollbackSQL(ctx context.Context, sql string) (string, string, error) {
 	client, err := p.DriverManager.GetAuditDriver()
 	if err != nil {
@@ -311,6 +315,10 @@ func (p *PluginImplV1) GetTableMetaBySQL(ctx context.Context, conf *GetTableMeta
 	return resultV2, nil
 }
 
+func (p *PluginImplV1) ExtractTableFromSQL(ctx context.Context, sql string) ([]*driverV2.Table, error) {
+	return nil, NewErrPluginAPINotImplement(driverV2.OptionalModuleExtractTableFromSQL)
+}
+
 func (p *PluginImplV1) EstimateSQLAffectRows(ctx context.Context, sql string) (*driverV2.EstimatedAffectRows, error) {
 	return nil, NewErrPluginAPINotImplement(driverV2.OptionalModuleEstimateSQLAffectRows)
 }
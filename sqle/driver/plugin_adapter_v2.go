@@ -5,6 +5,7 @@ import (
 	sqlDriver "database/sql/driver"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 
 	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
@@ -14,6 +15,7 @@ import (
 
 	goPlugin "github.com/hashicorp/go-plugin"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -232,20 +234,79 @@ func (s *PluginImplV2) Audit(ctx context.Context, sqls []string) ([]*driverV2.Au
 	if err != nil {
 		return nil, err
 	}
+	return driverV2.ConvertAuditResultsFromProtoToDriver(resp.AuditResults), nil
+}
 
-	rets := []*driverV2.AuditResults{}
-	for _, results := range resp.AuditResults {
-		ret := &driverV2.AuditResults{}
-		for _, result := range results.Results {
-			ret.Results = append(ret.Results, &driverV2.AuditResult{
-				Level:    driverV2.RuleLevel(result.Level),
-				Message:  result.Message,
-				RuleName: result.RuleName,
-			})
+func (s *PluginImplV2) AuditStream(ctx context.Context, source AuditStreamSource, handler AuditStreamHandler) error {
+	api := "AuditStream"
+	s.preLog(api)
+	sqls, err := source()
+	if err == nil {
+		err = s.auditStream(ctx, sqls, source, handler)
+	}
+	if status.Code(err) == codes.Unimplemented {
+		// the plugin is built before AuditStream is introduced, fall back to audit batch by batch.
+		s.l.Warnf("plugin interface [%s] is not implemented, audit SQLs in batches by [Audit]", api)
+		err = AuditInBatches(ctx, s.Audit, prependAuditStreamBatch(sqls, source), handler)
+	}
+	s.afterLog(api, err)
+	return err
+}
+
+// prependAuditStreamBatch returns the source which returns the batch before the batches of source.
+func prependAuditStreamBatch(sqls []string, source AuditStreamSource) AuditStreamSource {
+	returned := false
+	return func() ([]string, error) {
+		if returned {
+			return source()
+		}
+		returned = true
+		return sqls, nil
+	}
+}
+
+// auditStream sends one batch and waits for its results before getting the next one from
+// source, so neither side keeps more than one batch in memory. The first batch is got from
+// source by the caller, so the source is untouched if the stream is not implemented by plugin.
+func (s *PluginImplV2) auditStream(ctx context.Context, sqls []string, source AuditStreamSource, handler AuditStreamHandler) error {
+	if len(sqls) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.client.AuditStream(ctx)
+	if err != nil {
+		return err
+	}
+	for len(sqls) > 0 {
+		auditSqls := make([]*protoV2.AuditSQL, 0, len(sqls))
+		for _, sql := range sqls {
+			auditSqls = append(auditSqls, &protoV2.AuditSQL{Query: sql})
+		}
+		err = stream.Send(&protoV2.AuditRequest{
+			Session: s.Session,
+			Sqls:    auditSqls,
+		})
+		// io.EOF means the stream is aborted by server, the real error is returned by Recv.
+		if err != nil && err != io.EOF {
+			return err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if len(resp.AuditResults) != len(sqls) {
+			return fmt.Errorf("audit results [%d] does not match the number of SQL [%d]", len(resp.AuditResults), len(sqls))
+		}
+		if err := handler(driverV2.ConvertAuditResultsFromProtoToDriver(resp.AuditResults)); err != nil {
+			return err
+		}
+		if sqls, err = source(); err != nil {
+			return err
 		}
-		rets = append(rets, ret)
 	}
-	return rets, nil
+	return stream.CloseSend()
 }
 
 func (s *PluginImplV2) GenRollbackSQL(ctx context.Context, sql string) (string, string, error) {
@@ -426,7 +487,7 @@ func (s *PluginImplV2) getTableMeta(ctx context.Context, table *driverV2.Table)
 	return driverV2.ConvertProtoTableMetaToDriver(result.TableMeta), nil
 }
 
-func (s *PluginImplV2) extractTableFromSQL(ctx context.Context, sql string) ([]*driverV2.Table, error) {
+func (s *PluginImplV2) ExtractTableFromSQL(ctx context.Context, sql string) ([]*driverV2.Table, error) {
 	api := "ExtractTableFromSQL"
 	s.preLog(api)
 	result, err := s.client.ExtractTableFromSQL(ctx, &protoV2.ExtractTableFromSQLRequest{
@@ -448,7 +509,7 @@ func (s *PluginImplV2) extractTableFromSQL(ctx context.Context, sql string) ([]*
 }
 
 func (s *PluginImplV2) GetTableMetaBySQL(ctx context.Context, conf *GetTableMetaBySQLConf) (*GetTableMetaBySQLResult, error) {
-	tables, err := s.extractTableFromSQL(ctx, conf.Sql)
+	tables, err := s.ExtractTableFromSQL(ctx, conf.Sql)
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +18,11 @@ type Plugin interface {
 	// Audit sql with rules. sql is single SQL text or multi audit.
 	Audit(ctx context.Context, sqls []string) ([]*driverV2.AuditResults, error)
 
+	// AuditStream audit sqls with rules batch by batch, the batches are got from source and
+	// handler is called with the results of each batch as soon as they are returned. It is
+	// used to audit very large SQL batches.
+	AuditStream(ctx context.Context, source AuditStreamSource, handler AuditStreamHandler) error
+
 	// GenRollbackSQL generate sql's rollback SQL.
 	GenRollbackSQL(ctx context.Context, sql string) (string, string, error)
 
@@ -38,6 +43,9 @@ type Plugin interface {
 	// in v2, this is a virtual api, it is a combination of [ExtractTableFromSQL, GetTableMeta]
 	GetTableMetaBySQL(ctx context.Context, conf *GetTableMetaBySQLConf) (*GetTableMetaBySQLResult, error)
 
+	// ExtractTableFromSQL returns the tables used by the SQL, this SQL should be a single SQL.
+	ExtractTableFromSQL(ctx context.Context, sql string) ([]*driverV2.Table, error)
+
 	// Introduced from v2.2304.0
 	EstimateSQLAffectRows(ctx context.Context, sql string) (*driverV2.EstimatedAffectRows, error)
 }
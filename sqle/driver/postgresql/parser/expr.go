@@ -0,0 +1,257 @@
+package parser
+
+import (
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v5"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// walk calls fn for the message and all messages in it, the fields are visited in
+// the order of declaration.
+func walk(m protoreflect.Message, fn func(protoreflect.Message)) {
+	fn(m)
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind {
+			continue
+		}
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				walk(list.Get(j).Message(), fn)
+			}
+		} else if m.Has(fd) {
+			walk(m.Get(fd).Message(), fn)
+		}
+	}
+}
+
+// tablesOf returns the tables referenced in the nodes, the names of common table
+// expressions are excluded.
+func tablesOf(nodes ...*pg_query.Node) []TableName {
+	rangeVars := []*pg_query.RangeVar{}
+	ctes := map[string]struct{}{}
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		walk(n.ProtoReflect(), func(m protoreflect.Message) {
+			switch v := m.Interface().(type) {
+			case *pg_query.RangeVar:
+				rangeVars = append(rangeVars, v)
+			case *pg_query.CommonTableExpr:
+				ctes[v.Ctename] = struct{}{}
+			}
+		})
+	}
+	tables := []TableName{}
+	exist := map[TableName]struct{}{}
+	for _, rv := range rangeVars {
+		if _, ok := ctes[rv.Relname]; ok && rv.Schemaname == "" {
+			continue
+		}
+		table := tableName(rv)
+		if _, ok := exist[table]; ok {
+			continue
+		}
+		exist[table] = struct{}{}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+func deparseStmt(stmt *pg_query.Node) (string, error) {
+	return pg_query.Deparse(&pg_query.ParseResult{Stmts: []*pg_query.RawStmt{{Stmt: stmt}}})
+}
+
+// deparseExpr returns the text of expression, it is empty if expr is nil.
+func deparseExpr(expr *pg_query.Node) string {
+	if expr == nil {
+		return ""
+	}
+	sel := &pg_query.SelectStmt{TargetList: []*pg_query.Node{pg_query.MakeResTargetNodeWithVal(expr, 0)}}
+	text, err := deparseStmt(&pg_query.Node{Node: &pg_query.Node_SelectStmt{SelectStmt: sel}})
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(text, "SELECT ")
+}
+
+func deparseTypeName(typ *pg_query.TypeName) string {
+	if typ == nil {
+		return ""
+	}
+	cast := &pg_query.Node{Node: &pg_query.Node_TypeCast{TypeCast: &pg_query.TypeCast{
+		Arg:      &pg_query.Node{Node: &pg_query.Node_AConst{AConst: &pg_query.A_Const{Isnull: true}}},
+		TypeName: typ,
+	}}}
+	return strings.TrimPrefix(deparseExpr(cast), "NULL::")
+}
+
+const deparsedAlterTablePrefix = "ALTER TABLE t "
+
+// deparseAlterTableCmd returns the text of command, e.g. "ADD COLUMN c1 int".
+func deparseAlterTableCmd(cmd *pg_query.AlterTableCmd) string {
+	alter := &pg_query.AlterTableStmt{
+		Relation: pg_query.MakeSimpleRangeVar("t", 0),
+		Cmds:     []*pg_query.Node{{Node: &pg_query.Node_AlterTableCmd{AlterTableCmd: cmd}}},
+		Objtype:  pg_query.ObjectType_OBJECT_TABLE,
+	}
+	text, err := deparseStmt(&pg_query.Node{Node: &pg_query.Node_AlterTableStmt{AlterTableStmt: alter}})
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(text, deparsedAlterTablePrefix)
+}
+
+func deparseConstraint(c *pg_query.Constraint) string {
+	return strings.TrimPrefix(deparseAlterTableCmd(&pg_query.AlterTableCmd{
+		Subtype: pg_query.AlterTableType_AT_AddConstraint,
+		Def:     &pg_query.Node{Node: &pg_query.Node_Constraint{Constraint: c}},
+	}), "ADD ")
+}
+
+// parseExpr parses the expression, it returns nil if expr is not a valid expression.
+func parseExpr(expr string) *pg_query.Node {
+	tree, err := pg_query.Parse("SELECT " + expr)
+	if err != nil || len(tree.Stmts) != 1 {
+		return nil
+	}
+	targets := tree.Stmts[0].Stmt.GetSelectStmt().GetTargetList()
+	if len(targets) != 1 {
+		return nil
+	}
+	return targets[0].GetResTarget().GetVal()
+}
+
+// Fingerprint returns the normalized sql, literals are replaced with parameters.
+func Fingerprint(sql string) string {
+	normalized, err := pg_query.Normalize(sql)
+	if err != nil {
+		return sql
+	}
+	tree, err := pg_query.Parse(normalized)
+	if err != nil {
+		return normalized
+	}
+	fingerprint, err := pg_query.Deparse(tree)
+	if err != nil {
+		return normalized
+	}
+	return fingerprint
+}
+
+// QuoteIdent quotes identifier if it can not be written without quotes, it follows
+// quote_ident of PostgreSQL.
+func QuoteIdent(name string) string {
+	if name == "" {
+		return name
+	}
+	plain := name[0] == '_' || (name[0] >= 'a' && name[0] <= 'z')
+	for _, r := range name {
+		if !(r == '_' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')) {
+			plain = false
+			break
+		}
+	}
+	if plain && !isReservedKeyword(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// isReservedKeyword reports whether the name is a keyword which can not be used as
+// identifier without quotes in all contexts.
+func isReservedKeyword(name string) bool {
+	scan, err := pg_query.Scan(name)
+	if err != nil || len(scan.Tokens) != 1 {
+		return true
+	}
+	kind := scan.Tokens[0].KeywordKind
+	return kind != pg_query.KeywordKind_NO_KEYWORD && kind != pg_query.KeywordKind_UNRESERVED_KEYWORD
+}
+
+// QuoteLiteral quotes s as a string literal.
+func QuoteLiteral(s string) string {
+	if strings.Contains(s, `\`) {
+		return `E'` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `'`, `''`) + `'`
+	}
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
+
+// IsLiteral reports whether expr is a constant, such as 1, 'a', -1, 'a'::text.
+func IsLiteral(expr string) bool {
+	node := parseExpr(expr)
+	if cast := node.GetTypeCast(); cast != nil {
+		node = cast.Arg
+	}
+	return node.GetAConst() != nil
+}
+
+// IsAlwaysTrue reports whether the condition is empty or always true, e.g. "1=1", "true".
+func IsAlwaysTrue(condition string) bool {
+	if strings.TrimSpace(condition) == "" {
+		return true
+	}
+	node := parseExpr(condition)
+	if c := node.GetAConst(); c != nil {
+		return c.GetBoolval().GetBoolval()
+	}
+	e := node.GetAExpr()
+	if e == nil || e.Kind != pg_query.A_Expr_Kind_AEXPR_OP || len(e.Name) != 1 || e.Name[0].GetString_().GetSval() != "=" {
+		return false
+	}
+	if e.Lexpr.GetAConst() == nil || e.Rexpr.GetAConst() == nil {
+		return false
+	}
+	return deparseExpr(e.Lexpr) == deparseExpr(e.Rexpr)
+}
+
+// FunctionCalls returns the lower case names of functions called in expr.
+func FunctionCalls(expr string) []string {
+	names := []string{}
+	node := parseExpr(expr)
+	if node == nil {
+		return names
+	}
+	walk(node.ProtoReflect(), func(m protoreflect.Message) {
+		if call, ok := m.Interface().(*pg_query.FuncCall); ok && len(call.Funcname) > 0 {
+			name := call.Funcname[len(call.Funcname)-1].GetString_().GetSval()
+			names = append(names, strings.ToLower(name))
+		}
+	})
+	return names
+}
+
+// maxIdentifierLength is NAMEDATALEN-1 of PostgreSQL, the longer identifiers are
+// truncated by the parser.
+const maxIdentifierLength = 63
+
+// FullIdentifier returns the identifier in sql which is truncated to name by the
+// parser, name is returned if it is not truncated.
+func FullIdentifier(sql, name string) string {
+	if len(name) != maxIdentifierLength {
+		return name
+	}
+	scan, err := pg_query.Scan(sql)
+	if err != nil {
+		return name
+	}
+	for _, token := range scan.Tokens {
+		if token.Token != pg_query.Token_IDENT {
+			continue
+		}
+		ident := sql[token.Start:token.End]
+		if strings.HasPrefix(ident, `"`) {
+			ident = strings.ReplaceAll(strings.Trim(ident, `"`), `""`, `"`)
+		} else {
+			ident = strings.ToLower(ident)
+		}
+		if len(ident) > len(name) && strings.HasPrefix(ident, name) {
+			return ident
+		}
+	}
+	return name
+}
@@ -0,0 +1,689 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+
+	pg_query "github.com/pganalyze/pg_query_go/v5"
+)
+
+// Node is a parsed PostgreSQL statement. The statement is parsed by the PostgreSQL
+// grammar of pg_query, the parts used by audit rules and rollback are extracted
+// into the typed nodes, and the complete parse tree is kept in AST.
+type Node interface {
+	Text() string
+	StartLine() int
+	SQLType() string
+	AST() *pg_query.Node
+}
+
+type baseNode struct {
+	text      string
+	startLine int
+	stmt      *pg_query.Node
+}
+
+func (n *baseNode) Text() string {
+	return n.text
+}
+
+func (n *baseNode) StartLine() int {
+	return n.startLine
+}
+
+func (n *baseNode) AST() *pg_query.Node {
+	return n.stmt
+}
+
+type TableName struct {
+	Schema string
+	Name   string
+}
+
+func (t TableName) String() string {
+	if t.Schema == "" {
+		return QuoteIdent(t.Name)
+	}
+	return fmt.Sprintf("%s.%s", QuoteIdent(t.Schema), QuoteIdent(t.Name))
+}
+
+type ColumnDef struct {
+	Name       string
+	Type       string
+	NotNull    bool
+	PrimaryKey bool
+	Unique     bool
+	HasDefault bool
+	Default    string
+}
+
+const (
+	ConstraintPrimaryKey = "PRIMARY KEY"
+	ConstraintUnique     = "UNIQUE"
+	ConstraintForeignKey = "FOREIGN KEY"
+	ConstraintCheck      = "CHECK"
+	ConstraintExclude    = "EXCLUDE"
+)
+
+type Constraint struct {
+	Name     string
+	Type     string
+	Columns  []string
+	NotValid bool
+	// Text is the constraint definition, e.g. "CONSTRAINT pk PRIMARY KEY (id)".
+	Text string
+}
+
+type CreateTableStmt struct {
+	baseNode
+	Table       TableName
+	IfNotExists bool
+	Columns     []*ColumnDef
+	Constraints []*Constraint
+}
+
+type AlterTableCmdType int
+
+const (
+	AlterTableOther AlterTableCmdType = iota
+	AlterTableAddColumn
+	AlterTableDropColumn
+	AlterTableAlterColumnType
+	AlterTableSetDefault
+	AlterTableDropDefault
+	AlterTableSetNotNull
+	AlterTableDropNotNull
+	AlterTableAddConstraint
+	AlterTableDropConstraint
+	AlterTableValidateConstraint
+	AlterTableRenameColumn
+	AlterTableRenameConstraint
+	AlterTableRenameTable
+	AlterTableSetSchema
+)
+
+type AlterTableCmd struct {
+	Type AlterTableCmdType
+	// Column is the new column definition of ADD COLUMN.
+	Column *ColumnDef
+	// ColumnName is the column name of DROP/ALTER/RENAME COLUMN.
+	ColumnName string
+	// NewName is the new name of RENAME and the new schema of SET SCHEMA.
+	NewName string
+	// NewType is the new type of ALTER COLUMN TYPE.
+	NewType string
+	// Default is the new default expression of ALTER COLUMN SET DEFAULT.
+	Default        string
+	Constraint     *Constraint
+	ConstraintName string
+	IfExists       bool
+	// Text is the text of the command.
+	Text string
+}
+
+type AlterTableStmt struct {
+	baseNode
+	Table    TableName
+	IfExists bool
+	Cmds     []*AlterTableCmd
+}
+
+type CreateIndexStmt struct {
+	baseNode
+	Name         string
+	Table        TableName
+	Unique       bool
+	Concurrently bool
+	IfNotExists  bool
+	Columns      []string
+}
+
+type DropStmt struct {
+	baseNode
+	// ObjectType is the type of dropped objects in lower case, e.g. "table", "index".
+	ObjectType   string
+	Names        []TableName
+	IfExists     bool
+	Concurrently bool
+	Cascade      bool
+}
+
+type TruncateStmt struct {
+	baseNode
+	Tables []TableName
+}
+
+type InsertStmt struct {
+	baseNode
+	Table   TableName
+	Columns []string
+	// Rows is the value expressions of VALUES list, it is empty for INSERT ... SELECT.
+	Rows       [][]string
+	IsSelect   bool
+	OnConflict bool
+}
+
+type Assignment struct {
+	Column string
+	Value  string
+}
+
+type UpdateStmt struct {
+	baseNode
+	Table TableName
+	Alias string
+	Sets  []*Assignment
+	From  []TableName
+	// Where is the WHERE condition, it is empty if there is no WHERE clause.
+	Where string
+}
+
+type DeleteStmt struct {
+	baseNode
+	Table TableName
+	Alias string
+	Using []TableName
+	Where string
+}
+
+type SelectStmt struct {
+	baseNode
+	Tables     []TableName
+	SelectStar bool
+	HasWhere   bool
+	HasLimit   bool
+	ForUpdate  bool
+}
+
+// OtherStmt is statement which has no typed node, such as SET, GRANT, BEGIN,
+// its parse tree is still available by AST.
+type OtherStmt struct {
+	baseNode
+}
+
+func (n *CreateTableStmt) SQLType() string { return driverV2.SQLTypeDDL }
+func (n *AlterTableStmt) SQLType() string  { return driverV2.SQLTypeDDL }
+func (n *CreateIndexStmt) SQLType() string { return driverV2.SQLTypeDDL }
+func (n *DropStmt) SQLType() string        { return driverV2.SQLTypeDDL }
+func (n *TruncateStmt) SQLType() string    { return driverV2.SQLTypeDDL }
+func (n *InsertStmt) SQLType() string      { return driverV2.SQLTypeDML }
+func (n *UpdateStmt) SQLType() string      { return driverV2.SQLTypeDML }
+func (n *DeleteStmt) SQLType() string      { return driverV2.SQLTypeDML }
+func (n *SelectStmt) SQLType() string      { return driverV2.SQLTypeDQL }
+
+func (n *OtherStmt) SQLType() string {
+	switch n.stmt.Node.(type) {
+	case *pg_query.Node_MergeStmt, *pg_query.Node_CopyStmt, *pg_query.Node_CallStmt:
+		return driverV2.SQLTypeDML
+	case *pg_query.Node_ExplainStmt, *pg_query.Node_VariableShowStmt, *pg_query.Node_SelectStmt:
+		return driverV2.SQLTypeDQL
+	}
+	return driverV2.SQLTypeDDL
+}
+
+// Parse splits sql text into statements and parses them.
+func Parse(sql string) ([]Node, error) {
+	tree, err := pg_query.Parse(sql)
+	if err != nil {
+		return nil, fmt.Errorf("parse sql failed: %v", err)
+	}
+	scan, err := pg_query.Scan(sql)
+	if err != nil {
+		return nil, fmt.Errorf("scan sql failed: %v", err)
+	}
+	nodes := make([]Node, 0, len(tree.Stmts))
+	for _, raw := range tree.Stmts {
+		start := int(raw.StmtLocation)
+		end := len(sql)
+		if raw.StmtLen > 0 {
+			end = start + int(raw.StmtLen)
+		}
+		// the location of statement starts after the previous statement, the leading
+		// comments are excluded from the text of statement.
+		textStart, textEnd := -1, start
+		for _, token := range scan.Tokens {
+			if int(token.Start) < start || int(token.End) > end ||
+				token.Token == pg_query.Token_SQL_COMMENT || token.Token == pg_query.Token_C_COMMENT {
+				continue
+			}
+			if textStart == -1 {
+				textStart = int(token.Start)
+			}
+			textEnd = int(token.End)
+		}
+		if textStart == -1 {
+			continue
+		}
+		base := baseNode{
+			text:      sql[textStart:textEnd],
+			startLine: strings.Count(sql[:textStart], "\n") + 1,
+			stmt:      raw.Stmt,
+		}
+		nodes = append(nodes, convertStmt(base))
+	}
+	return nodes, nil
+}
+
+// ParseOneStmt parses sql which should be a single statement.
+func ParseOneStmt(sql string) (Node, error) {
+	nodes, err := Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) != 1 {
+		return nil, fmt.Errorf("expect one statement, but got %d", len(nodes))
+	}
+	return nodes[0], nil
+}
+
+func convertStmt(base baseNode) Node {
+	switch stmt := base.stmt.Node.(type) {
+	case *pg_query.Node_CreateStmt:
+		return convertCreateTable(base, stmt.CreateStmt)
+	case *pg_query.Node_CreateTableAsStmt:
+		if stmt.CreateTableAsStmt.Objtype == pg_query.ObjectType_OBJECT_TABLE && stmt.CreateTableAsStmt.Into != nil {
+			return &CreateTableStmt{
+				baseNode:    base,
+				Table:       tableName(stmt.CreateTableAsStmt.Into.Rel),
+				IfNotExists: stmt.CreateTableAsStmt.IfNotExists,
+			}
+		}
+	case *pg_query.Node_AlterTableStmt:
+		if stmt.AlterTableStmt.Objtype == pg_query.ObjectType_OBJECT_TABLE {
+			return convertAlterTable(base, stmt.AlterTableStmt)
+		}
+	case *pg_query.Node_RenameStmt:
+		if node := convertRename(base, stmt.RenameStmt); node != nil {
+			return node
+		}
+	case *pg_query.Node_AlterObjectSchemaStmt:
+		if stmt.AlterObjectSchemaStmt.ObjectType == pg_query.ObjectType_OBJECT_TABLE {
+			return &AlterTableStmt{
+				baseNode: base,
+				Table:    tableName(stmt.AlterObjectSchemaStmt.Relation),
+				IfExists: stmt.AlterObjectSchemaStmt.MissingOk,
+				Cmds: []*AlterTableCmd{{
+					Type:    AlterTableSetSchema,
+					NewName: stmt.AlterObjectSchemaStmt.Newschema,
+					Text:    "SET SCHEMA " + QuoteIdent(stmt.AlterObjectSchemaStmt.Newschema),
+				}},
+			}
+		}
+	case *pg_query.Node_IndexStmt:
+		return convertCreateIndex(base, stmt.IndexStmt)
+	case *pg_query.Node_DropStmt:
+		return convertDrop(base, stmt.DropStmt)
+	case *pg_query.Node_DropdbStmt:
+		return &DropStmt{
+			baseNode:   base,
+			ObjectType: "database",
+			Names:      []TableName{{Name: stmt.DropdbStmt.Dbname}},
+			IfExists:   stmt.DropdbStmt.MissingOk,
+		}
+	case *pg_query.Node_TruncateStmt:
+		return &TruncateStmt{baseNode: base, Tables: rangeVarNames(stmt.TruncateStmt.Relations)}
+	case *pg_query.Node_InsertStmt:
+		return convertInsert(base, stmt.InsertStmt)
+	case *pg_query.Node_UpdateStmt:
+		return convertUpdate(base, stmt.UpdateStmt)
+	case *pg_query.Node_DeleteStmt:
+		return &DeleteStmt{
+			baseNode: base,
+			Table:    tableName(stmt.DeleteStmt.Relation),
+			Alias:    aliasName(stmt.DeleteStmt.Relation),
+			Using:    tablesOf(stmt.DeleteStmt.UsingClause...),
+			Where:    deparseExpr(stmt.DeleteStmt.WhereClause),
+		}
+	case *pg_query.Node_SelectStmt:
+		// SELECT ... INTO creates a table.
+		if stmt.SelectStmt.IntoClause == nil {
+			return convertSelect(base, stmt.SelectStmt)
+		}
+	}
+	return &OtherStmt{baseNode: base}
+}
+
+func tableName(rv *pg_query.RangeVar) TableName {
+	if rv == nil {
+		return TableName{}
+	}
+	return TableName{Schema: rv.Schemaname, Name: rv.Relname}
+}
+
+func aliasName(rv *pg_query.RangeVar) string {
+	if rv == nil || rv.Alias == nil {
+		return ""
+	}
+	return rv.Alias.Aliasname
+}
+
+func rangeVarNames(nodes []*pg_query.Node) []TableName {
+	names := []TableName{}
+	for _, n := range nodes {
+		if rv := n.GetRangeVar(); rv != nil {
+			names = append(names, tableName(rv))
+		}
+	}
+	return names
+}
+
+// stringValues returns the values of String nodes, such as the column names of constraint.
+func stringValues(nodes []*pg_query.Node) []string {
+	values := []string{}
+	for _, n := range nodes {
+		if s := n.GetString_(); s != nil {
+			values = append(values, s.Sval)
+		}
+	}
+	return values
+}
+
+func convertColumnDef(col *pg_query.ColumnDef) *ColumnDef {
+	def := &ColumnDef{
+		Name:    col.Colname,
+		Type:    deparseTypeName(col.TypeName),
+		NotNull: col.IsNotNull,
+	}
+	if col.RawDefault != nil {
+		def.HasDefault, def.Default = true, deparseExpr(col.RawDefault)
+	}
+	for _, n := range col.Constraints {
+		c := n.GetConstraint()
+		if c == nil {
+			continue
+		}
+		switch c.Contype {
+		case pg_query.ConstrType_CONSTR_NOTNULL:
+			def.NotNull = true
+		case pg_query.ConstrType_CONSTR_PRIMARY:
+			def.PrimaryKey, def.NotNull = true, true
+		case pg_query.ConstrType_CONSTR_UNIQUE:
+			def.Unique = true
+		case pg_query.ConstrType_CONSTR_DEFAULT:
+			def.HasDefault, def.Default = true, deparseExpr(c.RawExpr)
+		}
+	}
+	return def
+}
+
+func convertConstraint(c *pg_query.Constraint) *Constraint {
+	constraint := &Constraint{
+		Name:     c.Conname,
+		NotValid: c.SkipValidation,
+		Text:     deparseConstraint(c),
+	}
+	switch c.Contype {
+	case pg_query.ConstrType_CONSTR_PRIMARY:
+		constraint.Type, constraint.Columns = ConstraintPrimaryKey, stringValues(c.Keys)
+	case pg_query.ConstrType_CONSTR_UNIQUE:
+		constraint.Type, constraint.Columns = ConstraintUnique, stringValues(c.Keys)
+	case pg_query.ConstrType_CONSTR_FOREIGN:
+		constraint.Type, constraint.Columns = ConstraintForeignKey, stringValues(c.FkAttrs)
+	case pg_query.ConstrType_CONSTR_CHECK:
+		constraint.Type = ConstraintCheck
+	case pg_query.ConstrType_CONSTR_EXCLUSION:
+		constraint.Type = ConstraintExclude
+		for _, n := range c.Exclusions {
+			// the exclusion is a list of index element and operator
+			if items := n.GetList().GetItems(); len(items) > 0 {
+				if elem := items[0].GetIndexElem(); elem != nil {
+					constraint.Columns = append(constraint.Columns, indexElemName(elem))
+				}
+			}
+		}
+	}
+	return constraint
+}
+
+func convertCreateTable(base baseNode, stmt *pg_query.CreateStmt) Node {
+	node := &CreateTableStmt{
+		baseNode:    base,
+		Table:       tableName(stmt.Relation),
+		IfNotExists: stmt.IfNotExists,
+	}
+	for _, elt := range stmt.TableElts {
+		switch n := elt.Node.(type) {
+		case *pg_query.Node_ColumnDef:
+			node.Columns = append(node.Columns, convertColumnDef(n.ColumnDef))
+		case *pg_query.Node_Constraint:
+			node.Constraints = append(node.Constraints, convertConstraint(n.Constraint))
+		}
+	}
+	for _, c := range stmt.Constraints {
+		if c.GetConstraint() != nil {
+			node.Constraints = append(node.Constraints, convertConstraint(c.GetConstraint()))
+		}
+	}
+	return node
+}
+
+func convertAlterTable(base baseNode, stmt *pg_query.AlterTableStmt) Node {
+	node := &AlterTableStmt{
+		baseNode: base,
+		Table:    tableName(stmt.Relation),
+		IfExists: stmt.MissingOk,
+	}
+	for _, n := range stmt.Cmds {
+		c := n.GetAlterTableCmd()
+		if c == nil {
+			continue
+		}
+		cmd := &AlterTableCmd{
+			IfExists: c.MissingOk,
+			Text:     deparseAlterTableCmd(c),
+		}
+		switch c.Subtype {
+		case pg_query.AlterTableType_AT_AddColumn:
+			if col := c.Def.GetColumnDef(); col != nil {
+				cmd.Type, cmd.Column = AlterTableAddColumn, convertColumnDef(col)
+			}
+		case pg_query.AlterTableType_AT_DropColumn:
+			cmd.Type, cmd.ColumnName = AlterTableDropColumn, c.Name
+		case pg_query.AlterTableType_AT_AlterColumnType:
+			cmd.Type, cmd.ColumnName = AlterTableAlterColumnType, c.Name
+			if col := c.Def.GetColumnDef(); col != nil {
+				cmd.NewType = deparseTypeName(col.TypeName)
+			}
+		case pg_query.AlterTableType_AT_ColumnDefault:
+			cmd.ColumnName = c.Name
+			if c.Def != nil {
+				cmd.Type, cmd.Default = AlterTableSetDefault, deparseExpr(c.Def)
+			} else {
+				cmd.Type = AlterTableDropDefault
+			}
+		case pg_query.AlterTableType_AT_SetNotNull:
+			cmd.Type, cmd.ColumnName = AlterTableSetNotNull, c.Name
+		case pg_query.AlterTableType_AT_DropNotNull:
+			cmd.Type, cmd.ColumnName = AlterTableDropNotNull, c.Name
+		case pg_query.AlterTableType_AT_AddConstraint:
+			if constraint := c.Def.GetConstraint(); constraint != nil {
+				cmd.Type, cmd.Constraint = AlterTableAddConstraint, convertConstraint(constraint)
+			}
+		case pg_query.AlterTableType_AT_DropConstraint:
+			cmd.Type, cmd.ConstraintName = AlterTableDropConstraint, c.Name
+		case pg_query.AlterTableType_AT_ValidateConstraint:
+			cmd.Type, cmd.ConstraintName = AlterTableValidateConstraint, c.Name
+		}
+		node.Cmds = append(node.Cmds, cmd)
+	}
+	return node
+}
+
+// convertRename converts the RENAME of table, column and constraint to ALTER TABLE.
+func convertRename(base baseNode, stmt *pg_query.RenameStmt) Node {
+	cmd := &AlterTableCmd{NewName: stmt.Newname}
+	switch stmt.RenameType {
+	case pg_query.ObjectType_OBJECT_TABLE:
+		cmd.Type = AlterTableRenameTable
+		cmd.Text = "RENAME TO " + QuoteIdent(stmt.Newname)
+	case pg_query.ObjectType_OBJECT_COLUMN:
+		if stmt.RelationType != pg_query.ObjectType_OBJECT_TABLE {
+			return nil
+		}
+		cmd.Type, cmd.ColumnName = AlterTableRenameColumn, stmt.Subname
+		cmd.Text = fmt.Sprintf("RENAME COLUMN %s TO %s", QuoteIdent(stmt.Subname), QuoteIdent(stmt.Newname))
+	case pg_query.ObjectType_OBJECT_TABCONSTRAINT:
+		cmd.Type, cmd.ConstraintName = AlterTableRenameConstraint, stmt.Subname
+		cmd.Text = fmt.Sprintf("RENAME CONSTRAINT %s TO %s", QuoteIdent(stmt.Subname), QuoteIdent(stmt.Newname))
+	default:
+		return nil
+	}
+	return &AlterTableStmt{
+		baseNode: base,
+		Table:    tableName(stmt.Relation),
+		IfExists: stmt.MissingOk,
+		Cmds:     []*AlterTableCmd{cmd},
+	}
+}
+
+func indexElemName(elem *pg_query.IndexElem) string {
+	if elem.Name != "" {
+		return elem.Name
+	}
+	return deparseExpr(elem.Expr)
+}
+
+func convertCreateIndex(base baseNode, stmt *pg_query.IndexStmt) Node {
+	node := &CreateIndexStmt{
+		baseNode:     base,
+		Name:         stmt.Idxname,
+		Table:        tableName(stmt.Relation),
+		Unique:       stmt.Unique,
+		Concurrently: stmt.Concurrent,
+		IfNotExists:  stmt.IfNotExists,
+	}
+	for _, n := range stmt.IndexParams {
+		if elem := n.GetIndexElem(); elem != nil {
+			node.Columns = append(node.Columns, indexElemName(elem))
+		}
+	}
+	return node
+}
+
+// objectTypeName returns the object type in lower case, e.g. "materialized view" of OBJECT_MATVIEW.
+func objectTypeName(typ pg_query.ObjectType) string {
+	switch typ {
+	case pg_query.ObjectType_OBJECT_MATVIEW:
+		return "materialized view"
+	}
+	name := strings.TrimPrefix(typ.String(), "OBJECT_")
+	return strings.ToLower(strings.ReplaceAll(name, "_", " "))
+}
+
+func convertDrop(base baseNode, stmt *pg_query.DropStmt) Node {
+	node := &DropStmt{
+		baseNode:     base,
+		ObjectType:   objectTypeName(stmt.RemoveType),
+		IfExists:     stmt.MissingOk,
+		Concurrently: stmt.Concurrent,
+		Cascade:      stmt.Behavior == pg_query.DropBehavior_DROP_CASCADE,
+	}
+	for _, n := range stmt.Objects {
+		// the name of relation is a list of [catalog.][schema.]name, and the other
+		// objects such as schema are a single name.
+		names := stringValues(n.GetList().GetItems())
+		if s := n.GetString_(); s != nil {
+			names = []string{s.Sval}
+		}
+		switch len(names) {
+		case 0:
+			continue
+		case 1:
+			node.Names = append(node.Names, TableName{Name: names[0]})
+		default:
+			node.Names = append(node.Names, TableName{Schema: names[len(names)-2], Name: names[len(names)-1]})
+		}
+	}
+	return node
+}
+
+func convertInsert(base baseNode, stmt *pg_query.InsertStmt) Node {
+	node := &InsertStmt{
+		baseNode:   base,
+		Table:      tableName(stmt.Relation),
+		OnConflict: stmt.OnConflictClause != nil,
+	}
+	for _, n := range stmt.Cols {
+		if target := n.GetResTarget(); target != nil {
+			node.Columns = append(node.Columns, target.Name)
+		}
+	}
+	sel := stmt.SelectStmt.GetSelectStmt()
+	// INSERT ... DEFAULT VALUES has no select statement
+	if sel == nil {
+		return node
+	}
+	if len(sel.ValuesLists) == 0 {
+		node.IsSelect = true
+		return node
+	}
+	for _, n := range sel.ValuesLists {
+		row := []string{}
+		for _, item := range n.GetList().GetItems() {
+			row = append(row, deparseExpr(item))
+		}
+		node.Rows = append(node.Rows, row)
+	}
+	return node
+}
+
+func convertUpdate(base baseNode, stmt *pg_query.UpdateStmt) Node {
+	node := &UpdateStmt{
+		baseNode: base,
+		Table:    tableName(stmt.Relation),
+		Alias:    aliasName(stmt.Relation),
+		From:     tablesOf(stmt.FromClause...),
+		Where:    deparseExpr(stmt.WhereClause),
+	}
+	for _, n := range stmt.TargetList {
+		target := n.GetResTarget()
+		if target == nil {
+			continue
+		}
+		// the column of multiple-column assignment, e.g. SET (a, b) = (1, 2), is left empty.
+		if target.Val.GetMultiAssignRef() != nil || len(target.Indirection) > 0 {
+			node.Sets = append(node.Sets, &Assignment{Value: deparseExpr(target.Val)})
+			continue
+		}
+		node.Sets = append(node.Sets, &Assignment{Column: target.Name, Value: deparseExpr(target.Val)})
+	}
+	return node
+}
+
+func convertSelect(base baseNode, stmt *pg_query.SelectStmt) Node {
+	node := &SelectStmt{
+		baseNode: base,
+		Tables:   tablesOf(base.stmt),
+		HasLimit: stmt.LimitCount != nil,
+	}
+	for _, n := range stmt.LockingClause {
+		switch n.GetLockingClause().GetStrength() {
+		case pg_query.LockClauseStrength_LCS_FORUPDATE, pg_query.LockClauseStrength_LCS_FORNOKEYUPDATE:
+			node.ForUpdate = true
+		}
+	}
+	// the arms of UNION/INTERSECT/EXCEPT are checked for "SELECT *".
+	selects := []*pg_query.SelectStmt{stmt}
+	for len(selects) > 0 {
+		s := selects[0]
+		selects = selects[1:]
+		if s == nil {
+			continue
+		}
+		if s.Op != pg_query.SetOperation_SETOP_NONE {
+			selects = append(selects, s.Larg, s.Rarg)
+			continue
+		}
+		node.HasWhere = node.HasWhere || s.WhereClause != nil
+		for _, n := range s.TargetList {
+			for _, field := range n.GetResTarget().GetVal().GetColumnRef().GetFields() {
+				if field.GetAStar() != nil {
+					node.SelectStar = true
+				}
+			}
+		}
+	}
+	return node
+}
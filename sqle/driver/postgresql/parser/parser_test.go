@@ -0,0 +1,196 @@
+package parser
+
+import (
+	"testing"
+
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSplit(t *testing.T) {
+	nodes, err := Parse(`
+-- comment; with semicolon
+CREATE TABLE t1 (id int PRIMARY KEY, name text DEFAULT 'a;b');
+CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql;
+/* block /* nested */ comment */ SELECT * FROM t1;;
+`)
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 3)
+	assert.Equal(t, "CREATE TABLE t1 (id int PRIMARY KEY, name text DEFAULT 'a;b')", nodes[0].Text())
+	assert.Equal(t, 3, nodes[0].StartLine())
+	assert.Equal(t, driverV2.SQLTypeDDL, nodes[1].SQLType())
+	assert.Equal(t, "SELECT * FROM t1", nodes[2].Text())
+	assert.Equal(t, driverV2.SQLTypeDQL, nodes[2].SQLType())
+}
+
+func TestParseCreateTable(t *testing.T) {
+	node, err := ParseOneStmt(`CREATE TABLE IF NOT EXISTS public."User" (
+	id bigserial,
+	name character varying(32) NOT NULL DEFAULT '',
+	created_at timestamp with time zone DEFAULT now(),
+	CONSTRAINT pk_user PRIMARY KEY (id),
+	UNIQUE (name, created_at)
+)`)
+	assert.NoError(t, err)
+	stmt := node.(*CreateTableStmt)
+	assert.True(t, stmt.IfNotExists)
+	assert.Equal(t, TableName{Schema: "public", Name: "User"}, stmt.Table)
+	assert.Equal(t, `public."User"`, stmt.Table.String())
+	assert.Len(t, stmt.Columns, 3)
+	assert.Equal(t, "varchar(32)", stmt.Columns[1].Type)
+	assert.True(t, stmt.Columns[1].NotNull)
+	assert.Equal(t, "''", stmt.Columns[1].Default)
+	assert.Equal(t, "now()", stmt.Columns[2].Default)
+	assert.Len(t, stmt.Constraints, 2)
+	assert.Equal(t, ConstraintPrimaryKey, stmt.Constraints[0].Type)
+	assert.Equal(t, "pk_user", stmt.Constraints[0].Name)
+	assert.Equal(t, []string{"name", "created_at"}, stmt.Constraints[1].Columns)
+}
+
+func TestParseAlterTable(t *testing.T) {
+	node, err := ParseOneStmt(`ALTER TABLE t1
+	ADD COLUMN c1 int NOT NULL DEFAULT 0,
+	DROP COLUMN IF EXISTS c2,
+	ALTER COLUMN c3 TYPE bigint USING c3::bigint,
+	ALTER COLUMN c4 SET NOT NULL,
+	ADD CONSTRAINT fk_t2 FOREIGN KEY (t2_id) REFERENCES t2 (id) NOT VALID`)
+	assert.NoError(t, err)
+	stmt := node.(*AlterTableStmt)
+	assert.Len(t, stmt.Cmds, 5)
+	assert.Equal(t, AlterTableAddColumn, stmt.Cmds[0].Type)
+	assert.Equal(t, "0", stmt.Cmds[0].Column.Default)
+	assert.Equal(t, AlterTableDropColumn, stmt.Cmds[1].Type)
+	assert.Equal(t, "c2", stmt.Cmds[1].ColumnName)
+	assert.Equal(t, AlterTableAlterColumnType, stmt.Cmds[2].Type)
+	assert.Equal(t, "bigint", stmt.Cmds[2].NewType)
+	assert.Equal(t, AlterTableSetNotNull, stmt.Cmds[3].Type)
+	assert.Equal(t, AlterTableAddConstraint, stmt.Cmds[4].Type)
+	assert.Equal(t, ConstraintForeignKey, stmt.Cmds[4].Constraint.Type)
+	assert.True(t, stmt.Cmds[4].Constraint.NotValid)
+
+	node, err = ParseOneStmt(`ALTER TABLE t1 RENAME COLUMN "A" TO b`)
+	assert.NoError(t, err)
+	cmd := node.(*AlterTableStmt).Cmds[0]
+	assert.Equal(t, AlterTableRenameColumn, cmd.Type)
+	assert.Equal(t, "A", cmd.ColumnName)
+	assert.Equal(t, "b", cmd.NewName)
+}
+
+func TestParseDML(t *testing.T) {
+	node, err := ParseOneStmt(`INSERT INTO t1 (id, name) VALUES (1, 'a'), (2, upper('b'))`)
+	assert.NoError(t, err)
+	insert := node.(*InsertStmt)
+	assert.Equal(t, []string{"id", "name"}, insert.Columns)
+	assert.Equal(t, [][]string{{"1", "'a'"}, {"2", "upper('b')"}}, insert.Rows)
+
+	node, err = ParseOneStmt(`UPDATE t1 AS a SET name = 'x', age = age + 1 WHERE a.id IN (SELECT id FROM t2) RETURNING *`)
+	assert.NoError(t, err)
+	update := node.(*UpdateStmt)
+	assert.Equal(t, "a", update.Alias)
+	assert.Len(t, update.Sets, 2)
+	assert.Equal(t, "age + 1", update.Sets[1].Value)
+	assert.Equal(t, "a.id IN (SELECT id FROM t2)", update.Where)
+
+	node, err = ParseOneStmt(`DELETE FROM t1 USING t2 WHERE t1.id = t2.id`)
+	assert.NoError(t, err)
+	del := node.(*DeleteStmt)
+	assert.Equal(t, []TableName{{Name: "t2"}}, del.Using)
+	assert.Equal(t, "t1.id = t2.id", del.Where)
+
+	node, err = ParseOneStmt(`SELECT a.*, extract(year FROM b.created_at) FROM s1.t1 a JOIN t2 b ON a.id = b.id WHERE a.x IS DISTINCT FROM b.x`)
+	assert.NoError(t, err)
+	sel := node.(*SelectStmt)
+	assert.Equal(t, []TableName{{Schema: "s1", Name: "t1"}, {Name: "t2"}}, sel.Tables)
+	assert.True(t, sel.SelectStar)
+	assert.True(t, sel.HasWhere)
+	assert.False(t, sel.HasLimit)
+
+	node, err = ParseOneStmt(`SELECT count(*) FROM t1 LIMIT 1`)
+	assert.NoError(t, err)
+	assert.False(t, node.(*SelectStmt).SelectStar)
+	assert.True(t, node.(*SelectStmt).HasLimit)
+}
+
+func TestFingerprint(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM t1 WHERE id = $1 AND name = $2",
+		Fingerprint("select *  FROM t1\nWHERE id = 1 AND name = 'abc'"))
+	assert.Equal(t, `INSERT INTO "T" (a, b) VALUES ($1, $2::text)`,
+		Fingerprint(`INSERT INTO "T" (a, b) VALUES ($1, 'x'::text)`))
+}
+
+func TestParseGrammar(t *testing.T) {
+	_, err := Parse("SELECT * FORM t1")
+	assert.Error(t, err)
+
+	node, err := ParseOneStmt(`WITH recent AS (SELECT * FROM orders WHERE created_at > now() - interval '1 day')
+SELECT r.id FROM recent r JOIN s1.users u ON u.id = r.user_id WHERE u.id IN (SELECT user_id FROM blocked)`)
+	assert.NoError(t, err)
+	sel := node.(*SelectStmt)
+	assert.Equal(t, []TableName{{Schema: "s1", Name: "users"}, {Name: "blocked"}, {Name: "orders"}}, sel.Tables)
+	assert.False(t, sel.SelectStar)
+
+	node, err = ParseOneStmt(`SELECT id FROM t1 UNION ALL SELECT * FROM t2`)
+	assert.NoError(t, err)
+	assert.True(t, node.(*SelectStmt).SelectStar)
+
+	node, err = ParseOneStmt(`DROP DATABASE IF EXISTS db1`)
+	assert.NoError(t, err)
+	assert.Equal(t, "database", node.(*DropStmt).ObjectType)
+
+	node, err = ParseOneStmt(`DROP MATERIALIZED VIEW s1.v1, v2 CASCADE`)
+	assert.NoError(t, err)
+	drop := node.(*DropStmt)
+	assert.Equal(t, "materialized view", drop.ObjectType)
+	assert.Equal(t, []TableName{{Schema: "s1", Name: "v1"}, {Name: "v2"}}, drop.Names)
+	assert.True(t, drop.Cascade)
+
+	node, err = ParseOneStmt(`ALTER TABLE s1.t1 RENAME TO t2`)
+	assert.NoError(t, err)
+	alter := node.(*AlterTableStmt)
+	assert.Equal(t, TableName{Schema: "s1", Name: "t1"}, alter.Table)
+	assert.Equal(t, AlterTableRenameTable, alter.Cmds[0].Type)
+	assert.Equal(t, "t2", alter.Cmds[0].NewName)
+
+	node, err = ParseOneStmt(`ALTER TABLE t1 OWNER TO u1`)
+	assert.NoError(t, err)
+	assert.Equal(t, AlterTableOther, node.(*AlterTableStmt).Cmds[0].Type)
+	assert.Equal(t, "OWNER TO u1", node.(*AlterTableStmt).Cmds[0].Text)
+
+	node, err = ParseOneStmt(`CREATE TABLE t2 AS SELECT * FROM t1`)
+	assert.NoError(t, err)
+	assert.Equal(t, TableName{Name: "t2"}, node.(*CreateTableStmt).Table)
+
+	node, err = ParseOneStmt(`UPDATE t1 SET (a, b) = (1, 2) WHERE id = 1`)
+	assert.NoError(t, err)
+	assert.Equal(t, "", node.(*UpdateStmt).Sets[0].Column)
+
+	node, err = ParseOneStmt(`SET search_path TO s1`)
+	assert.NoError(t, err)
+	assert.IsType(t, &OtherStmt{}, node)
+	assert.Equal(t, driverV2.SQLTypeDDL, node.SQLType())
+	assert.NotNil(t, node.AST().GetVariableSetStmt())
+}
+
+func TestExpr(t *testing.T) {
+	assert.True(t, IsLiteral("-1"))
+	assert.True(t, IsLiteral("'a'::text"))
+	assert.False(t, IsLiteral("upper('a')"))
+	assert.True(t, IsAlwaysTrue(""))
+	assert.True(t, IsAlwaysTrue("1 = 1"))
+	assert.False(t, IsAlwaysTrue("a = a"))
+	assert.Equal(t, []string{"lower", "nextval"}, FunctionCalls("coalesce(lower(a), pg_catalog.nextval('s1'))"))
+	assert.Equal(t, "id", QuoteIdent("id"))
+	assert.Equal(t, `"user"`, QuoteIdent("user"))
+	assert.Equal(t, `"Name"`, QuoteIdent("Name"))
+}
+
+func TestFullIdentifier(t *testing.T) {
+	long := "a_very_long_column_name_which_will_be_truncated_by_postgresql_server"
+	sql := "CREATE TABLE t1 (" + long + " int)"
+	nodes, err := Parse(sql)
+	assert.NoError(t, err)
+	stmt := nodes[0].(*CreateTableStmt)
+	assert.Equal(t, long[:63], stmt.Columns[0].Name)
+	assert.Equal(t, long, FullIdentifier(sql, stmt.Columns[0].Name))
+	assert.Equal(t, "t1", FullIdentifier(sql, "t1"))
+}
@@ -0,0 +1,531 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/actiontech/sqle/sqle/driver/postgresql/parser"
+	"github.com/actiontech/sqle/sqle/driver/postgresql/rule"
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	pkgdriver "github.com/actiontech/sqle/sqle/pkg/driver"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+)
+
+// PostgreSQLDriverImpl is the PostgreSQL driver, it reuses the generic database/sql
+// implementation of pkg/driver for execution and adds the PostgreSQL specific audit,
+// rollback and metadata capabilities.
+type PostgreSQLDriverImpl struct {
+	*pkgdriver.DriverImpl
+
+	// backendPid is the process id of the server process handling the connection.
+	backendPid       int64
+	serverVersionNum int
+}
+
+var _ driverV2.Driver = &PostgreSQLDriverImpl{}
+var _ rule.Inspector = &PostgreSQLDriverImpl{}
+
+// RegisterRules adds the PostgreSQL rules and SQL parser to the driver builder.
+func RegisterRules(b *pkgdriver.DriverBuilder) {
+	b.SetSQLParserFn(func(sql string) (interface{}, error) {
+		return parser.ParseOneStmt(sql)
+	})
+	for i := range rule.RuleHandlers {
+		handler := rule.RuleHandlers[i]
+		r := handler.Rule
+		b.AddRuleWithSQLParser(&r, func(ctx context.Context, r *driverV2.Rule, ast interface{}, nextSQL []string) (string, error) {
+			node, ok := ast.(parser.Node)
+			if !ok || handler.Func == nil {
+				return "", nil
+			}
+			return handler.Func(&rule.RuleHandlerInput{
+				Ctx:       ctx,
+				Rule:      r,
+				Node:      node,
+				Inspector: rule.InspectorFromContext(ctx),
+				Message:   handler.Message,
+			})
+		})
+	}
+}
+
+func NewDriverImpl(l hclog.Logger, dt pkgdriver.Dialector, ah *pkgdriver.AuditHandler, cfg *driverV2.Config) (driverV2.Driver, error) {
+	d, err := pkgdriver.NewDriverImpl(l, dt, ah, cfg)
+	if err != nil {
+		return nil, err
+	}
+	p := &PostgreSQLDriverImpl{DriverImpl: d.(*pkgdriver.DriverImpl)}
+	if p.Conn != nil {
+		if err := p.Conn.QueryRowContext(context.TODO(), "SELECT pg_backend_pid()").Scan(&p.backendPid); err != nil {
+			p.Close(context.TODO())
+			return nil, errors.Wrap(err, "get backend pid")
+		}
+	}
+	return p, nil
+}
+
+func (p *PostgreSQLDriverImpl) isOffline() bool {
+	return p.Conn == nil
+}
+
+func (p *PostgreSQLDriverImpl) Parse(ctx context.Context, sql string) ([]driverV2.Node, error) {
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]driverV2.Node, 0, len(stmts))
+	for _, stmt := range stmts {
+		nodes = append(nodes, driverV2.Node{
+			Text:        stmt.Text(),
+			Type:        stmt.SQLType(),
+			Fingerprint: parser.Fingerprint(stmt.Text()),
+			StartLine:   uint64(stmt.StartLine()),
+		})
+	}
+	return nodes, nil
+}
+
+func (p *PostgreSQLDriverImpl) Audit(ctx context.Context, sqls []string) ([]*driverV2.AuditResults, error) {
+	if !p.isOffline() {
+		ctx = rule.WithInspector(ctx, p)
+	}
+	results := make([]*driverV2.AuditResults, 0, len(sqls))
+	for i, sql := range sqls {
+		ruleResults := driverV2.NewAuditResults()
+		node, err := parser.ParseOneStmt(sql)
+		if err != nil {
+			p.Log.Warn("parse sql failed", "sql", sql, "err", err)
+			ruleResults.Add(driverV2.RuleLevelWarn, "", "语法错误或者解析器不支持，请人工确认SQL正确性")
+			results = append(results, ruleResults)
+			continue
+		}
+		for _, rule := range p.Config.Rules {
+			result, err := p.Ah.AuditWithAST(ctx, rule, sql, node, sqls[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			ruleResults.Add(result.Level, result.RuleName, result.Message)
+		}
+		results = append(results, ruleResults)
+	}
+	return results, nil
+}
+
+func (p *PostgreSQLDriverImpl) TableRows(ctx context.Context, table parser.TableName) (int64, bool, error) {
+	rows := sql.NullInt64{}
+	err := p.Conn.QueryRowContext(ctx, `
+SELECT GREATEST(c.reltuples, 0)::bigint
+FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relname = $1 AND n.nspname = COALESCE(NULLIF($2, ''), current_schema()) AND c.relkind IN ('r', 'p')`,
+		table.Name, table.Schema).Scan(&rows)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "get rows of table %s", table)
+	}
+	return rows.Int64, true, nil
+}
+
+func (p *PostgreSQLDriverImpl) ServerVersionNum(ctx context.Context) (int, error) {
+	if p.serverVersionNum != 0 {
+		return p.serverVersionNum, nil
+	}
+	var version string
+	if err := p.Conn.QueryRowContext(ctx, "SHOW server_version_num").Scan(&version); err != nil {
+		return 0, errors.Wrap(err, "get server version")
+	}
+	num, err := strconv.Atoi(version)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse server version %s", version)
+	}
+	p.serverVersionNum = num
+	return num, nil
+}
+
+func (p *PostgreSQLDriverImpl) Explain(ctx context.Context, conf *driverV2.ExplainConf) (*driverV2.ExplainResult, error) {
+	node, err := parser.ParseOneStmt(conf.Sql)
+	if err != nil {
+		return nil, err
+	}
+	if node.SQLType() == driverV2.SQLTypeDDL {
+		return nil, driverV2.ErrSQLIsNotSupported
+	}
+	conn, err := p.GetConn()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, "EXPLAIN "+node.Text())
+	if err != nil {
+		return nil, errors.Wrap(err, "explain sql")
+	}
+	defer rows.Close()
+
+	data := driverV2.TabularData{
+		Columns: []driverV2.TabularDataHead{{Name: "QUERY PLAN", Desc: "执行计划"}},
+	}
+	for rows.Next() {
+		var plan string
+		if err := rows.Scan(&plan); err != nil {
+			return nil, errors.Wrap(err, "scan explain result")
+		}
+		data.Rows = append(data.Rows, []string{plan})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "scan explain result")
+	}
+	return &driverV2.ExplainResult{
+		ClassicResult: driverV2.ExplainClassicResult{TabularData: data},
+	}, nil
+}
+
+type columnInfo struct {
+	name     string
+	typ      string
+	notNull  bool
+	defaults sql.NullString
+	comment  sql.NullString
+}
+
+func (c *columnInfo) definition() string {
+	def := fmt.Sprintf("%s %s", parser.QuoteIdent(c.name), c.typ)
+	if c.notNull {
+		def += " NOT NULL"
+	}
+	if c.defaults.Valid {
+		def += " DEFAULT " + c.defaults.String
+	}
+	return def
+}
+
+const columnInfoQuery = `
+SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull,
+	pg_get_expr(d.adbin, d.adrelid), col_description(a.attrelid, a.attnum)
+FROM pg_attribute a LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped`
+
+func (p *PostgreSQLDriverImpl) getColumns(ctx context.Context, table parser.TableName) ([]*columnInfo, error) {
+	conn, err := p.GetConn()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, columnInfoQuery+" ORDER BY a.attnum", table.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "get columns of table %s", table)
+	}
+	defer rows.Close()
+	columns := []*columnInfo{}
+	for rows.Next() {
+		c := &columnInfo{}
+		if err := rows.Scan(&c.name, &c.typ, &c.notNull, &c.defaults, &c.comment); err != nil {
+			return nil, errors.Wrapf(err, "scan columns of table %s", table)
+		}
+		columns = append(columns, c)
+	}
+	return columns, rows.Err()
+}
+
+func (p *PostgreSQLDriverImpl) getColumn(ctx context.Context, table parser.TableName, column string) (*columnInfo, error) {
+	conn, err := p.GetConn()
+	if err != nil {
+		return nil, err
+	}
+	c := &columnInfo{}
+	err = conn.QueryRowContext(ctx, columnInfoQuery+" AND a.attname = $2", table.String(), column).
+		Scan(&c.name, &c.typ, &c.notNull, &c.defaults, &c.comment)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("column %s not exist in table %s", column, table)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "get column %s of table %s", column, table)
+	}
+	return c, nil
+}
+
+func (p *PostgreSQLDriverImpl) getPrimaryKey(ctx context.Context, table parser.TableName) ([]string, error) {
+	conn, err := p.GetConn()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, `
+SELECT a.attname
+FROM pg_index i JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+WHERE i.indrelid = $1::regclass AND i.indisprimary
+ORDER BY array_position(i.indkey::int2[], a.attnum)`, table.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "get primary key of table %s", table)
+	}
+	defer rows.Close()
+	columns := []string{}
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, errors.Wrapf(err, "scan primary key of table %s", table)
+		}
+		columns = append(columns, column)
+	}
+	return columns, rows.Err()
+}
+
+type constraintInfo struct {
+	name       string
+	definition string
+}
+
+func (p *PostgreSQLDriverImpl) getConstraints(ctx context.Context, table parser.TableName) ([]*constraintInfo, error) {
+	conn, err := p.GetConn()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, `
+SELECT conname, pg_get_constraintdef(oid) FROM pg_constraint
+WHERE conrelid = $1::regclass ORDER BY contype DESC, conname`, table.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "get constraints of table %s", table)
+	}
+	defer rows.Close()
+	constraints := []*constraintInfo{}
+	for rows.Next() {
+		c := &constraintInfo{}
+		if err := rows.Scan(&c.name, &c.definition); err != nil {
+			return nil, errors.Wrapf(err, "scan constraints of table %s", table)
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, rows.Err()
+}
+
+type indexInfo struct {
+	name       string
+	definition string
+	// isConstraint is true if the index is created by primary key or unique constraint.
+	isConstraint bool
+}
+
+func (p *PostgreSQLDriverImpl) getIndexes(ctx context.Context, table parser.TableName) ([]*indexInfo, error) {
+	conn, err := p.GetConn()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, `
+SELECT c.relname, pg_get_indexdef(i.indexrelid),
+	EXISTS (SELECT 1 FROM pg_constraint WHERE conindid = i.indexrelid AND contype IN ('p', 'u', 'x'))
+FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid
+WHERE i.indrelid = $1::regclass ORDER BY c.relname`, table.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "get indexes of table %s", table)
+	}
+	defer rows.Close()
+	indexes := []*indexInfo{}
+	for rows.Next() {
+		i := &indexInfo{}
+		if err := rows.Scan(&i.name, &i.definition, &i.isConstraint); err != nil {
+			return nil, errors.Wrapf(err, "scan indexes of table %s", table)
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes, rows.Err()
+}
+
+func (p *PostgreSQLDriverImpl) tableExist(ctx context.Context, table parser.TableName) (bool, error) {
+	conn, err := p.GetConn()
+	if err != nil {
+		return false, err
+	}
+	var exist bool
+	err = conn.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", table.String()).Scan(&exist)
+	if err != nil {
+		return false, errors.Wrapf(err, "check table %s exist", table)
+	}
+	return exist, nil
+}
+
+// showCreateTable builds the CREATE TABLE statement from catalog, since PostgreSQL
+// has no SHOW CREATE TABLE.
+func showCreateTable(table parser.TableName, columns []*columnInfo, constraints []*constraintInfo, indexes []*indexInfo) string {
+	defs := []string{}
+	for _, c := range columns {
+		defs = append(defs, "    "+c.definition())
+	}
+	for _, c := range constraints {
+		defs = append(defs, fmt.Sprintf("    CONSTRAINT %s %s", parser.QuoteIdent(c.name), c.definition))
+	}
+	stmts := []string{fmt.Sprintf("CREATE TABLE %s (\n%s\n);", table, strings.Join(defs, ",\n"))}
+	for _, i := range indexes {
+		if !i.isConstraint {
+			stmts = append(stmts, i.definition+";")
+		}
+	}
+	for _, c := range columns {
+		if c.comment.Valid {
+			stmts = append(stmts, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", table, parser.QuoteIdent(c.name), parser.QuoteLiteral(c.comment.String)))
+		}
+	}
+	return strings.Join(stmts, "\n")
+}
+
+func (p *PostgreSQLDriverImpl) GetTableMeta(ctx context.Context, table *driverV2.Table) (*driverV2.TableMeta, error) {
+	name := parser.TableName{Schema: table.Schema, Name: table.Name}
+	exist, err := p.tableExist(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return &driverV2.TableMeta{Message: fmt.Sprintf("表 %s 不存在", name)}, nil
+	}
+	columns, err := p.getColumns(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	constraints, err := p.getConstraints(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := p.getIndexes(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &driverV2.TableMeta{CreateTableSQL: showCreateTable(name, columns, constraints, indexes)}
+	meta.ColumnsInfo.Columns = []driverV2.TabularDataHead{
+		{Name: "COLUMN_NAME", Desc: "列名"},
+		{Name: "COLUMN_TYPE", Desc: "列类型"},
+		{Name: "IS_NULLABLE", Desc: "是否可以为空"},
+		{Name: "COLUMN_DEFAULT", Desc: "默认值"},
+		{Name: "COLUMN_COMMENT", Desc: "列说明"},
+	}
+	for _, c := range columns {
+		nullable := "YES"
+		if c.notNull {
+			nullable = "NO"
+		}
+		meta.ColumnsInfo.Rows = append(meta.ColumnsInfo.Rows, []string{c.name, c.typ, nullable, c.defaults.String, c.comment.String})
+	}
+	meta.IndexesInfo.Columns = []driverV2.TabularDataHead{
+		{Name: "INDEX_NAME", Desc: "索引名"},
+		{Name: "INDEX_DEFINITION", Desc: "索引定义"},
+	}
+	for _, i := range indexes {
+		meta.IndexesInfo.Rows = append(meta.IndexesInfo.Rows, []string{i.name, i.definition})
+	}
+	return meta, nil
+}
+
+// tablesOfNode returns the tables referenced by the statement.
+func tablesOfNode(node parser.Node) []parser.TableName {
+	var tables []parser.TableName
+	switch stmt := node.(type) {
+	case *parser.CreateTableStmt:
+		tables = []parser.TableName{stmt.Table}
+	case *parser.AlterTableStmt:
+		tables = []parser.TableName{stmt.Table}
+	case *parser.CreateIndexStmt:
+		tables = []parser.TableName{stmt.Table}
+	case *parser.DropStmt:
+		if stmt.ObjectType == "table" {
+			tables = stmt.Names
+		}
+	case *parser.TruncateStmt:
+		tables = stmt.Tables
+	case *parser.InsertStmt:
+		tables = []parser.TableName{stmt.Table}
+	case *parser.UpdateStmt:
+		tables = append([]parser.TableName{stmt.Table}, stmt.From...)
+	case *parser.DeleteStmt:
+		tables = append([]parser.TableName{stmt.Table}, stmt.Using...)
+	case *parser.SelectStmt:
+		tables = stmt.Tables
+	}
+	result := []parser.TableName{}
+	exist := map[parser.TableName]struct{}{}
+	for _, table := range tables {
+		if _, ok := exist[table]; ok {
+			continue
+		}
+		exist[table] = struct{}{}
+		result = append(result, table)
+	}
+	return result
+}
+
+func (p *PostgreSQLDriverImpl) ExtractTableFromSQL(ctx context.Context, sql string) ([]*driverV2.Table, error) {
+	node, err := parser.ParseOneStmt(sql)
+	if err != nil {
+		return nil, err
+	}
+	tables := []*driverV2.Table{}
+	for _, table := range tablesOfNode(node) {
+		tables = append(tables, &driverV2.Table{Name: table.Name, Schema: table.Schema})
+	}
+	return tables, nil
+}
+
+type explainPlan struct {
+	PlanRows float64        `json:"Plan Rows"`
+	NodeType string         `json:"Node Type"`
+	Plans    []*explainPlan `json:"Plans"`
+}
+
+func (p *PostgreSQLDriverImpl) EstimateSQLAffectRows(ctx context.Context, sql string) (*driverV2.EstimatedAffectRows, error) {
+	node, err := parser.ParseOneStmt(sql)
+	if err != nil {
+		return nil, err
+	}
+	switch stmt := node.(type) {
+	case *parser.InsertStmt:
+		if !stmt.IsSelect {
+			return &driverV2.EstimatedAffectRows{Count: int64(len(stmt.Rows))}, nil
+		}
+	case *parser.UpdateStmt, *parser.DeleteStmt, *parser.SelectStmt:
+	default:
+		return &driverV2.EstimatedAffectRows{ErrMessage: "不支持的SQL类型"}, nil
+	}
+
+	conn, err := p.GetConn()
+	if err != nil {
+		return nil, err
+	}
+	var output string
+	if err := conn.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+node.Text()).Scan(&output); err != nil {
+		return nil, errors.Wrap(err, "explain sql")
+	}
+	var plans []struct {
+		Plan *explainPlan `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(output), &plans); err != nil || len(plans) == 0 || plans[0].Plan == nil {
+		return nil, fmt.Errorf("unexpected explain result: %s", output)
+	}
+	plan := plans[0].Plan
+	// the estimated rows of ModifyTable node is always 0, the affected rows is the
+	// rows of its sub plan.
+	if plan.NodeType == "ModifyTable" && len(plan.Plans) > 0 {
+		plan = plan.Plans[0]
+	}
+	return &driverV2.EstimatedAffectRows{Count: int64(plan.PlanRows)}, nil
+}
+
+func (p *PostgreSQLDriverImpl) KillProcess(ctx context.Context) (*driverV2.KillProcessInfo, error) {
+	if p.backendPid == 0 {
+		return driverV2.NewKillProcessInfo("cannot find backend pid of the connection"), nil
+	}
+	db, conn, err := p.Dt.Open(p.Config.DSN)
+	if err != nil {
+		return driverV2.NewKillProcessInfo(err.Error()), nil
+	}
+	defer db.Close()
+	defer conn.Close()
+
+	var canceled bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_cancel_backend($1)", p.backendPid).Scan(&canceled); err != nil {
+		return driverV2.NewKillProcessInfo(err.Error()), nil
+	}
+	if !canceled {
+		return driverV2.NewKillProcessInfo(fmt.Sprintf("cancel backend %d failed", p.backendPid)), nil
+	}
+	return &driverV2.KillProcessInfo{}, nil
+}
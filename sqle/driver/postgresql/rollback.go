@@ -0,0 +1,351 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/actiontech/sqle/sqle/driver/postgresql/parser"
+	"github.com/actiontech/sqle/sqle/driver/postgresql/rule"
+	"github.com/pkg/errors"
+)
+
+const defaultDMLRollbackMaxRows = 1000
+
+// GenRollbackSQL generates the rollback SQL of DDL and DML. The second result
+// is the reason if the rollback SQL can not be generated.
+func (p *PostgreSQLDriverImpl) GenRollbackSQL(ctx context.Context, sql string) (string, string, error) {
+	node, err := parser.ParseOneStmt(sql)
+	if err != nil {
+		return "", "", err
+	}
+	switch stmt := node.(type) {
+	case *parser.CreateTableStmt:
+		return fmt.Sprintf("DROP TABLE %s;", stmt.Table), "", nil
+	case *parser.CreateIndexStmt:
+		if stmt.Name == "" {
+			return "", "不支持回滚未指定索引名的 CREATE INDEX 语句", nil
+		}
+		name := parser.TableName{Schema: stmt.Table.Schema, Name: stmt.Name}
+		return fmt.Sprintf("DROP INDEX %s;", name), "", nil
+	}
+
+	if p.isOffline() {
+		return "", "", nil
+	}
+	switch stmt := node.(type) {
+	case *parser.AlterTableStmt:
+		return p.generateAlterTableRollbackSQL(ctx, stmt)
+	case *parser.DropStmt:
+		return p.generateDropIndexRollbackSQL(ctx, stmt)
+	case *parser.InsertStmt:
+		return p.generateInsertRollbackSQL(ctx, stmt)
+	case *parser.DeleteStmt:
+		return p.generateDeleteRollbackSQL(ctx, stmt)
+	case *parser.UpdateStmt:
+		return p.generateUpdateRollbackSQL(ctx, stmt)
+	}
+	return "", "", nil
+}
+
+func (p *PostgreSQLDriverImpl) generateAlterTableRollbackSQL(ctx context.Context, stmt *parser.AlterTableStmt) (string, string, error) {
+	table := stmt.Table
+	rollbackCmds := []string{}
+	// the commands are reverted in reverse order
+	for i := len(stmt.Cmds) - 1; i >= 0; i-- {
+		cmd := stmt.Cmds[i]
+		switch cmd.Type {
+		case parser.AlterTableRenameTable:
+			newTable := parser.TableName{Schema: table.Schema, Name: cmd.NewName}
+			return fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", newTable, parser.QuoteIdent(table.Name)), "", nil
+		case parser.AlterTableSetSchema:
+			schema := table.Schema
+			if schema == "" {
+				if err := p.Conn.QueryRowContext(ctx, "SELECT current_schema()").Scan(&schema); err != nil {
+					return "", "", errors.Wrap(err, "get current schema")
+				}
+			}
+			newTable := parser.TableName{Schema: cmd.NewName, Name: table.Name}
+			return fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s;", newTable, parser.QuoteIdent(schema)), "", nil
+		case parser.AlterTableAddColumn:
+			rollbackCmds = append(rollbackCmds, fmt.Sprintf("DROP COLUMN %s", parser.QuoteIdent(cmd.Column.Name)))
+		case parser.AlterTableDropColumn:
+			col, err := p.getColumn(ctx, table, cmd.ColumnName)
+			if err != nil {
+				return "", "", err
+			}
+			rollbackCmds = append(rollbackCmds, "ADD COLUMN "+col.definition())
+		case parser.AlterTableAlterColumnType:
+			col, err := p.getColumn(ctx, table, cmd.ColumnName)
+			if err != nil {
+				return "", "", err
+			}
+			rollbackCmds = append(rollbackCmds, fmt.Sprintf("ALTER COLUMN %s TYPE %s", parser.QuoteIdent(col.name), col.typ))
+		case parser.AlterTableSetDefault, parser.AlterTableDropDefault:
+			col, err := p.getColumn(ctx, table, cmd.ColumnName)
+			if err != nil {
+				return "", "", err
+			}
+			if col.defaults.Valid {
+				rollbackCmds = append(rollbackCmds, fmt.Sprintf("ALTER COLUMN %s SET DEFAULT %s", parser.QuoteIdent(col.name), col.defaults.String))
+			} else {
+				rollbackCmds = append(rollbackCmds, fmt.Sprintf("ALTER COLUMN %s DROP DEFAULT", parser.QuoteIdent(col.name)))
+			}
+		case parser.AlterTableSetNotNull, parser.AlterTableDropNotNull:
+			col, err := p.getColumn(ctx, table, cmd.ColumnName)
+			if err != nil {
+				return "", "", err
+			}
+			if col.notNull {
+				rollbackCmds = append(rollbackCmds, fmt.Sprintf("ALTER COLUMN %s SET NOT NULL", parser.QuoteIdent(col.name)))
+			} else {
+				rollbackCmds = append(rollbackCmds, fmt.Sprintf("ALTER COLUMN %s DROP NOT NULL", parser.QuoteIdent(col.name)))
+			}
+		case parser.AlterTableAddConstraint:
+			if cmd.Constraint.Name == "" {
+				return "", "不支持回滚未指定约束名的 ADD CONSTRAINT 语句", nil
+			}
+			rollbackCmds = append(rollbackCmds, fmt.Sprintf("DROP CONSTRAINT %s", parser.QuoteIdent(cmd.Constraint.Name)))
+		case parser.AlterTableDropConstraint:
+			constraints, err := p.getConstraints(ctx, table)
+			if err != nil {
+				return "", "", err
+			}
+			found := false
+			for _, c := range constraints {
+				if c.name == cmd.ConstraintName {
+					rollbackCmds = append(rollbackCmds, fmt.Sprintf("ADD CONSTRAINT %s %s", parser.QuoteIdent(c.name), c.definition))
+					found = true
+				}
+			}
+			if !found && !cmd.IfExists {
+				return "", "", fmt.Errorf("constraint %s not exist in table %s", cmd.ConstraintName, table)
+			}
+		case parser.AlterTableRenameColumn:
+			rollbackCmds = append(rollbackCmds, fmt.Sprintf("RENAME COLUMN %s TO %s", parser.QuoteIdent(cmd.NewName), parser.QuoteIdent(cmd.ColumnName)))
+		case parser.AlterTableRenameConstraint:
+			rollbackCmds = append(rollbackCmds, fmt.Sprintf("RENAME CONSTRAINT %s TO %s", parser.QuoteIdent(cmd.NewName), parser.QuoteIdent(cmd.ConstraintName)))
+		case parser.AlterTableValidateConstraint:
+			// validating constraint changes nothing need to be reverted
+		default:
+			return "", fmt.Sprintf("不支持回滚 ALTER TABLE 子句: %s", cmd.Text), nil
+		}
+	}
+	if len(rollbackCmds) == 0 {
+		return "", "", nil
+	}
+	// RENAME can not be combined with other sub commands in one ALTER TABLE statement.
+	sqls := []string{}
+	others := []string{}
+	for _, cmd := range rollbackCmds {
+		if strings.HasPrefix(cmd, "RENAME ") {
+			sqls = append(sqls, fmt.Sprintf("ALTER TABLE %s %s;", table, cmd))
+		} else {
+			others = append(others, cmd)
+		}
+	}
+	if len(others) > 0 {
+		sqls = append(sqls, fmt.Sprintf("ALTER TABLE %s %s;", table, strings.Join(others, ", ")))
+	}
+	return strings.Join(sqls, "\n"), "", nil
+}
+
+func (p *PostgreSQLDriverImpl) generateDropIndexRollbackSQL(ctx context.Context, stmt *parser.DropStmt) (string, string, error) {
+	if stmt.ObjectType != "index" {
+		return "", "", nil
+	}
+	sqls := []string{}
+	for _, name := range stmt.Names {
+		var definition string
+		err := p.Conn.QueryRowContext(ctx, "SELECT pg_get_indexdef(to_regclass($1))", name.String()).Scan(&definition)
+		if err == sql.ErrNoRows || (err == nil && definition == "") {
+			if stmt.IfExists {
+				continue
+			}
+			return "", "", fmt.Errorf("index %s not exist", name)
+		}
+		if err != nil {
+			return "", "", errors.Wrapf(err, "get definition of index %s", name)
+		}
+		sqls = append(sqls, definition+";")
+	}
+	return strings.Join(sqls, "\n"), "", nil
+}
+
+func (p *PostgreSQLDriverImpl) dmlRollbackMaxRows() int64 {
+	for _, r := range p.Config.Rules {
+		if r.Name == rule.ConfigDMLRollbackMaxRows {
+			if param := r.Params.GetParam(rule.DefaultSingleParamKeyName); param != nil {
+				return int64(param.Int())
+			}
+		}
+	}
+	return defaultDMLRollbackMaxRows
+}
+
+func (p *PostgreSQLDriverImpl) generateInsertRollbackSQL(ctx context.Context, stmt *parser.InsertStmt) (string, string, error) {
+	if stmt.IsSelect || len(stmt.Rows) == 0 {
+		return "", "不支持回滚 INSERT ... SELECT 语句", nil
+	}
+	if stmt.OnConflict {
+		return "", "不支持回滚 INSERT ... ON CONFLICT 语句", nil
+	}
+	if int64(len(stmt.Rows)) > p.dmlRollbackMaxRows() {
+		return "", "预计影响行数超过配置的最大值，不生成回滚语句", nil
+	}
+	pk, err := p.getPrimaryKey(ctx, stmt.Table)
+	if err != nil {
+		return "", "", err
+	}
+	if len(pk) == 0 {
+		return "", "不支持回滚没有主键的表的 INSERT 语句", nil
+	}
+	pkIndex := map[string]int{}
+	for i, column := range stmt.Columns {
+		pkIndex[column] = i
+	}
+	sqls := []string{}
+	for _, row := range stmt.Rows {
+		if len(row) != len(stmt.Columns) {
+			return "", "不支持回滚没有指定列名的 INSERT 语句", nil
+		}
+		conditions := []string{}
+		for _, column := range pk {
+			i, ok := pkIndex[column]
+			if !ok || !parser.IsLiteral(row[i]) {
+				return "", "不支持回滚主键值不是常量的 INSERT 语句", nil
+			}
+			conditions = append(conditions, fmt.Sprintf("%s = %s", parser.QuoteIdent(column), row[i]))
+		}
+		sqls = append(sqls, fmt.Sprintf("DELETE FROM %s WHERE %s;", stmt.Table, strings.Join(conditions, " AND ")))
+	}
+	return strings.Join(sqls, "\n"), "", nil
+}
+
+// queryRows selects the rows which will be affected by the DML, it returns
+// nil rows if there are more rows than the rollback limit.
+func (p *PostgreSQLDriverImpl) queryRows(ctx context.Context, table parser.TableName, alias, where string) ([]string, [][]sql.NullString, error) {
+	limit := p.dmlRollbackMaxRows()
+	query := fmt.Sprintf("SELECT * FROM %s", table)
+	if alias != "" {
+		query += " AS " + parser.QuoteIdent(alias)
+	}
+	if where != "" {
+		query += " WHERE " + where
+	}
+	query += fmt.Sprintf(" LIMIT %d", limit+1)
+
+	rows, err := p.Conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "query rows for rollback")
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	records := [][]sql.NullString{}
+	for rows.Next() {
+		record := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range record {
+			dest[i] = &record[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, errors.Wrap(err, "scan rows for rollback")
+		}
+		records = append(records, record)
+		if int64(len(records)) > limit {
+			return columns, nil, nil
+		}
+	}
+	return columns, records, rows.Err()
+}
+
+func valueLiteral(v sql.NullString) string {
+	if !v.Valid {
+		return "NULL"
+	}
+	return parser.QuoteLiteral(v.String)
+}
+
+func (p *PostgreSQLDriverImpl) generateDeleteRollbackSQL(ctx context.Context, stmt *parser.DeleteStmt) (string, string, error) {
+	if len(stmt.Using) > 0 {
+		return "", "不支持回滚 DELETE ... USING 语句", nil
+	}
+	columns, records, err := p.queryRows(ctx, stmt.Table, stmt.Alias, stmt.Where)
+	if err != nil {
+		return "", "", err
+	}
+	if records == nil {
+		return "", "预计影响行数超过配置的最大值，不生成回滚语句", nil
+	}
+	quotedColumns := make([]string, 0, len(columns))
+	for _, column := range columns {
+		quotedColumns = append(quotedColumns, parser.QuoteIdent(column))
+	}
+	sqls := []string{}
+	for _, record := range records {
+		values := make([]string, 0, len(record))
+		for _, v := range record {
+			values = append(values, valueLiteral(v))
+		}
+		sqls = append(sqls, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);",
+			stmt.Table, strings.Join(quotedColumns, ", "), strings.Join(values, ", ")))
+	}
+	return strings.Join(sqls, "\n"), "", nil
+}
+
+func (p *PostgreSQLDriverImpl) generateUpdateRollbackSQL(ctx context.Context, stmt *parser.UpdateStmt) (string, string, error) {
+	if len(stmt.From) > 0 {
+		return "", "不支持回滚 UPDATE ... FROM 语句", nil
+	}
+	pk, err := p.getPrimaryKey(ctx, stmt.Table)
+	if err != nil {
+		return "", "", err
+	}
+	if len(pk) == 0 {
+		return "", "不支持回滚没有主键的表的 UPDATE 语句", nil
+	}
+	updated := map[string]struct{}{}
+	for _, set := range stmt.Sets {
+		if set.Column == "" {
+			return "", "不支持回滚多列赋值的 UPDATE 语句", nil
+		}
+		updated[set.Column] = struct{}{}
+	}
+	for _, column := range pk {
+		if _, ok := updated[column]; ok {
+			return "", "不支持回滚修改主键的 UPDATE 语句", nil
+		}
+	}
+
+	columns, records, err := p.queryRows(ctx, stmt.Table, stmt.Alias, stmt.Where)
+	if err != nil {
+		return "", "", err
+	}
+	if records == nil {
+		return "", "预计影响行数超过配置的最大值，不生成回滚语句", nil
+	}
+	sqls := []string{}
+	for _, record := range records {
+		sets := []string{}
+		conditions := []string{}
+		for i, column := range columns {
+			if _, ok := updated[column]; ok {
+				sets = append(sets, fmt.Sprintf("%s = %s", parser.QuoteIdent(column), valueLiteral(record[i])))
+			}
+		}
+		for _, key := range pk {
+			for i, column := range columns {
+				if column == key {
+					conditions = append(conditions, fmt.Sprintf("%s = %s", parser.QuoteIdent(column), valueLiteral(record[i])))
+				}
+			}
+		}
+		sqls = append(sqls, fmt.Sprintf("UPDATE %s SET %s WHERE %s;",
+			stmt.Table, strings.Join(sets, ", "), strings.Join(conditions, " AND ")))
+	}
+	return strings.Join(sqls, "\n"), "", nil
+}
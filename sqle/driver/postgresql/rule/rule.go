@@ -0,0 +1,781 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/actiontech/sqle/sqle/driver/postgresql/parser"
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/pkg/params"
+)
+
+// rule type
+const (
+	RuleTypeGlobalConfig       = "全局配置"
+	RuleTypeNamingConvention   = "命名规范"
+	RuleTypeIndexingConvention = "索引规范"
+	RuleTypeDDLConvention      = "DDL规范"
+	RuleTypeDMLConvention      = "DML规范"
+)
+
+// ddl rules
+const (
+	ConfigDMLRollbackMaxRows = "pg_config_dml_rollback_max_rows"
+
+	DDLCheckObjectNameLength            = "pg_ddl_check_object_name_length"
+	DDLCheckObjectNameIsLowerCase       = "pg_ddl_check_object_name_is_lower_case"
+	DDLCheckIndexPrefix                 = "pg_ddl_check_index_prefix"
+	DDLCheckUniqueIndexPrefix           = "pg_ddl_check_unique_index_prefix"
+	DDLCheckPKNotExist                  = "pg_ddl_check_pk_not_exist"
+	DDLCheckCompositeIndexMax           = "pg_ddl_check_composite_index_max"
+	DDLCheckCreateIndexConcurrently     = "pg_ddl_check_create_index_concurrently"
+	DDLCheckDropIndexConcurrently       = "pg_ddl_check_drop_index_concurrently"
+	DDLCheckAddColumnWithDefault        = "pg_ddl_check_add_column_with_default"
+	DDLCheckAlterColumnType             = "pg_ddl_check_alter_column_type"
+	DDLCheckSetNotNull                  = "pg_ddl_check_set_not_null"
+	DDLCheckAddConstraintNotValid       = "pg_ddl_check_add_constraint_not_valid"
+	DDLCheckAddUniqueConstraintUseIndex = "pg_ddl_check_add_unique_constraint_using_index"
+	DDLDisableDropStatement             = "pg_ddl_disable_drop_statement"
+)
+
+// dml rules
+const (
+	DMLCheckWhereIsInvalid      = "pg_dml_check_where_is_invalid"
+	DMLDisableSelectAllColumn   = "pg_dml_disable_select_all_column"
+	DMLCheckInsertColumnsExist  = "pg_dml_check_insert_columns_exist"
+	DMLCheckSelectWithoutLimit  = "pg_dml_check_select_without_limit"
+	DMLDisableTruncateStatement = "pg_dml_disable_truncate_statement"
+	DMLCheckSelectForUpdate     = "pg_dml_check_select_for_update"
+)
+
+const DefaultSingleParamKeyName = "first_key" // For most of the rules, it is just has one param, this is first params.
+
+// Inspector provides the information of the audited database. It is nil when
+// the audit is offline.
+type Inspector interface {
+	// TableRows returns the estimated row count of table, exist is false if the table not exists.
+	TableRows(ctx context.Context, table parser.TableName) (rows int64, exist bool, err error)
+	// ServerVersionNum returns the server_version_num, e.g. 110005.
+	ServerVersionNum(ctx context.Context) (int, error)
+}
+
+type inspectorKey struct{}
+
+// WithInspector returns a copy of ctx carrying the inspector of audited database.
+func WithInspector(ctx context.Context, i Inspector) context.Context {
+	return context.WithValue(ctx, inspectorKey{}, i)
+}
+
+func InspectorFromContext(ctx context.Context) Inspector {
+	i, _ := ctx.Value(inspectorKey{}).(Inspector)
+	return i
+}
+
+type RuleHandlerInput struct {
+	Ctx  context.Context
+	Rule *driverV2.Rule
+	Node parser.Node
+	// Inspector is nil when the audit is offline.
+	Inspector Inspector
+	// Message is the message format of the rule handler.
+	Message string
+}
+
+type RuleHandlerFunc func(input *RuleHandlerInput) (message string, err error)
+
+type RuleHandler struct {
+	Rule driverV2.Rule
+	// Message is the format of audit message, it is formatted by Func.
+	Message string
+	Func    RuleHandlerFunc
+}
+
+func message(input *RuleHandlerInput, args ...interface{}) string {
+	return fmt.Sprintf(input.Message, args...)
+}
+
+func ruleIntParam(rule *driverV2.Rule) int {
+	p := rule.Params.GetParam(DefaultSingleParamKeyName)
+	if p == nil {
+		return 0
+	}
+	return p.Int()
+}
+
+func ruleStringParam(rule *driverV2.Rule) string {
+	p := rule.Params.GetParam(DefaultSingleParamKeyName)
+	if p == nil {
+		return ""
+	}
+	return p.String()
+}
+
+var tableRowsParam = params.Params{
+	&params.Param{
+		Key:   DefaultSingleParamKeyName,
+		Value: "1000000",
+		Desc:  "表行数",
+		Type:  params.ParamTypeInt,
+	},
+}
+
+var RuleHandlers = []RuleHandler{
+	{
+		Rule: driverV2.Rule{
+			Name:       ConfigDMLRollbackMaxRows,
+			Desc:       "在 DML 语句中预计影响行数超过指定值则不回滚",
+			Annotation: "大事务回滚，容易影响数据库性能，使得业务发生波动；具体规则阈值可以根据业务需求调整，默认值：1000",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeGlobalConfig,
+			Params: params.Params{
+				&params.Param{
+					Key:   DefaultSingleParamKeyName,
+					Value: "1000",
+					Desc:  "最大影响行数",
+					Type:  params.ParamTypeInt,
+				},
+			},
+		},
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckObjectNameLength,
+			Desc:       "表名、列名、索引名的长度不能超过阈值",
+			Annotation: "PostgreSQL 标识符最大长度为 63 字节，超出部分会被静默截断，可能导致对象名称冲突或与预期不符；具体规则阈值可以根据业务需求调整，默认值：63",
+			Level:      driverV2.RuleLevelError,
+			Category:   RuleTypeNamingConvention,
+			Params: params.Params{
+				&params.Param{
+					Key:   DefaultSingleParamKeyName,
+					Value: "63",
+					Desc:  "最大长度（字节）",
+					Type:  params.ParamTypeInt,
+				},
+			},
+		},
+		Message: "表名、列名、索引名的长度不能大于%v字节，超长的名称：%v",
+		Func:    checkObjectNameLength,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckObjectNameIsLowerCase,
+			Desc:       "建议对象名称只使用小写字母",
+			Annotation: "PostgreSQL 会将未加双引号的标识符转换为小写，使用带大写字母的名称后，每次引用该对象都必须加双引号，容易出错",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeNamingConvention,
+		},
+		Message: "建议对象名称只使用小写字母，不符合规范的名称：%v",
+		Func:    checkObjectNameIsLowerCase,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckIndexPrefix,
+			Desc:       "建议普通索引使用固定前缀",
+			Annotation: "通过配置该规则可以规范指定业务的索引命名规则，具体命名规范可以自定义设置，默认提示值：idx_",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeNamingConvention,
+			Params: params.Params{
+				&params.Param{
+					Key:   DefaultSingleParamKeyName,
+					Value: "idx_",
+					Desc:  "索引前缀",
+					Type:  params.ParamTypeString,
+				},
+			},
+		},
+		Message: "建议普通索引要以\"%v\"为前缀",
+		Func:    checkIndexPrefix,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckUniqueIndexPrefix,
+			Desc:       "建议UNIQUE索引使用固定前缀",
+			Annotation: "通过配置该规则可以规范指定业务的UNIQUE索引命名规则，具体命名规范可以自定义设置，默认提示值：uniq_",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeNamingConvention,
+			Params: params.Params{
+				&params.Param{
+					Key:   DefaultSingleParamKeyName,
+					Value: "uniq_",
+					Desc:  "索引前缀",
+					Type:  params.ParamTypeString,
+				},
+			},
+		},
+		Message: "建议UNIQUE索引要以\"%v\"为前缀",
+		Func:    checkUniqueIndexPrefix,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckPKNotExist,
+			Desc:       "表必须有主键",
+			Annotation: "主键使数据达到全局唯一，逻辑复制等场景也依赖主键或唯一标识来定位行数据",
+			Level:      driverV2.RuleLevelError,
+			Category:   RuleTypeIndexingConvention,
+		},
+		Message: "表必须有主键",
+		Func:    checkPKNotExist,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckCompositeIndexMax,
+			Desc:       "复合索引的列数量不建议超过阈值",
+			Annotation: "复合索引会根据索引列数创建对应组合的索引，列数越多，创建的索引越多，每个索引都会增加磁盘空间的开销，同时增加索引维护的开销；具体规则阈值可以根据业务需求调整，默认值：3",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeIndexingConvention,
+			Params: params.Params{
+				&params.Param{
+					Key:   DefaultSingleParamKeyName,
+					Value: "3",
+					Desc:  "最大索引列数量",
+					Type:  params.ParamTypeInt,
+				},
+			},
+		},
+		Message: "复合索引的列数量不建议超过%v个",
+		Func:    checkCompositeIndexMax,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckCreateIndexConcurrently,
+			Desc:       "在已有表上创建索引建议使用 CONCURRENTLY",
+			Annotation: "CREATE INDEX 会对表加 SHARE 锁，建索引期间表上的写入全部阻塞；使用 CREATE INDEX CONCURRENTLY 可以在不阻塞写入的情况下创建索引",
+			Level:      driverV2.RuleLevelWarn,
+			Category:   RuleTypeIndexingConvention,
+		},
+		Message: "在已有表 %v 上创建索引会阻塞写入，建议使用 CREATE INDEX CONCURRENTLY",
+		Func:    checkCreateIndexConcurrently,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckDropIndexConcurrently,
+			Desc:       "删除索引建议使用 CONCURRENTLY",
+			Annotation: "DROP INDEX 会对索引所在的表加 ACCESS EXCLUSIVE 锁，阻塞表上的所有读写；使用 DROP INDEX CONCURRENTLY 可以避免长时间阻塞业务",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeIndexingConvention,
+		},
+		Message: "删除索引建议使用 DROP INDEX CONCURRENTLY",
+		Func:    checkDropIndexConcurrently,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckAddColumnWithDefault,
+			Desc:       "不建议在大表上添加需要重写表的带默认值的列",
+			Annotation: "PostgreSQL 11 之前的版本，或者默认值为易变函数（如 random()、clock_timestamp()、nextval()）时，ALTER TABLE ADD COLUMN ... DEFAULT 需要在 ACCESS EXCLUSIVE 锁下重写整张表，期间阻塞所有读写；具体规则阈值可以根据业务需求调整，默认值：1000000",
+			Level:      driverV2.RuleLevelWarn,
+			Category:   RuleTypeDDLConvention,
+			Params:     tableRowsParam.Copy(),
+		},
+		Message: "表 %v 添加带默认值的列 %v 需要重写整张表，会长时间阻塞读写，建议先添加不带默认值的列，再分批更新数据",
+		Func:    checkAddColumnWithDefault,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckAlterColumnType,
+			Desc:       "不建议在大表上修改列类型",
+			Annotation: "ALTER COLUMN TYPE 通常需要在 ACCESS EXCLUSIVE 锁下重写整张表及其索引，期间阻塞所有读写；具体规则阈值可以根据业务需求调整，默认值：1000000",
+			Level:      driverV2.RuleLevelWarn,
+			Category:   RuleTypeDDLConvention,
+			Params:     tableRowsParam.Copy(),
+		},
+		Message: "修改表 %v 的列 %v 的类型可能需要重写整张表，会长时间阻塞读写",
+		Func:    checkAlterColumnType,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckSetNotNull,
+			Desc:       "不建议在大表上直接为列添加 NOT NULL 约束",
+			Annotation: "SET NOT NULL 需要在 ACCESS EXCLUSIVE 锁下全表扫描校验数据；PostgreSQL 12 及以上版本可以先添加 CHECK (col IS NOT NULL) NOT VALID 约束并 VALIDATE，再设置 NOT NULL 以跳过全表扫描；具体规则阈值可以根据业务需求调整，默认值：1000000",
+			Level:      driverV2.RuleLevelWarn,
+			Category:   RuleTypeDDLConvention,
+			Params:     tableRowsParam.Copy(),
+		},
+		Message: "为表 %v 的列 %v 添加 NOT NULL 约束需要全表扫描，会长时间阻塞读写",
+		Func:    checkSetNotNull,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckAddConstraintNotValid,
+			Desc:       "添加外键或 CHECK 约束建议使用 NOT VALID",
+			Annotation: "直接添加外键或 CHECK 约束会在锁表期间校验全部已有数据；先使用 NOT VALID 添加约束，再执行 VALIDATE CONSTRAINT，校验时只需持有较弱的锁",
+			Level:      driverV2.RuleLevelWarn,
+			Category:   RuleTypeDDLConvention,
+		},
+		Message: "在表 %v 上添加约束 %v 建议使用 NOT VALID，再单独执行 VALIDATE CONSTRAINT",
+		Func:    checkAddConstraintNotValid,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLCheckAddUniqueConstraintUseIndex,
+			Desc:       "添加主键或唯一约束建议使用已有索引",
+			Annotation: "直接添加主键或唯一约束会在 ACCESS EXCLUSIVE 锁下创建索引；建议先使用 CREATE UNIQUE INDEX CONCURRENTLY 创建索引，再通过 ADD CONSTRAINT ... USING INDEX 添加约束",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeDDLConvention,
+		},
+		Message: "在表 %v 上添加主键或唯一约束建议先并发创建唯一索引，再使用 USING INDEX 添加约束",
+		Func:    checkAddUniqueConstraintUseIndex,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DDLDisableDropStatement,
+			Desc:       "禁止除索引外的DROP操作",
+			Annotation: "DROP是DDL，数据变更不会写入日志，无法进行回滚",
+			Level:      driverV2.RuleLevelError,
+			Category:   RuleTypeDDLConvention,
+		},
+		Message: "禁止除索引外的DROP操作",
+		Func:    checkDropStatement,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DMLCheckWhereIsInvalid,
+			Desc:       "禁止使用没有WHERE条件或者WHERE条件恒为TRUE的SQL",
+			Annotation: "SQL缺少WHERE条件在执行时会进行全表扫描产生额外开销，建议在大数据量高并发环境下开启，避免影响数据库查询性能",
+			Level:      driverV2.RuleLevelError,
+			Category:   RuleTypeDMLConvention,
+		},
+		Message: "禁止使用没有WHERE条件或者WHERE条件恒为TRUE的SQL",
+		Func:    checkWhereIsInvalid,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DMLDisableSelectAllColumn,
+			Desc:       "不建议使用SELECT *",
+			Annotation: "当表结构变更时，使用*通配符选择所有列将导致查询行为会发生更改，与业务期望不符；同时SELECT * 中的无用字段会带来不必要的磁盘I/O，以及网络开销，且无法覆盖索引进而回表，大幅度降低查询效率",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeDMLConvention,
+		},
+		Message: "不建议使用SELECT *",
+		Func:    checkSelectAllColumn,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DMLCheckInsertColumnsExist,
+			Desc:       "INSERT 语句需要指定COLUMN",
+			Annotation: "当表结构发生变更，INSERT请求不明确指定列名，会发生插入数据不匹配的情况；建议开启此规则，避免插入结果与业务预期不符",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeDMLConvention,
+		},
+		Message: "INSERT 语句需要指定COLUMN",
+		Func:    checkInsertColumnsExist,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DMLCheckSelectWithoutLimit,
+			Desc:       "SELECT 语句建议指定 LIMIT",
+			Annotation: "没有 LIMIT 的查询可能返回大量数据，占用大量内存和网络资源",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeDMLConvention,
+		},
+		Message: "SELECT 语句建议指定 LIMIT",
+		Func:    checkSelectWithoutLimit,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DMLCheckSelectForUpdate,
+			Desc:       "不建议使用 SELECT FOR UPDATE",
+			Annotation: "SELECT FOR UPDATE 会对查询结果集中的每一行加行锁，其他事务对这些行的更新会被阻塞，高并发场景下容易造成锁等待",
+			Level:      driverV2.RuleLevelNotice,
+			Category:   RuleTypeDMLConvention,
+		},
+		Message: "不建议使用 SELECT FOR UPDATE",
+		Func:    checkSelectForUpdate,
+	},
+	{
+		Rule: driverV2.Rule{
+			Name:       DMLDisableTruncateStatement,
+			Desc:       "禁止使用 TRUNCATE",
+			Annotation: "TRUNCATE 会对表加 ACCESS EXCLUSIVE 锁并直接清空数据，无法通过 SQLE 生成回滚语句",
+			Level:      driverV2.RuleLevelError,
+			Category:   RuleTypeDMLConvention,
+		},
+		Message: "禁止使用 TRUNCATE",
+		Func:    checkTruncateStatement,
+	},
+}
+
+// objectNames returns the names of objects created or renamed by the statement.
+func objectNames(node parser.Node) []string {
+	names := []string{}
+	switch stmt := node.(type) {
+	case *parser.CreateTableStmt:
+		names = append(names, stmt.Table.Name)
+		for _, col := range stmt.Columns {
+			names = append(names, col.Name)
+		}
+		for _, c := range stmt.Constraints {
+			if c.Name != "" {
+				names = append(names, c.Name)
+			}
+		}
+	case *parser.AlterTableStmt:
+		for _, cmd := range stmt.Cmds {
+			switch cmd.Type {
+			case parser.AlterTableAddColumn:
+				names = append(names, cmd.Column.Name)
+			case parser.AlterTableAddConstraint:
+				if cmd.Constraint.Name != "" {
+					names = append(names, cmd.Constraint.Name)
+				}
+			case parser.AlterTableRenameColumn, parser.AlterTableRenameConstraint, parser.AlterTableRenameTable:
+				names = append(names, cmd.NewName)
+			}
+		}
+	case *parser.CreateIndexStmt:
+		if stmt.Name != "" {
+			names = append(names, stmt.Name)
+		}
+	}
+	return names
+}
+
+func checkObjectNameLength(input *RuleHandlerInput) (string, error) {
+	max := ruleIntParam(input.Rule)
+	invalid := []string{}
+	for _, name := range objectNames(input.Node) {
+		name = parser.FullIdentifier(input.Node.Text(), name)
+		if len(name) > max {
+			invalid = append(invalid, name)
+		}
+	}
+	if len(invalid) > 0 {
+		return message(input, max, strings.Join(invalid, ",")), nil
+	}
+	return "", nil
+}
+
+func checkObjectNameIsLowerCase(input *RuleHandlerInput) (string, error) {
+	invalid := []string{}
+	for _, name := range objectNames(input.Node) {
+		if name != strings.ToLower(name) {
+			invalid = append(invalid, name)
+		}
+	}
+	if len(invalid) > 0 {
+		return message(input, strings.Join(invalid, ",")), nil
+	}
+	return "", nil
+}
+
+func checkIndexPrefix(input *RuleHandlerInput) (string, error) {
+	stmt, ok := input.Node.(*parser.CreateIndexStmt)
+	if !ok || stmt.Unique || stmt.Name == "" {
+		return "", nil
+	}
+	prefix := ruleStringParam(input.Rule)
+	if !strings.HasPrefix(stmt.Name, prefix) {
+		return message(input, prefix), nil
+	}
+	return "", nil
+}
+
+func checkUniqueIndexPrefix(input *RuleHandlerInput) (string, error) {
+	prefix := ruleStringParam(input.Rule)
+	names := []string{}
+	switch stmt := input.Node.(type) {
+	case *parser.CreateIndexStmt:
+		if stmt.Unique {
+			names = append(names, stmt.Name)
+		}
+	case *parser.CreateTableStmt:
+		for _, c := range stmt.Constraints {
+			if c.Type == parser.ConstraintUnique && c.Name != "" {
+				names = append(names, c.Name)
+			}
+		}
+	case *parser.AlterTableStmt:
+		for _, cmd := range stmt.Cmds {
+			if cmd.Type == parser.AlterTableAddConstraint && cmd.Constraint.Type == parser.ConstraintUnique {
+				names = append(names, cmd.Constraint.Name)
+			}
+		}
+	}
+	for _, name := range names {
+		if name != "" && !strings.HasPrefix(name, prefix) {
+			return message(input, prefix), nil
+		}
+	}
+	return "", nil
+}
+
+func checkPKNotExist(input *RuleHandlerInput) (string, error) {
+	stmt, ok := input.Node.(*parser.CreateTableStmt)
+	// CREATE TABLE ... AS/PARTITION OF has no column definitions
+	if !ok || len(stmt.Columns) == 0 {
+		return "", nil
+	}
+	for _, col := range stmt.Columns {
+		if col.PrimaryKey {
+			return "", nil
+		}
+	}
+	for _, c := range stmt.Constraints {
+		if c.Type == parser.ConstraintPrimaryKey {
+			return "", nil
+		}
+	}
+	return message(input), nil
+}
+
+func checkCompositeIndexMax(input *RuleHandlerInput) (string, error) {
+	max := ruleIntParam(input.Rule)
+	counts := []int{}
+	switch stmt := input.Node.(type) {
+	case *parser.CreateIndexStmt:
+		counts = append(counts, len(stmt.Columns))
+	case *parser.CreateTableStmt:
+		for _, c := range stmt.Constraints {
+			counts = append(counts, len(c.Columns))
+		}
+	case *parser.AlterTableStmt:
+		for _, cmd := range stmt.Cmds {
+			if cmd.Type == parser.AlterTableAddConstraint {
+				counts = append(counts, len(cmd.Constraint.Columns))
+			}
+		}
+	}
+	for _, count := range counts {
+		if count > max {
+			return message(input, max), nil
+		}
+	}
+	return "", nil
+}
+
+func checkCreateIndexConcurrently(input *RuleHandlerInput) (string, error) {
+	stmt, ok := input.Node.(*parser.CreateIndexStmt)
+	if !ok || stmt.Concurrently {
+		return "", nil
+	}
+	// the index on a table which is not created yet will not block anything.
+	if input.Inspector != nil {
+		_, exist, err := input.Inspector.TableRows(input.Ctx, stmt.Table)
+		if err != nil {
+			return "", err
+		}
+		if !exist {
+			return "", nil
+		}
+	}
+	return message(input, stmt.Table.String()), nil
+}
+
+func checkDropIndexConcurrently(input *RuleHandlerInput) (string, error) {
+	stmt, ok := input.Node.(*parser.DropStmt)
+	if ok && stmt.ObjectType == "index" && !stmt.Concurrently {
+		return message(input), nil
+	}
+	return "", nil
+}
+
+// isLargeTable returns true if the table has more rows than the rule param, the
+// table is treated as a large table when its size is unknown.
+func isLargeTable(input *RuleHandlerInput, table parser.TableName) (bool, error) {
+	if input.Inspector == nil {
+		return true, nil
+	}
+	rows, exist, err := input.Inspector.TableRows(input.Ctx, table)
+	if err != nil {
+		return false, err
+	}
+	if !exist {
+		return false, nil
+	}
+	return rows >= int64(ruleIntParam(input.Rule)), nil
+}
+
+// volatileFunctions are common volatile functions, a column with volatile default
+// value can not be added without a table rewrite.
+var volatileFunctions = []string{
+	"random", "clock_timestamp", "timeofday", "nextval", "gen_random_uuid",
+	"uuid_generate_v1", "uuid_generate_v1mc", "uuid_generate_v4", "txid_current",
+}
+
+func isVolatileDefault(expr string) bool {
+	for _, name := range parser.FunctionCalls(expr) {
+		for _, fn := range volatileFunctions {
+			if name == fn {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addColumnRewriteTable reports whether adding the column needs to rewrite the table.
+func addColumnRewriteTable(input *RuleHandlerInput, col *parser.ColumnDef) (bool, error) {
+	colType := strings.TrimSpace(col.Type)
+	if colType == "serial" || colType == "bigserial" || colType == "smallserial" ||
+		colType == "serial4" || colType == "serial8" || colType == "serial2" {
+		return true, nil
+	}
+	if !col.HasDefault || strings.EqualFold(strings.TrimSpace(col.Default), "null") {
+		return false, nil
+	}
+	if isVolatileDefault(col.Default) {
+		return true, nil
+	}
+	// PostgreSQL 11 and later store the non-volatile default in catalog without rewriting table.
+	if input.Inspector != nil {
+		version, err := input.Inspector.ServerVersionNum(input.Ctx)
+		if err != nil {
+			return false, err
+		}
+		return version < 110000, nil
+	}
+	return false, nil
+}
+
+func checkAddColumnWithDefault(input *RuleHandlerInput) (string, error) {
+	stmt, ok := input.Node.(*parser.AlterTableStmt)
+	if !ok {
+		return "", nil
+	}
+	columns := []string{}
+	for _, cmd := range stmt.Cmds {
+		if cmd.Type != parser.AlterTableAddColumn {
+			continue
+		}
+		rewrite, err := addColumnRewriteTable(input, cmd.Column)
+		if err != nil {
+			return "", err
+		}
+		if rewrite {
+			columns = append(columns, cmd.Column.Name)
+		}
+	}
+	if len(columns) == 0 {
+		return "", nil
+	}
+	large, err := isLargeTable(input, stmt.Table)
+	if err != nil || !large {
+		return "", err
+	}
+	return message(input, stmt.Table.String(), strings.Join(columns, ",")), nil
+}
+
+func checkAlterTableColumns(input *RuleHandlerInput, cmdType parser.AlterTableCmdType) (string, error) {
+	stmt, ok := input.Node.(*parser.AlterTableStmt)
+	if !ok {
+		return "", nil
+	}
+	columns := []string{}
+	for _, cmd := range stmt.Cmds {
+		if cmd.Type == cmdType {
+			columns = append(columns, cmd.ColumnName)
+		}
+	}
+	if len(columns) == 0 {
+		return "", nil
+	}
+	large, err := isLargeTable(input, stmt.Table)
+	if err != nil || !large {
+		return "", err
+	}
+	return message(input, stmt.Table.String(), strings.Join(columns, ",")), nil
+}
+
+func checkAlterColumnType(input *RuleHandlerInput) (string, error) {
+	return checkAlterTableColumns(input, parser.AlterTableAlterColumnType)
+}
+
+func checkSetNotNull(input *RuleHandlerInput) (string, error) {
+	return checkAlterTableColumns(input, parser.AlterTableSetNotNull)
+}
+
+func checkAddConstraintNotValid(input *RuleHandlerInput) (string, error) {
+	stmt, ok := input.Node.(*parser.AlterTableStmt)
+	if !ok {
+		return "", nil
+	}
+	for _, cmd := range stmt.Cmds {
+		if cmd.Type != parser.AlterTableAddConstraint || cmd.Constraint.NotValid {
+			continue
+		}
+		if cmd.Constraint.Type == parser.ConstraintForeignKey || cmd.Constraint.Type == parser.ConstraintCheck {
+			return message(input, stmt.Table.String(), cmd.Constraint.Name), nil
+		}
+	}
+	return "", nil
+}
+
+func checkAddUniqueConstraintUseIndex(input *RuleHandlerInput) (string, error) {
+	stmt, ok := input.Node.(*parser.AlterTableStmt)
+	if !ok {
+		return "", nil
+	}
+	for _, cmd := range stmt.Cmds {
+		if cmd.Type != parser.AlterTableAddConstraint {
+			continue
+		}
+		c := cmd.Constraint
+		if (c.Type == parser.ConstraintPrimaryKey || c.Type == parser.ConstraintUnique) && len(c.Columns) > 0 {
+			return message(input, stmt.Table.String()), nil
+		}
+	}
+	return "", nil
+}
+
+func checkDropStatement(input *RuleHandlerInput) (string, error) {
+	stmt, ok := input.Node.(*parser.DropStmt)
+	if !ok {
+		return "", nil
+	}
+	switch stmt.ObjectType {
+	case "table", "database", "schema", "materialized view", "foreign table":
+		return message(input), nil
+	}
+	return "", nil
+}
+
+func checkWhereIsInvalid(input *RuleHandlerInput) (string, error) {
+	var where string
+	switch stmt := input.Node.(type) {
+	case *parser.UpdateStmt:
+		where = stmt.Where
+	case *parser.DeleteStmt:
+		where = stmt.Where
+	default:
+		return "", nil
+	}
+	if parser.IsAlwaysTrue(where) {
+		return message(input), nil
+	}
+	return "", nil
+}
+
+func checkSelectAllColumn(input *RuleHandlerInput) (string, error) {
+	if stmt, ok := input.Node.(*parser.SelectStmt); ok && stmt.SelectStar {
+		return message(input), nil
+	}
+	return "", nil
+}
+
+func checkInsertColumnsExist(input *RuleHandlerInput) (string, error) {
+	if stmt, ok := input.Node.(*parser.InsertStmt); ok && len(stmt.Columns) == 0 {
+		return message(input), nil
+	}
+	return "", nil
+}
+
+func checkSelectWithoutLimit(input *RuleHandlerInput) (string, error) {
+	if stmt, ok := input.Node.(*parser.SelectStmt); ok && len(stmt.Tables) > 0 && !stmt.HasLimit {
+		return message(input), nil
+	}
+	return "", nil
+}
+
+func checkSelectForUpdate(input *RuleHandlerInput) (string, error) {
+	if stmt, ok := input.Node.(*parser.SelectStmt); ok && stmt.ForUpdate {
+		return message(input), nil
+	}
+	return "", nil
+}
+
+func checkTruncateStatement(input *RuleHandlerInput) (string, error) {
+	if _, ok := input.Node.(*parser.TruncateStmt); ok {
+		return message(input), nil
+	}
+	return "", nil
+}
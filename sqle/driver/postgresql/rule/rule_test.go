@@ -0,0 +1,124 @@
+package rule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/driver/postgresql/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockInspector struct {
+	rows    map[string]int64
+	version int
+}
+
+func (i *mockInspector) TableRows(ctx context.Context, table parser.TableName) (int64, bool, error) {
+	rows, ok := i.rows[table.Name]
+	return rows, ok, nil
+}
+
+func (i *mockInspector) ServerVersionNum(ctx context.Context) (int, error) {
+	return i.version, nil
+}
+
+func runRule(t *testing.T, ruleName string, inspector Inspector, sql string) string {
+	var handler *RuleHandler
+	for i := range RuleHandlers {
+		if RuleHandlers[i].Rule.Name == ruleName {
+			handler = &RuleHandlers[i]
+		}
+	}
+	if !assert.NotNil(t, handler) {
+		return ""
+	}
+	node, err := parser.ParseOneStmt(sql)
+	assert.NoError(t, err)
+	rule := handler.Rule
+	msg, err := handler.Func(&RuleHandlerInput{
+		Ctx:       context.TODO(),
+		Rule:      &rule,
+		Node:      node,
+		Inspector: inspector,
+		Message:   handler.Message,
+	})
+	assert.NoError(t, err)
+	return msg
+}
+
+func TestRuleNaming(t *testing.T) {
+	assert.NotEmpty(t, runRule(t, DDLCheckObjectNameLength, nil,
+		"CREATE TABLE t1 (a_very_long_column_name_which_will_be_truncated_by_postgresql_server int)"))
+	assert.Empty(t, runRule(t, DDLCheckObjectNameLength, nil, "CREATE TABLE t1 (id int)"))
+
+	assert.NotEmpty(t, runRule(t, DDLCheckObjectNameIsLowerCase, nil, `CREATE TABLE "User" (id int)`))
+	assert.Empty(t, runRule(t, DDLCheckObjectNameIsLowerCase, nil, `CREATE TABLE Users (id int)`))
+
+	assert.NotEmpty(t, runRule(t, DDLCheckIndexPrefix, nil, "CREATE INDEX i_name ON t1 (name)"))
+	assert.Empty(t, runRule(t, DDLCheckIndexPrefix, nil, "CREATE INDEX idx_name ON t1 (name)"))
+	assert.NotEmpty(t, runRule(t, DDLCheckUniqueIndexPrefix, nil, "ALTER TABLE t1 ADD CONSTRAINT u_name UNIQUE (name)"))
+	assert.Empty(t, runRule(t, DDLCheckUniqueIndexPrefix, nil, "CREATE UNIQUE INDEX uniq_name ON t1 (name)"))
+}
+
+func TestRuleIndex(t *testing.T) {
+	assert.NotEmpty(t, runRule(t, DDLCheckPKNotExist, nil, "CREATE TABLE t1 (id int, name text)"))
+	assert.Empty(t, runRule(t, DDLCheckPKNotExist, nil, "CREATE TABLE t1 (id int PRIMARY KEY)"))
+	assert.Empty(t, runRule(t, DDLCheckPKNotExist, nil, "CREATE TABLE t1 (id int, CONSTRAINT pk PRIMARY KEY (id))"))
+
+	assert.NotEmpty(t, runRule(t, DDLCheckCompositeIndexMax, nil, "CREATE INDEX idx_1 ON t1 (a, b, c, d)"))
+	assert.Empty(t, runRule(t, DDLCheckCompositeIndexMax, nil, "CREATE INDEX idx_1 ON t1 (a, b, c)"))
+
+	inspector := &mockInspector{rows: map[string]int64{"t1": 10}}
+	assert.NotEmpty(t, runRule(t, DDLCheckCreateIndexConcurrently, inspector, "CREATE INDEX idx_1 ON t1 (a)"))
+	assert.Empty(t, runRule(t, DDLCheckCreateIndexConcurrently, inspector, "CREATE INDEX CONCURRENTLY idx_1 ON t1 (a)"))
+	// the table is not created yet
+	assert.Empty(t, runRule(t, DDLCheckCreateIndexConcurrently, inspector, "CREATE INDEX idx_1 ON t2 (a)"))
+
+	assert.NotEmpty(t, runRule(t, DDLCheckDropIndexConcurrently, nil, "DROP INDEX idx_1"))
+	assert.Empty(t, runRule(t, DDLCheckDropIndexConcurrently, nil, "DROP INDEX CONCURRENTLY idx_1"))
+}
+
+func TestRuleDML(t *testing.T) {
+	assert.NotEmpty(t, runRule(t, DMLCheckWhereIsInvalid, nil, "DELETE FROM t1"))
+	assert.NotEmpty(t, runRule(t, DMLCheckWhereIsInvalid, nil, "UPDATE t1 SET a = 1 WHERE 1 = 1"))
+	assert.NotEmpty(t, runRule(t, DMLCheckWhereIsInvalid, nil, "UPDATE t1 SET a = 1 WHERE true"))
+	assert.Empty(t, runRule(t, DMLCheckWhereIsInvalid, nil, "UPDATE t1 SET a = 1 WHERE a = a + 0 AND id = 1"))
+
+	assert.NotEmpty(t, runRule(t, DMLDisableSelectAllColumn, nil, "SELECT * FROM t1"))
+	assert.Empty(t, runRule(t, DMLDisableSelectAllColumn, nil, "SELECT count(*) FROM t1"))
+
+	assert.NotEmpty(t, runRule(t, DMLCheckInsertColumnsExist, nil, "INSERT INTO t1 VALUES (1)"))
+	assert.Empty(t, runRule(t, DMLCheckInsertColumnsExist, nil, "INSERT INTO t1 (id) VALUES (1)"))
+
+	assert.NotEmpty(t, runRule(t, DMLDisableTruncateStatement, nil, "TRUNCATE TABLE t1"))
+}
+
+func TestRuleLockHeavyDDL(t *testing.T) {
+	large := &mockInspector{rows: map[string]int64{"t1": 5000000}, version: 150002}
+	small := &mockInspector{rows: map[string]int64{"t1": 10}, version: 150002}
+	old := &mockInspector{rows: map[string]int64{"t1": 5000000}, version: 100012}
+
+	// volatile default always rewrites the table
+	sql := "ALTER TABLE t1 ADD COLUMN c1 uuid DEFAULT gen_random_uuid()"
+	assert.NotEmpty(t, runRule(t, DDLCheckAddColumnWithDefault, large, sql))
+	assert.NotEmpty(t, runRule(t, DDLCheckAddColumnWithDefault, nil, sql))
+	assert.Empty(t, runRule(t, DDLCheckAddColumnWithDefault, small, sql))
+	// constant default only rewrites the table before PostgreSQL 11
+	sql = "ALTER TABLE t1 ADD COLUMN c1 int NOT NULL DEFAULT 0"
+	assert.Empty(t, runRule(t, DDLCheckAddColumnWithDefault, large, sql))
+	assert.NotEmpty(t, runRule(t, DDLCheckAddColumnWithDefault, old, sql))
+	assert.NotEmpty(t, runRule(t, DDLCheckAddColumnWithDefault, large, "ALTER TABLE t1 ADD COLUMN c1 bigserial"))
+	assert.Empty(t, runRule(t, DDLCheckAddColumnWithDefault, old, "ALTER TABLE t1 ADD COLUMN c1 int"))
+
+	assert.NotEmpty(t, runRule(t, DDLCheckAlterColumnType, large, "ALTER TABLE t1 ALTER COLUMN c1 TYPE bigint"))
+	assert.Empty(t, runRule(t, DDLCheckAlterColumnType, small, "ALTER TABLE t1 ALTER COLUMN c1 TYPE bigint"))
+	assert.NotEmpty(t, runRule(t, DDLCheckSetNotNull, large, "ALTER TABLE t1 ALTER COLUMN c1 SET NOT NULL"))
+
+	assert.NotEmpty(t, runRule(t, DDLCheckAddConstraintNotValid, nil, "ALTER TABLE t1 ADD CONSTRAINT ck CHECK (c1 > 0)"))
+	assert.Empty(t, runRule(t, DDLCheckAddConstraintNotValid, nil, "ALTER TABLE t1 ADD CONSTRAINT ck CHECK (c1 > 0) NOT VALID"))
+	assert.NotEmpty(t, runRule(t, DDLCheckAddUniqueConstraintUseIndex, nil, "ALTER TABLE t1 ADD PRIMARY KEY (id)"))
+	assert.Empty(t, runRule(t, DDLCheckAddUniqueConstraintUseIndex, nil, "ALTER TABLE t1 ADD CONSTRAINT pk PRIMARY KEY USING INDEX uniq_id"))
+
+	assert.NotEmpty(t, runRule(t, DDLDisableDropStatement, nil, "DROP TABLE t1"))
+	assert.Empty(t, runRule(t, DDLDisableDropStatement, nil, "DROP INDEX idx_1"))
+}
@@ -0,0 +1,21 @@
+package driver
+
+import (
+	"context"
+)
+
+type StatementProgress struct {
+	// State is the thread state of the statement, such as "Sending data".
+	State          string
+	ElapsedSeconds int64
+	RowsExamined   int64
+	RowsAffected   int64
+}
+
+// StatementProgressReporter is implemented by the plugin which is able to report the progress of
+// the statement running on the connection used by Exec, the progress is got from another
+// connection. Now only the built-in MySQL plugin implements it.
+type StatementProgressReporter interface {
+	// GetStatementProgress returns nil if there is no statement running.
+	GetStatementProgress(ctx context.Context) (*StatementProgress, error)
+}
@@ -0,0 +1,25 @@
+package driver
+
+import (
+	"context"
+)
+
+// Rehearser is implemented by the plugin which is able to recreate the schema of target
+// instance on a sandbox instance, the SQLs of task are rehearsed on the sandbox instance
+// before the workflow is approved. Now only the built-in MySQL plugin implements it.
+type Rehearser interface {
+	// ShowCreateTable returns the CREATE TABLE statement of table, exist is false if the
+	// table does not exist.
+	ShowCreateTable(ctx context.Context, schema, table string) (createTableSQL string, exist bool, err error)
+
+	// CreateSchemaWithTables creates the schema and the tables in it, it returns error if
+	// the schema already exists, so that the schema not created by rehearsal is never dropped.
+	CreateSchemaWithTables(ctx context.Context, schema string, createTableSQLs []string) error
+
+	// DropSchema drops the schema created by CreateSchemaWithTables.
+	DropSchema(ctx context.Context, schema string) error
+
+	// ExtractSchemasFromSQL returns the schemas named by sql explicitly, such as the schemas of
+	// tables and the schemas created, dropped or used by sql, e.g. "DROP DATABASE db1".
+	ExtractSchemasFromSQL(ctx context.Context, sql string) ([]string, error)
+}
@@ -0,0 +1,24 @@
+package driver
+
+import (
+	"context"
+)
+
+// ReplicaLagGuardConfig is the config of checking the replica lag before executing the SQLs of task.
+type ReplicaLagGuardConfig struct {
+	MaxLagSeconds int64
+	// PauseOnLag is true if the execution is paused until the lag drops to MaxLagSeconds,
+	// otherwise the execution fails.
+	PauseOnLag bool
+}
+
+// ReplicaLagGuard is implemented by the plugin which is able to get the lag of the replicas of
+// instance, it is checked before the execution and between the SQLs, so that the large batches
+// do not push the replicas too far behind. Now only the built-in MySQL plugin implements it.
+type ReplicaLagGuard interface {
+	// GetReplicaLagGuardConfig returns nil if the replica lag is not checked.
+	GetReplicaLagGuardConfig(ctx context.Context) (*ReplicaLagGuardConfig, error)
+
+	// GetReplicaLag returns the max lag of the replicas of instance, ok is false if there is no replica.
+	GetReplicaLag(ctx context.Context) (lagSeconds int64, ok bool, err error)
+}
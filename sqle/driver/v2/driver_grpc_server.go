@@ -3,6 +3,7 @@ package driverV2
 import (
 	"context"
 	"fmt"
+	"io"
 	"sync"
 
 	protoV2 "github.com/actiontech/sqle/sqle/driver/v2/proto"
@@ -188,21 +189,31 @@ func (d *DriverGrpcServer) Audit(ctx context.Context, req *protoV2.AuditRequest)
 		return &protoV2.AuditResponse{}, err
 	}
 
-	resp := &protoV2.AuditResponse{}
-	for _, results := range auditResults {
-		rets := &protoV2.AuditResults{
-			Results: []*protoV2.AuditResult{},
+	return &protoV2.AuditResponse{
+		AuditResults: ConvertAuditResultsFromDriverToProto(auditResults),
+	}, nil
+}
+
+// AuditStream receives SQLs chunk by chunk and audits every chunk as soon as it arrives,
+// so the plugin only holds one chunk in memory at a time. The driver session keeps its
+// context between chunks, so the result is the same as auditing the whole batch at once.
+func (d *DriverGrpcServer) AuditStream(stream protoV2.Driver_AuditStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
 		}
-		for _, result := range results.Results {
-			rets.Results = append(rets.Results, &protoV2.AuditResult{
-				Level:    string(result.Level),
-				Message:  result.Message,
-				RuleName: result.RuleName,
-			})
+		if err != nil {
+			return err
+		}
+		resp, err := d.Audit(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
 		}
-		resp.AuditResults = append(resp.AuditResults, rets)
 	}
-	return resp, nil
 }
 
 func (d *DriverGrpcServer) GenRollbackSQL(ctx context.Context, req *protoV2.GenRollbackSQLRequest) (*protoV2.GenRollbackSQLResponse, error) {
@@ -144,9 +144,17 @@ type AuditResults struct {
 }
 
 type AuditResult struct {
-	Level    RuleLevel
-	Message  string
-	RuleName string
+	Level         RuleLevel
+	Message       string
+	RuleName      string
+	FixSuggestion *FixSuggestion
+}
+
+// FixSuggestion is a machine-applicable rewrite of the audited SQL, the
+// RewrittenSQL can replace the original SQL as a whole.
+type FixSuggestion struct {
+	Description  string
+	RewrittenSQL string
 }
 
 func NewAuditResults() *AuditResults {
@@ -206,6 +214,17 @@ func (rs *AuditResults) Add(level RuleLevel, ruleName string, messagePattern str
 	rs.SortByLevel()
 }
 
+// SetFixSuggestion attaches fix to the result which is generated by rule ruleName,
+// it does nothing if the rule has no result.
+func (rs *AuditResults) SetFixSuggestion(ruleName string, fix *FixSuggestion) {
+	for _, result := range rs.Results {
+		if result.RuleName == ruleName {
+			result.FixSuggestion = fix
+			return
+		}
+	}
+}
+
 func (rs *AuditResults) SortByLevel() {
 	sort.Slice(rs.Results, func(i, j int) bool {
 		return rs.Results[i].Level.More(rs.Results[j].Level)
@@ -25,6 +25,7 @@ It has these top-level messages:
 	ParseResponse
 	AuditSQL
 	AuditRequest
+	FixSuggestion
 	AuditResult
 	AuditResults
 	AuditResponse
@@ -597,16 +598,41 @@ func (m *AuditRequest) GetSqls() []*AuditSQL {
 	return nil
 }
 
+type FixSuggestion struct {
+	Description  string `protobuf:"bytes,1,opt,name=description" json:"description,omitempty"`
+	RewrittenSql string `protobuf:"bytes,2,opt,name=rewritten_sql,json=rewrittenSql" json:"rewritten_sql,omitempty"`
+}
+
+func (m *FixSuggestion) Reset()                    { *m = FixSuggestion{} }
+func (m *FixSuggestion) String() string            { return proto.CompactTextString(m) }
+func (*FixSuggestion) ProtoMessage()               {}
+func (*FixSuggestion) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *FixSuggestion) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *FixSuggestion) GetRewrittenSql() string {
+	if m != nil {
+		return m.RewrittenSql
+	}
+	return ""
+}
+
 type AuditResult struct {
-	Message  string `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
-	Level    string `protobuf:"bytes,2,opt,name=level" json:"level,omitempty"`
-	RuleName string `protobuf:"bytes,3,opt,name=rule_name,json=ruleName" json:"rule_name,omitempty"`
+	Message       string         `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	Level         string         `protobuf:"bytes,2,opt,name=level" json:"level,omitempty"`
+	RuleName      string         `protobuf:"bytes,3,opt,name=rule_name,json=ruleName" json:"rule_name,omitempty"`
+	FixSuggestion *FixSuggestion `protobuf:"bytes,4,opt,name=fix_suggestion,json=fixSuggestion" json:"fix_suggestion,omitempty"`
 }
 
 func (m *AuditResult) Reset()                    { *m = AuditResult{} }
 func (m *AuditResult) String() string            { return proto.CompactTextString(m) }
 func (*AuditResult) ProtoMessage()               {}
-func (*AuditResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*AuditResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *AuditResult) GetMessage() string {
 	if m != nil {
@@ -629,6 +655,13 @@ func (m *AuditResult) GetRuleName() string {
 	return ""
 }
 
+func (m *AuditResult) GetFixSuggestion() *FixSuggestion {
+	if m != nil {
+		return m.FixSuggestion
+	}
+	return nil
+}
+
 type AuditResults struct {
 	Results []*AuditResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
 }
@@ -636,7 +669,7 @@ type AuditResults struct {
 func (m *AuditResults) Reset()                    { *m = AuditResults{} }
 func (m *AuditResults) String() string            { return proto.CompactTextString(m) }
 func (*AuditResults) ProtoMessage()               {}
-func (*AuditResults) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*AuditResults) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *AuditResults) GetResults() []*AuditResult {
 	if m != nil {
@@ -652,7 +685,7 @@ type AuditResponse struct {
 func (m *AuditResponse) Reset()                    { *m = AuditResponse{} }
 func (m *AuditResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuditResponse) ProtoMessage()               {}
-func (*AuditResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*AuditResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *AuditResponse) GetAuditResults() []*AuditResults {
 	if m != nil {
@@ -669,7 +702,7 @@ type NeedRollbackSQL struct {
 func (m *NeedRollbackSQL) Reset()                    { *m = NeedRollbackSQL{} }
 func (m *NeedRollbackSQL) String() string            { return proto.CompactTextString(m) }
 func (*NeedRollbackSQL) ProtoMessage()               {}
-func (*NeedRollbackSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*NeedRollbackSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *NeedRollbackSQL) GetQuery() string {
 	if m != nil {
@@ -686,7 +719,7 @@ type GenRollbackSQLRequest struct {
 func (m *GenRollbackSQLRequest) Reset()                    { *m = GenRollbackSQLRequest{} }
 func (m *GenRollbackSQLRequest) String() string            { return proto.CompactTextString(m) }
 func (*GenRollbackSQLRequest) ProtoMessage()               {}
-func (*GenRollbackSQLRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*GenRollbackSQLRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *GenRollbackSQLRequest) GetSession() *Session {
 	if m != nil {
@@ -710,7 +743,7 @@ type RollbackSQL struct {
 func (m *RollbackSQL) Reset()                    { *m = RollbackSQL{} }
 func (m *RollbackSQL) String() string            { return proto.CompactTextString(m) }
 func (*RollbackSQL) ProtoMessage()               {}
-func (*RollbackSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*RollbackSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *RollbackSQL) GetQuery() string {
 	if m != nil {
@@ -733,7 +766,7 @@ type GenRollbackSQLResponse struct {
 func (m *GenRollbackSQLResponse) Reset()                    { *m = GenRollbackSQLResponse{} }
 func (m *GenRollbackSQLResponse) String() string            { return proto.CompactTextString(m) }
 func (*GenRollbackSQLResponse) ProtoMessage()               {}
-func (*GenRollbackSQLResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*GenRollbackSQLResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *GenRollbackSQLResponse) GetSql() *RollbackSQL {
 	if m != nil {
@@ -750,7 +783,7 @@ type PingRequest struct {
 func (m *PingRequest) Reset()                    { *m = PingRequest{} }
 func (m *PingRequest) String() string            { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()               {}
-func (*PingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*PingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *PingRequest) GetSession() *Session {
 	if m != nil {
@@ -767,7 +800,7 @@ type ExecSQL struct {
 func (m *ExecSQL) Reset()                    { *m = ExecSQL{} }
 func (m *ExecSQL) String() string            { return proto.CompactTextString(m) }
 func (*ExecSQL) ProtoMessage()               {}
-func (*ExecSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*ExecSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *ExecSQL) GetQuery() string {
 	if m != nil {
@@ -784,7 +817,7 @@ type ExecRequest struct {
 func (m *ExecRequest) Reset()                    { *m = ExecRequest{} }
 func (m *ExecRequest) String() string            { return proto.CompactTextString(m) }
 func (*ExecRequest) ProtoMessage()               {}
-func (*ExecRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*ExecRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *ExecRequest) GetSession() *Session {
 	if m != nil {
@@ -810,7 +843,7 @@ type ExecResult struct {
 func (m *ExecResult) Reset()                    { *m = ExecResult{} }
 func (m *ExecResult) String() string            { return proto.CompactTextString(m) }
 func (*ExecResult) ProtoMessage()               {}
-func (*ExecResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*ExecResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *ExecResult) GetLastInsertId() int64 {
 	if m != nil {
@@ -847,7 +880,7 @@ type ExecResponse struct {
 func (m *ExecResponse) Reset()                    { *m = ExecResponse{} }
 func (m *ExecResponse) String() string            { return proto.CompactTextString(m) }
 func (*ExecResponse) ProtoMessage()               {}
-func (*ExecResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*ExecResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *ExecResponse) GetResult() *ExecResult {
 	if m != nil {
@@ -865,7 +898,7 @@ type TxRequest struct {
 func (m *TxRequest) Reset()                    { *m = TxRequest{} }
 func (m *TxRequest) String() string            { return proto.CompactTextString(m) }
 func (*TxRequest) ProtoMessage()               {}
-func (*TxRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*TxRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *TxRequest) GetSession() *Session {
 	if m != nil {
@@ -888,7 +921,7 @@ type TxResponse struct {
 func (m *TxResponse) Reset()                    { *m = TxResponse{} }
 func (m *TxResponse) String() string            { return proto.CompactTextString(m) }
 func (*TxResponse) ProtoMessage()               {}
-func (*TxResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*TxResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *TxResponse) GetResults() []*ExecResult {
 	if m != nil {
@@ -905,7 +938,7 @@ type QuerySQL struct {
 func (m *QuerySQL) Reset()                    { *m = QuerySQL{} }
 func (m *QuerySQL) String() string            { return proto.CompactTextString(m) }
 func (*QuerySQL) ProtoMessage()               {}
-func (*QuerySQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*QuerySQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *QuerySQL) GetQuery() string {
 	if m != nil {
@@ -921,7 +954,7 @@ type QueryConf struct {
 func (m *QueryConf) Reset()                    { *m = QueryConf{} }
 func (m *QueryConf) String() string            { return proto.CompactTextString(m) }
 func (*QueryConf) ProtoMessage()               {}
-func (*QueryConf) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*QueryConf) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *QueryConf) GetTimeoutSecond() uint32 {
 	if m != nil {
@@ -939,7 +972,7 @@ type QueryRequest struct {
 func (m *QueryRequest) Reset()                    { *m = QueryRequest{} }
 func (m *QueryRequest) String() string            { return proto.CompactTextString(m) }
 func (*QueryRequest) ProtoMessage()               {}
-func (*QueryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*QueryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *QueryRequest) GetSession() *Session {
 	if m != nil {
@@ -970,7 +1003,7 @@ type QueryResponse struct {
 func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
 func (m *QueryResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()               {}
-func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *QueryResponse) GetColumn() []*Param {
 	if m != nil {
@@ -993,7 +1026,7 @@ type QueryResultRow struct {
 func (m *QueryResultRow) Reset()                    { *m = QueryResultRow{} }
 func (m *QueryResultRow) String() string            { return proto.CompactTextString(m) }
 func (*QueryResultRow) ProtoMessage()               {}
-func (*QueryResultRow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*QueryResultRow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *QueryResultRow) GetValues() []*QueryResultValue {
 	if m != nil {
@@ -1009,7 +1042,7 @@ type QueryResultValue struct {
 func (m *QueryResultValue) Reset()                    { *m = QueryResultValue{} }
 func (m *QueryResultValue) String() string            { return proto.CompactTextString(m) }
 func (*QueryResultValue) ProtoMessage()               {}
-func (*QueryResultValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*QueryResultValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *QueryResultValue) GetValue() string {
 	if m != nil {
@@ -1026,7 +1059,7 @@ type ExplainSQL struct {
 func (m *ExplainSQL) Reset()                    { *m = ExplainSQL{} }
 func (m *ExplainSQL) String() string            { return proto.CompactTextString(m) }
 func (*ExplainSQL) ProtoMessage()               {}
-func (*ExplainSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*ExplainSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *ExplainSQL) GetQuery() string {
 	if m != nil {
@@ -1043,7 +1076,7 @@ type ExplainRequest struct {
 func (m *ExplainRequest) Reset()                    { *m = ExplainRequest{} }
 func (m *ExplainRequest) String() string            { return proto.CompactTextString(m) }
 func (*ExplainRequest) ProtoMessage()               {}
-func (*ExplainRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*ExplainRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 func (m *ExplainRequest) GetSession() *Session {
 	if m != nil {
@@ -1066,7 +1099,7 @@ type ExplainResponse struct {
 func (m *ExplainResponse) Reset()                    { *m = ExplainResponse{} }
 func (m *ExplainResponse) String() string            { return proto.CompactTextString(m) }
 func (*ExplainResponse) ProtoMessage()               {}
-func (*ExplainResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*ExplainResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *ExplainResponse) GetClassicResult() *ExplainClassicResult {
 	if m != nil {
@@ -1082,7 +1115,7 @@ type ExplainClassicResult struct {
 func (m *ExplainClassicResult) Reset()                    { *m = ExplainClassicResult{} }
 func (m *ExplainClassicResult) String() string            { return proto.CompactTextString(m) }
 func (*ExplainClassicResult) ProtoMessage()               {}
-func (*ExplainClassicResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*ExplainClassicResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 func (m *ExplainClassicResult) GetData() *TabularData {
 	if m != nil {
@@ -1099,7 +1132,7 @@ type GetDatabasesRequest struct {
 func (m *GetDatabasesRequest) Reset()                    { *m = GetDatabasesRequest{} }
 func (m *GetDatabasesRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetDatabasesRequest) ProtoMessage()               {}
-func (*GetDatabasesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*GetDatabasesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
 func (m *GetDatabasesRequest) GetSession() *Session {
 	if m != nil {
@@ -1115,7 +1148,7 @@ type Database struct {
 func (m *Database) Reset()                    { *m = Database{} }
 func (m *Database) String() string            { return proto.CompactTextString(m) }
 func (*Database) ProtoMessage()               {}
-func (*Database) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*Database) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
 
 func (m *Database) GetName() string {
 	if m != nil {
@@ -1131,7 +1164,7 @@ type GetDatabasesResponse struct {
 func (m *GetDatabasesResponse) Reset()                    { *m = GetDatabasesResponse{} }
 func (m *GetDatabasesResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetDatabasesResponse) ProtoMessage()               {}
-func (*GetDatabasesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*GetDatabasesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 func (m *GetDatabasesResponse) GetDatabases() []*Database {
 	if m != nil {
@@ -1149,7 +1182,7 @@ type Table struct {
 func (m *Table) Reset()                    { *m = Table{} }
 func (m *Table) String() string            { return proto.CompactTextString(m) }
 func (*Table) ProtoMessage()               {}
-func (*Table) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*Table) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 func (m *Table) GetName() string {
 	if m != nil {
@@ -1173,7 +1206,7 @@ type GetTableMetaRequest struct {
 func (m *GetTableMetaRequest) Reset()                    { *m = GetTableMetaRequest{} }
 func (m *GetTableMetaRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetTableMetaRequest) ProtoMessage()               {}
-func (*GetTableMetaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*GetTableMetaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
 func (m *GetTableMetaRequest) GetSession() *Session {
 	if m != nil {
@@ -1196,7 +1229,7 @@ type GetTableMetaResponse struct {
 func (m *GetTableMetaResponse) Reset()                    { *m = GetTableMetaResponse{} }
 func (m *GetTableMetaResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetTableMetaResponse) ProtoMessage()               {}
-func (*GetTableMetaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*GetTableMetaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 func (m *GetTableMetaResponse) GetTableMeta() *TableMeta {
 	if m != nil {
@@ -1215,7 +1248,7 @@ type TableMeta struct {
 func (m *TableMeta) Reset()                    { *m = TableMeta{} }
 func (m *TableMeta) String() string            { return proto.CompactTextString(m) }
 func (*TableMeta) ProtoMessage()               {}
-func (*TableMeta) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*TableMeta) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
 
 func (m *TableMeta) GetColumnsInfo() *ColumnsInfo {
 	if m != nil {
@@ -1252,7 +1285,7 @@ type ColumnsInfo struct {
 func (m *ColumnsInfo) Reset()                    { *m = ColumnsInfo{} }
 func (m *ColumnsInfo) String() string            { return proto.CompactTextString(m) }
 func (*ColumnsInfo) ProtoMessage()               {}
-func (*ColumnsInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*ColumnsInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
 func (m *ColumnsInfo) GetData() *TabularData {
 	if m != nil {
@@ -1268,7 +1301,7 @@ type IndexesInfo struct {
 func (m *IndexesInfo) Reset()                    { *m = IndexesInfo{} }
 func (m *IndexesInfo) String() string            { return proto.CompactTextString(m) }
 func (*IndexesInfo) ProtoMessage()               {}
-func (*IndexesInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*IndexesInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
 func (m *IndexesInfo) GetData() *TabularData {
 	if m != nil {
@@ -1285,7 +1318,7 @@ type TabularDataHead struct {
 func (m *TabularDataHead) Reset()                    { *m = TabularDataHead{} }
 func (m *TabularDataHead) String() string            { return proto.CompactTextString(m) }
 func (*TabularDataHead) ProtoMessage()               {}
-func (*TabularDataHead) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*TabularDataHead) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
 
 func (m *TabularDataHead) GetName() string {
 	if m != nil {
@@ -1308,7 +1341,7 @@ type TabularDataRows struct {
 func (m *TabularDataRows) Reset()                    { *m = TabularDataRows{} }
 func (m *TabularDataRows) String() string            { return proto.CompactTextString(m) }
 func (*TabularDataRows) ProtoMessage()               {}
-func (*TabularDataRows) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+func (*TabularDataRows) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
 
 func (m *TabularDataRows) GetItems() []string {
 	if m != nil {
@@ -1325,7 +1358,7 @@ type TabularData struct {
 func (m *TabularData) Reset()                    { *m = TabularData{} }
 func (m *TabularData) String() string            { return proto.CompactTextString(m) }
 func (*TabularData) ProtoMessage()               {}
-func (*TabularData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+func (*TabularData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
 
 func (m *TabularData) GetColumns() []*TabularDataHead {
 	if m != nil {
@@ -1349,7 +1382,7 @@ type ExtractedSQL struct {
 func (m *ExtractedSQL) Reset()                    { *m = ExtractedSQL{} }
 func (m *ExtractedSQL) String() string            { return proto.CompactTextString(m) }
 func (*ExtractedSQL) ProtoMessage()               {}
-func (*ExtractedSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+func (*ExtractedSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
 
 func (m *ExtractedSQL) GetQuery() string {
 	if m != nil {
@@ -1366,7 +1399,7 @@ type ExtractTableFromSQLRequest struct {
 func (m *ExtractTableFromSQLRequest) Reset()                    { *m = ExtractTableFromSQLRequest{} }
 func (m *ExtractTableFromSQLRequest) String() string            { return proto.CompactTextString(m) }
 func (*ExtractTableFromSQLRequest) ProtoMessage()               {}
-func (*ExtractTableFromSQLRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+func (*ExtractTableFromSQLRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
 
 func (m *ExtractTableFromSQLRequest) GetSession() *Session {
 	if m != nil {
@@ -1389,7 +1422,7 @@ type ExtractTableFromSQLResponse struct {
 func (m *ExtractTableFromSQLResponse) Reset()                    { *m = ExtractTableFromSQLResponse{} }
 func (m *ExtractTableFromSQLResponse) String() string            { return proto.CompactTextString(m) }
 func (*ExtractTableFromSQLResponse) ProtoMessage()               {}
-func (*ExtractTableFromSQLResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+func (*ExtractTableFromSQLResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
 
 func (m *ExtractTableFromSQLResponse) GetTables() []*Table {
 	if m != nil {
@@ -1406,7 +1439,7 @@ type AffectRowsSQL struct {
 func (m *AffectRowsSQL) Reset()                    { *m = AffectRowsSQL{} }
 func (m *AffectRowsSQL) String() string            { return proto.CompactTextString(m) }
 func (*AffectRowsSQL) ProtoMessage()               {}
-func (*AffectRowsSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+func (*AffectRowsSQL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
 
 func (m *AffectRowsSQL) GetQuery() string {
 	if m != nil {
@@ -1423,7 +1456,7 @@ type EstimateSQLAffectRowsRequest struct {
 func (m *EstimateSQLAffectRowsRequest) Reset()                    { *m = EstimateSQLAffectRowsRequest{} }
 func (m *EstimateSQLAffectRowsRequest) String() string            { return proto.CompactTextString(m) }
 func (*EstimateSQLAffectRowsRequest) ProtoMessage()               {}
-func (*EstimateSQLAffectRowsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+func (*EstimateSQLAffectRowsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
 
 func (m *EstimateSQLAffectRowsRequest) GetSession() *Session {
 	if m != nil {
@@ -1447,7 +1480,7 @@ type EstimateSQLAffectRowsResponse struct {
 func (m *EstimateSQLAffectRowsResponse) Reset()                    { *m = EstimateSQLAffectRowsResponse{} }
 func (m *EstimateSQLAffectRowsResponse) String() string            { return proto.CompactTextString(m) }
 func (*EstimateSQLAffectRowsResponse) ProtoMessage()               {}
-func (*EstimateSQLAffectRowsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+func (*EstimateSQLAffectRowsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
 
 func (m *EstimateSQLAffectRowsResponse) GetCount() int64 {
 	if m != nil {
@@ -1470,7 +1503,7 @@ type KillProcessResponse struct {
 func (m *KillProcessResponse) Reset()                    { *m = KillProcessResponse{} }
 func (m *KillProcessResponse) String() string            { return proto.CompactTextString(m) }
 func (*KillProcessResponse) ProtoMessage()               {}
-func (*KillProcessResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+func (*KillProcessResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
 
 func (m *KillProcessResponse) GetErrMessage() string {
 	if m != nil {
@@ -1497,6 +1530,7 @@ func init() {
 	proto.RegisterType((*ParseResponse)(nil), "protoV2.ParseResponse")
 	proto.RegisterType((*AuditSQL)(nil), "protoV2.AuditSQL")
 	proto.RegisterType((*AuditRequest)(nil), "protoV2.AuditRequest")
+	proto.RegisterType((*FixSuggestion)(nil), "protoV2.FixSuggestion")
 	proto.RegisterType((*AuditResult)(nil), "protoV2.AuditResult")
 	proto.RegisterType((*AuditResults)(nil), "protoV2.AuditResults")
 	proto.RegisterType((*AuditResponse)(nil), "protoV2.AuditResponse")
@@ -1566,6 +1600,7 @@ type DriverClient interface {
 	// db audit
 	Parse(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (*ParseResponse, error)
 	Audit(ctx context.Context, in *AuditRequest, opts ...grpc.CallOption) (*AuditResponse, error)
+	AuditStream(ctx context.Context, opts ...grpc.CallOption) (Driver_AuditStreamClient, error)
 	GenRollbackSQL(ctx context.Context, in *GenRollbackSQLRequest, opts ...grpc.CallOption) (*GenRollbackSQLResponse, error)
 	// db executor
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*Empty, error)
@@ -1642,6 +1677,37 @@ func (c *driverClient) Audit(ctx context.Context, in *AuditRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *driverClient) AuditStream(ctx context.Context, opts ...grpc.CallOption) (Driver_AuditStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Driver_serviceDesc.Streams[0], c.cc, "/protoV2.Driver/AuditStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &driverAuditStreamClient{stream}
+	return x, nil
+}
+
+type Driver_AuditStreamClient interface {
+	Send(*AuditRequest) error
+	Recv() (*AuditResponse, error)
+	grpc.ClientStream
+}
+
+type driverAuditStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *driverAuditStreamClient) Send(m *AuditRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *driverAuditStreamClient) Recv() (*AuditResponse, error) {
+	m := new(AuditResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *driverClient) GenRollbackSQL(ctx context.Context, in *GenRollbackSQLRequest, opts ...grpc.CallOption) (*GenRollbackSQLResponse, error) {
 	out := new(GenRollbackSQLResponse)
 	err := grpc.Invoke(ctx, "/protoV2.Driver/GenRollbackSQL", in, out, c.cc, opts...)
@@ -1747,6 +1813,7 @@ type DriverServer interface {
 	// db audit
 	Parse(context.Context, *ParseRequest) (*ParseResponse, error)
 	Audit(context.Context, *AuditRequest) (*AuditResponse, error)
+	AuditStream(Driver_AuditStreamServer) error
 	GenRollbackSQL(context.Context, *GenRollbackSQLRequest) (*GenRollbackSQLResponse, error)
 	// db executor
 	Ping(context.Context, *PingRequest) (*Empty, error)
@@ -1873,6 +1940,32 @@ func _Driver_Audit_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_AuditStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DriverServer).AuditStream(&driverAuditStreamServer{stream})
+}
+
+type Driver_AuditStreamServer interface {
+	Send(*AuditResponse) error
+	Recv() (*AuditRequest, error)
+	grpc.ServerStream
+}
+
+type driverAuditStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *driverAuditStreamServer) Send(m *AuditResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *driverAuditStreamServer) Recv() (*AuditRequest, error) {
+	m := new(AuditRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Driver_GenRollbackSQL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenRollbackSQLRequest)
 	if err := dec(in); err != nil {
@@ -2129,124 +2222,128 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("driver_v2.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1893 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xeb, 0x72, 0x14, 0xb9,
-	0x15, 0xce, 0x5c, 0xed, 0x39, 0x73, 0xf1, 0xac, 0x6c, 0xc3, 0x30, 0x80, 0xd7, 0x11, 0xe0, 0x75,
-	0xb1, 0xc4, 0xb0, 0x26, 0x61, 0x8b, 0x25, 0x49, 0x41, 0x6c, 0x07, 0xbc, 0x80, 0x63, 0x64, 0x42,
-	0xaa, 0xb6, 0x6a, 0x6b, 0x57, 0x9e, 0x96, 0x67, 0xbb, 0xe8, 0x69, 0x8d, 0x5b, 0x1a, 0x6c, 0x9e,
-	0x20, 0x0f, 0x90, 0x1f, 0x79, 0x8d, 0xfc, 0xd9, 0x9f, 0x79, 0x91, 0x3c, 0x4d, 0x4a, 0x97, 0xee,
-	0x56, 0x5f, 0x66, 0x2f, 0xf3, 0xab, 0xa5, 0x73, 0xd3, 0xa7, 0x73, 0x8e, 0x8e, 0x8e, 0x1a, 0x56,
-	0xbc, 0xc8, 0xff, 0xc0, 0xa2, 0xef, 0x3e, 0xec, 0xee, 0x4c, 0x23, 0x2e, 0x39, 0x5a, 0xd2, 0x9f,
-	0x77, 0xbb, 0x78, 0x09, 0x1a, 0x07, 0x93, 0xa9, 0xfc, 0x88, 0xaf, 0xc1, 0xd2, 0x09, 0x13, 0xc2,
-	0xe7, 0x21, 0xea, 0x41, 0xd5, 0xf7, 0x06, 0x95, 0xcd, 0xca, 0x76, 0x8b, 0x54, 0x7d, 0x0f, 0xff,
-	0x03, 0x1a, 0xc7, 0x34, 0xa2, 0x13, 0xd4, 0x87, 0xda, 0x7b, 0xf6, 0xd1, 0x72, 0xd4, 0x10, 0xad,
-	0x41, 0xe3, 0x03, 0x0d, 0x66, 0x6c, 0x50, 0xd5, 0x34, 0x33, 0x41, 0x08, 0xea, 0x1e, 0x13, 0xa3,
-	0x41, 0x4d, 0x13, 0xf5, 0x58, 0xd1, 0xe4, 0xc7, 0x29, 0x1b, 0xd4, 0x0d, 0x4d, 0x8d, 0xf1, 0x8f,
-	0x15, 0xa8, 0xed, 0x9f, 0x1c, 0x29, 0xde, 0x0f, 0x5c, 0x48, 0x6b, 0x58, 0x8f, 0x15, 0x6d, 0xca,
-	0x23, 0x69, 0x0d, 0xeb, 0xb1, 0xa2, 0xcd, 0x04, 0x8b, 0x62, 0xbb, 0x6a, 0x8c, 0x86, 0xb0, 0x3c,
-	0xa5, 0x42, 0x5c, 0xf0, 0xc8, 0xb3, 0xb6, 0x93, 0xb9, 0xe2, 0x79, 0x54, 0xd2, 0x53, 0x2a, 0xd8,
-	0xa0, 0x61, 0x78, 0xf1, 0x1c, 0x7d, 0x05, 0x7d, 0xea, 0x79, 0xbe, 0xf4, 0x79, 0x48, 0x03, 0xbd,
-	0x3d, 0x31, 0x68, 0x6e, 0xd6, 0xb6, 0xdb, 0xbb, 0xbd, 0x1d, 0xeb, 0x9c, 0x1d, 0x4d, 0x26, 0x05,
-	0x39, 0xfc, 0xbf, 0x0a, 0xd4, 0xc9, 0x2c, 0xd0, 0x1b, 0x0d, 0xe9, 0x84, 0xc5, 0xc0, 0xd5, 0x38,
-	0xd9, 0x7c, 0xd5, 0xd9, 0xfc, 0x1a, 0x34, 0x02, 0xf6, 0x81, 0x05, 0x16, 0xb9, 0x99, 0x28, 0x78,
-	0x23, 0x2a, 0xd9, 0x98, 0x47, 0x1f, 0x63, 0xe8, 0xf1, 0x1c, 0x6d, 0x41, 0x73, 0x6a, 0x40, 0x35,
-	0x4a, 0x41, 0x59, 0x2e, 0xda, 0x00, 0xa0, 0x61, 0xc8, 0x25, 0x55, 0x00, 0x07, 0x4d, 0x6d, 0xc5,
-	0xa1, 0xa0, 0x07, 0xd0, 0x7a, 0x1f, 0xf2, 0x8b, 0x80, 0x79, 0x63, 0x36, 0x58, 0xda, 0xac, 0x6c,
-	0xb7, 0x77, 0x51, 0x62, 0xea, 0x65, 0xcc, 0x21, 0xa9, 0x10, 0xbe, 0x03, 0xad, 0x84, 0x8e, 0x06,
-	0xb0, 0x34, 0xe2, 0xa1, 0x64, 0x61, 0x1c, 0x9c, 0x78, 0x8a, 0x7f, 0xac, 0x42, 0xf7, 0x35, 0x93,
-	0x54, 0x10, 0x26, 0xa6, 0x3c, 0x14, 0x4c, 0x41, 0x99, 0x06, 0xb3, 0xb1, 0x1f, 0x1e, 0xa5, 0x2e,
-	0x71, 0x28, 0xe8, 0x01, 0xac, 0xc6, 0xde, 0xdf, 0x67, 0x67, 0x74, 0x16, 0xc8, 0xe3, 0x38, 0xc0,
-	0x35, 0x52, 0xc6, 0x42, 0x5f, 0xc3, 0x20, 0x26, 0x3f, 0xcb, 0xc7, 0xaa, 0x56, 0xea, 0x96, 0xb9,
-	0xf2, 0xe8, 0x16, 0x34, 0xa2, 0x59, 0xc0, 0xc4, 0xa0, 0xae, 0x15, 0xbb, 0x89, 0xa2, 0x0a, 0x24,
-	0x31, 0x3c, 0xf4, 0x1a, 0xd6, 0x59, 0x48, 0x4f, 0x03, 0xe6, 0xfd, 0x6d, 0x6a, 0xb4, 0x5f, 0x73,
-	0x6f, 0x16, 0x30, 0x1d, 0x84, 0xde, 0xee, 0xd5, 0x44, 0x29, 0xcb, 0x26, 0xe5, 0x5a, 0x2a, 0x15,
-	0x02, 0x3e, 0xe6, 0x3a, 0x2c, 0x1d, 0xa2, 0xc7, 0x98, 0x40, 0xfb, 0x30, 0xf4, 0x25, 0x61, 0xe7,
-	0x33, 0x26, 0x24, 0xda, 0x80, 0x9a, 0x27, 0x42, 0xed, 0xad, 0xf6, 0x6e, 0x27, 0xb1, 0xbf, 0x7f,
-	0x72, 0x44, 0x14, 0x23, 0x85, 0x5d, 0x9d, 0x0f, 0x1b, 0x7f, 0x05, 0x1d, 0x63, 0xd3, 0x46, 0xe2,
-	0x2e, 0x2c, 0x09, 0x73, 0x96, 0xad, 0xe1, 0x7e, 0xa2, 0x66, 0xcf, 0x38, 0x89, 0x05, 0x94, 0xee,
-	0x5e, 0xc0, 0x05, 0x8b, 0x01, 0xfd, 0x1a, 0xdd, 0xa7, 0x80, 0x5e, 0xfa, 0x41, 0x70, 0x1c, 0xf1,
-	0x11, 0x13, 0x62, 0x11, 0x0b, 0xbf, 0x85, 0xd6, 0x31, 0x8d, 0x04, 0xf3, 0x4e, 0xde, 0xbc, 0x52,
-	0xa7, 0xe4, 0x7c, 0xc6, 0xa2, 0xb8, 0xc0, 0x98, 0x09, 0xfe, 0x1e, 0x3a, 0x5a, 0x64, 0x01, 0xf3,
-	0xe8, 0x36, 0xd4, 0xc4, 0x79, 0xa0, 0x53, 0xcc, 0xcd, 0xfb, 0x64, 0x49, 0xa2, 0xd8, 0x38, 0x84,
-	0xfa, 0x11, 0xf7, 0x74, 0xb8, 0x24, 0xbb, 0x4c, 0xca, 0x90, 0x1a, 0x27, 0x65, 0xab, 0x9a, 0x96,
-	0x2d, 0xb4, 0x09, 0xed, 0x33, 0x3f, 0x1c, 0xb3, 0x68, 0x1a, 0xf9, 0xa1, 0xb4, 0x67, 0xda, 0x25,
-	0xa1, 0x1b, 0xd0, 0x12, 0x92, 0x46, 0xf2, 0x95, 0x1f, 0x9a, 0x8a, 0x57, 0x27, 0x29, 0x01, 0xff,
-	0x1e, 0xba, 0x76, 0x47, 0x36, 0x5e, 0xb7, 0xa0, 0x11, 0x72, 0x8f, 0x89, 0x41, 0x25, 0x17, 0x64,
-	0x05, 0x8b, 0x18, 0x1e, 0xde, 0x84, 0xe5, 0x67, 0x33, 0xcf, 0x97, 0xf3, 0x3d, 0x45, 0xa1, 0xa3,
-	0x25, 0x16, 0xf1, 0xd4, 0x1d, 0xa8, 0x8b, 0xf3, 0x20, 0x4e, 0xb3, 0x4f, 0x12, 0xc1, 0x78, 0x49,
-	0xa2, 0xd9, 0xf8, 0x1b, 0x68, 0xdb, 0x25, 0xc4, 0x2c, 0x90, 0xaa, 0x3c, 0x4c, 0x98, 0x10, 0x74,
-	0x1c, 0x9f, 0xf7, 0x78, 0x9a, 0x56, 0xbc, 0xaa, 0x5b, 0xf1, 0xae, 0x43, 0x4b, 0x65, 0xec, 0x77,
-	0xba, 0x68, 0x1a, 0xbf, 0x2d, 0x2b, 0x82, 0xaa, 0x0f, 0xf8, 0xcf, 0x09, 0x7c, 0x65, 0x5b, 0xa0,
-	0x1d, 0x58, 0x8a, 0xcc, 0xd0, 0xfa, 0x65, 0x2d, 0x8b, 0xca, 0xc8, 0x91, 0x58, 0x08, 0x7f, 0x0d,
-	0xdd, 0x98, 0x6e, 0xdc, 0xfa, 0x18, 0x3a, 0xd4, 0x31, 0x68, 0xad, 0xac, 0x97, 0x59, 0x11, 0x24,
-	0x23, 0x8a, 0x3f, 0x83, 0x95, 0x23, 0xc6, 0x3c, 0xc2, 0x83, 0xe0, 0x94, 0x8e, 0xde, 0xcf, 0xf7,
-	0x39, 0x87, 0xf5, 0xe7, 0x2c, 0x74, 0xe4, 0x16, 0x71, 0xfe, 0x5d, 0x37, 0x4d, 0x07, 0x69, 0xf4,
-	0xb3, 0x08, 0x4c, 0xb2, 0xfe, 0x09, 0xda, 0x3f, 0x8b, 0xca, 0x8d, 0x4b, 0x35, 0x13, 0x17, 0xfc,
-	0x14, 0xae, 0xe4, 0xf1, 0x5a, 0x6f, 0x6d, 0x19, 0x10, 0x06, 0x6c, 0xea, 0xea, 0x02, 0x80, 0xc7,
-	0xd0, 0x3e, 0xf6, 0xc3, 0xf1, 0x22, 0xa7, 0xfd, 0x53, 0x58, 0x3a, 0xb8, 0x64, 0xa3, 0xf9, 0xde,
-	0xfc, 0x16, 0xda, 0x4a, 0x60, 0x11, 0x1f, 0x62, 0xd7, 0x87, 0xa9, 0x9c, 0x5d, 0xcf, 0x40, 0xff,
-	0x4f, 0x05, 0xc0, 0xd8, 0xd7, 0xd9, 0x8b, 0xa1, 0x13, 0x50, 0x21, 0x0f, 0x43, 0xc1, 0x22, 0x79,
-	0x68, 0x3a, 0x9e, 0x1a, 0xc9, 0xd0, 0xd0, 0x3d, 0xf8, 0xc4, 0x9d, 0x1f, 0x44, 0x11, 0x8f, 0xac,
-	0x4f, 0x8b, 0x0c, 0x65, 0x31, 0xe2, 0x17, 0xe2, 0xd9, 0xd9, 0x19, 0x1b, 0x49, 0xe6, 0xe9, 0x14,
-	0xaf, 0x91, 0x0c, 0x4d, 0x59, 0x74, 0xe7, 0xc6, 0xa2, 0xb9, 0xfe, 0x8b, 0x0c, 0xfc, 0x04, 0x3a,
-	0x16, 0xb1, 0x89, 0xd2, 0xe7, 0xd0, 0x34, 0xf9, 0x6e, 0x3d, 0xb2, 0x9a, 0xd9, 0xa9, 0x3d, 0x12,
-	0x56, 0x04, 0x7f, 0x0b, 0xad, 0xb7, 0x97, 0x8b, 0xd5, 0x4d, 0xb7, 0x1a, 0x14, 0xbd, 0x69, 0x8a,
-	0xc1, 0x13, 0x00, 0x65, 0xde, 0x22, 0xfb, 0x5d, 0xfe, 0xb8, 0x96, 0x42, 0x4b, 0x4e, 0xeb, 0x26,
-	0x2c, 0xbf, 0x51, 0x31, 0x9f, 0x9f, 0x0c, 0x5f, 0x40, 0x4b, 0x4b, 0xec, 0xf1, 0xf0, 0x0c, 0xdd,
-	0x86, 0xae, 0xf4, 0x27, 0x8c, 0xcf, 0xe4, 0x09, 0x1b, 0xf1, 0xd0, 0x04, 0xab, 0x4b, 0xb2, 0x44,
-	0xfc, 0xcf, 0x0a, 0x74, 0xb4, 0xce, 0x22, 0x9b, 0xbe, 0xe5, 0x66, 0x50, 0x5a, 0x01, 0x63, 0x94,
-	0x3a, 0x85, 0xd0, 0x16, 0xd4, 0x47, 0x3c, 0x3c, 0xd3, 0x91, 0x75, 0xaf, 0x94, 0x04, 0x29, 0xd1,
-	0x7c, 0xec, 0x41, 0xd7, 0x02, 0x49, 0x8e, 0x57, 0x73, 0xc4, 0x83, 0xd9, 0x24, 0xb4, 0xde, 0x29,
-	0x34, 0x74, 0x86, 0x8b, 0x3e, 0x87, 0xba, 0xca, 0x02, 0xeb, 0xfa, 0xab, 0xd9, 0x05, 0xac, 0x13,
-	0xf9, 0x05, 0xd1, 0x42, 0x78, 0x0f, 0x7a, 0x59, 0x3a, 0xfa, 0x02, 0x9a, 0xba, 0x07, 0x8f, 0x83,
-	0x70, 0xad, 0xcc, 0xc0, 0x3b, 0x25, 0x41, 0xac, 0x20, 0xde, 0x86, 0x7e, 0x9e, 0x97, 0xf6, 0xf5,
-	0x15, 0xa7, 0xaf, 0xc7, 0x58, 0x1d, 0x9f, 0x69, 0x40, 0xfd, 0x70, 0x7e, 0xd4, 0x46, 0xd0, 0xb3,
-	0x32, 0x8b, 0x5d, 0x43, 0x4e, 0x0c, 0xdc, 0x04, 0x8a, 0x57, 0x35, 0x07, 0xf9, 0x1d, 0xac, 0x24,
-	0x8b, 0x58, 0xff, 0xee, 0x41, 0x77, 0x14, 0x50, 0x21, 0x7c, 0x9b, 0x69, 0x76, 0xad, 0x9b, 0x79,
-	0x1b, 0x7b, 0xae, 0x10, 0xc9, 0xea, 0xe0, 0xa7, 0xb0, 0x56, 0x26, 0x86, 0xb6, 0xa1, 0xae, 0x1a,
-	0xcb, 0x42, 0x71, 0x7c, 0x4b, 0x4f, 0x67, 0x01, 0x8d, 0xf6, 0xa9, 0xa4, 0x44, 0x4b, 0xe0, 0x67,
-	0xb0, 0xfa, 0x9c, 0xc9, 0x7d, 0xdb, 0x85, 0x2e, 0xd4, 0x13, 0x6d, 0xc0, 0x72, 0xac, 0x5f, 0xf6,
-	0xc0, 0xc0, 0xcf, 0x61, 0x2d, 0xbb, 0x84, 0xf5, 0xc0, 0x7d, 0x68, 0xc5, 0xdd, 0x6f, 0x1c, 0xfd,
-	0x34, 0x8b, 0x63, 0x71, 0x92, 0xca, 0xe0, 0x87, 0xd0, 0x78, 0xab, 0xda, 0xd6, 0xd2, 0x67, 0xcc,
-	0x15, 0x68, 0x8a, 0xd1, 0x0f, 0x6c, 0x42, 0x6d, 0xb5, 0xb3, 0x33, 0x3c, 0xd6, 0x1b, 0xd4, 0x7a,
-	0xaa, 0xfd, 0x5f, 0xac, 0xba, 0x34, 0xa4, 0xd2, 0xb7, 0x61, 0xee, 0xb9, 0xee, 0x54, 0x4d, 0xad,
-	0x66, 0xe2, 0x17, 0x7a, 0x9b, 0xce, 0x42, 0x76, 0x9b, 0x0f, 0xa0, 0x25, 0x63, 0xa2, 0x5d, 0x0b,
-	0x65, 0x2d, 0x68, 0xf1, 0x54, 0x08, 0xff, 0xb7, 0x02, 0xad, 0x84, 0x81, 0x1e, 0x41, 0xdb, 0x1c,
-	0x35, 0x71, 0x18, 0x9e, 0xf1, 0x42, 0x48, 0xf7, 0x52, 0x1e, 0x71, 0x05, 0x95, 0x9e, 0x1f, 0x7a,
-	0xec, 0x92, 0x19, 0xbd, 0x6a, 0x4e, 0xef, 0x30, 0xe5, 0x11, 0x57, 0x10, 0x6d, 0x41, 0x6f, 0x14,
-	0x31, 0x2a, 0x99, 0x86, 0x70, 0xf2, 0xe6, 0x95, 0x6d, 0x7c, 0x72, 0x54, 0xf7, 0xce, 0xae, 0x67,
-	0xef, 0xec, 0x2f, 0xa1, 0xed, 0xa0, 0xfa, 0x15, 0xc9, 0xf8, 0xa5, 0x7a, 0x6b, 0xa4, 0x48, 0x7e,
-	0xb9, 0xe2, 0x63, 0x58, 0x71, 0x88, 0x2f, 0x18, 0xf5, 0x7e, 0xe9, 0x53, 0x57, 0x75, 0x4e, 0xae,
-	0x3d, 0x7e, 0x21, 0x54, 0xa1, 0xf0, 0x25, 0x9b, 0x98, 0xa4, 0x6c, 0x11, 0x33, 0xc1, 0x1c, 0xda,
-	0x8e, 0x20, 0xda, 0x55, 0x2f, 0x4d, 0xbd, 0x49, 0x9b, 0xbb, 0x83, 0x32, 0x7c, 0x0a, 0x0a, 0x89,
-	0x05, 0xd1, 0xbd, 0x4c, 0xad, 0x2c, 0x55, 0x50, 0x00, 0x6c, 0xb1, 0xbc, 0xad, 0xae, 0x52, 0x19,
-	0x51, 0x75, 0xb9, 0xce, 0xaf, 0x5f, 0xe7, 0x30, 0xb4, 0x52, 0x3a, 0x32, 0x7f, 0x8d, 0xf8, 0x64,
-	0xc1, 0xae, 0xee, 0x33, 0xb7, 0x96, 0xad, 0x3b, 0x75, 0x28, 0xc5, 0x60, 0xaa, 0xd9, 0x01, 0x5c,
-	0x2f, 0x5d, 0x32, 0xbd, 0x39, 0x74, 0x2e, 0x8b, 0xc2, 0xcd, 0x61, 0xce, 0x8b, 0xe5, 0xe2, 0x3b,
-	0xd0, 0x35, 0xbd, 0x83, 0xda, 0xf3, 0xfc, 0x0d, 0x4a, 0xb8, 0x71, 0x20, 0xa4, 0x3f, 0xa1, 0x52,
-	0xa5, 0x5d, 0xaa, 0xb1, 0xc8, 0x16, 0xb7, 0xdd, 0x2d, 0x5e, 0x49, 0x1b, 0x6b, 0x17, 0x86, 0xd9,
-	0xe3, 0xdf, 0xe1, 0xe6, 0x9c, 0x55, 0xed, 0x2e, 0xd7, 0xa0, 0x31, 0xe2, 0x33, 0xfb, 0x9f, 0xa1,
-	0x46, 0xcc, 0x04, 0x6d, 0x00, 0xb0, 0x28, 0x7a, 0x9d, 0xe9, 0x65, 0x1d, 0x0a, 0xfe, 0x03, 0xac,
-	0x66, 0x5e, 0xa0, 0xe9, 0xaf, 0x08, 0x47, 0xad, 0x92, 0x57, 0xbb, 0xfb, 0xaf, 0x0a, 0xf4, 0x0a,
-	0x6f, 0xf5, 0x5e, 0xb6, 0x31, 0xee, 0xff, 0x06, 0xb5, 0xa0, 0xa1, 0x6f, 0xc6, 0x7e, 0x05, 0xb5,
-	0x55, 0xeb, 0xaa, 0x6f, 0x86, 0x7e, 0x15, 0xf5, 0xa1, 0xe3, 0x96, 0xa6, 0x7e, 0x0d, 0x5d, 0x85,
-	0xd5, 0x92, 0x10, 0xf6, 0xeb, 0xe8, 0x1a, 0xac, 0x97, 0xee, 0xbb, 0xdf, 0x40, 0x2b, 0xd0, 0x76,
-	0xb0, 0xf7, 0x9b, 0xbb, 0xff, 0x5e, 0x86, 0xe6, 0xbe, 0xfe, 0x51, 0x87, 0xee, 0x43, 0x43, 0xff,
-	0x5c, 0x41, 0x69, 0xb0, 0xf5, 0x6f, 0xba, 0x61, 0xea, 0xe4, 0xec, 0xcf, 0x97, 0x87, 0x50, 0x3f,
-	0x0c, 0x7d, 0x89, 0xdc, 0x82, 0x94, 0xbc, 0x04, 0x87, 0xeb, 0x39, 0xaa, 0x55, 0xda, 0x81, 0x86,
-	0x7e, 0xfb, 0xa3, 0x94, 0xef, 0xfe, 0x0b, 0x18, 0xe6, 0x16, 0x47, 0x2f, 0x32, 0x88, 0xd1, 0xf5,
-	0xf4, 0x47, 0x52, 0xe1, 0x2f, 0xc0, 0xf0, 0x46, 0x39, 0xd3, 0xae, 0xfc, 0x48, 0xff, 0x52, 0xcc,
-	0xac, 0xec, 0x3e, 0xf2, 0x9d, 0x6d, 0x66, 0x5f, 0xca, 0x8f, 0xa0, 0xa1, 0x5f, 0x6d, 0xa8, 0xf0,
-	0x8a, 0xcb, 0xeb, 0x65, 0x9f, 0x82, 0x6f, 0xf2, 0xd1, 0x45, 0x1b, 0x89, 0x64, 0xe9, 0xfb, 0x6d,
-	0xf8, 0xe9, 0x5c, 0xbe, 0x35, 0x79, 0x0f, 0xea, 0xea, 0x1d, 0xe4, 0x78, 0xdc, 0x79, 0x16, 0x15,
-	0x5c, 0xf7, 0x10, 0xea, 0xaa, 0x0b, 0x76, 0xa4, 0x9d, 0x87, 0xce, 0x70, 0x3d, 0xdf, 0x2a, 0xc7,
-	0x2d, 0x75, 0xf5, 0xed, 0x25, 0x72, 0x6e, 0xb7, 0xb8, 0x99, 0x1f, 0xae, 0x66, 0x68, 0xa9, 0x73,
-	0x74, 0xba, 0x3a, 0xce, 0x71, 0x9b, 0x61, 0xc7, 0x39, 0xd9, 0xd6, 0xf4, 0x8f, 0x49, 0x6e, 0xa3,
-	0xab, 0xf9, 0x76, 0x29, 0xd6, 0x1d, 0x14, 0x19, 0x56, 0xfb, 0xa5, 0x3e, 0x0c, 0x49, 0x3b, 0x82,
-	0x6e, 0x38, 0x8e, 0x2b, 0x34, 0x42, 0xc3, 0x9b, 0x73, 0xb8, 0x19, 0x63, 0xe9, 0x65, 0x9d, 0x31,
-	0x96, 0x6f, 0x3a, 0xb2, 0xc6, 0x8a, 0x9d, 0xc2, 0xf7, 0xa5, 0x87, 0x12, 0xdd, 0xca, 0x97, 0xe2,
-	0x92, 0x42, 0x3f, 0xbc, 0xfd, 0xd3, 0x42, 0x76, 0x85, 0xb3, 0x39, 0xa7, 0x1b, 0xdd, 0x49, 0xd5,
-	0x7f, 0xa2, 0xd6, 0x0e, 0xb7, 0x7e, 0x4e, 0xcc, 0xac, 0xf3, 0x97, 0xce, 0x37, 0xb0, 0x73, 0xff,
-	0x89, 0x95, 0x3d, 0x6d, 0xea, 0xc1, 0xc3, 0xff, 0x07, 0x00, 0x00, 0xff, 0xff, 0xb3, 0x57, 0xbd,
-	0x7d, 0xd6, 0x17, 0x00, 0x00,
+	// 1968 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x18, 0xdb, 0x72, 0xdb, 0xc6,
+	0xb5, 0xe0, 0x55, 0x3c, 0xbc, 0x88, 0x59, 0x5d, 0x0c, 0xd3, 0x97, 0xa8, 0x2b, 0x5b, 0xd6, 0x38,
+	0xae, 0xec, 0xd0, 0x6d, 0x32, 0x8e, 0x9b, 0x8e, 0x5d, 0x49, 0xb1, 0x15, 0xdb, 0xaa, 0xbc, 0x54,
+	0xdd, 0x99, 0xce, 0x64, 0x1c, 0x88, 0x58, 0x32, 0x18, 0x83, 0x00, 0x85, 0x5d, 0x5a, 0xf2, 0x17,
+	0xf4, 0x03, 0xfa, 0xd4, 0xbf, 0xe8, 0x4b, 0x1e, 0xfb, 0x21, 0xed, 0xd7, 0x74, 0xf6, 0x02, 0x60,
+	0x01, 0x82, 0x89, 0xcd, 0x27, 0x60, 0xcf, 0x6d, 0xcf, 0x7d, 0xcf, 0x2e, 0xac, 0xba, 0x91, 0xf7,
+	0x9e, 0x46, 0x6f, 0xdf, 0xf7, 0xf7, 0xa6, 0x51, 0xc8, 0x43, 0x54, 0x97, 0x9f, 0x37, 0x7d, 0x5c,
+	0x87, 0xea, 0xe1, 0x64, 0xca, 0x3f, 0xe0, 0xab, 0x50, 0x1f, 0x50, 0xc6, 0xbc, 0x30, 0x40, 0x1d,
+	0x28, 0x79, 0xae, 0x6d, 0x6d, 0x59, 0xbb, 0x0d, 0x52, 0xf2, 0x5c, 0xfc, 0x37, 0xa8, 0x9e, 0x38,
+	0x91, 0x33, 0x41, 0x5d, 0x28, 0xbf, 0xa3, 0x1f, 0x34, 0x46, 0xfc, 0xa2, 0x75, 0xa8, 0xbe, 0x77,
+	0xfc, 0x19, 0xb5, 0x4b, 0x12, 0xa6, 0x16, 0x08, 0x41, 0xc5, 0xa5, 0x6c, 0x68, 0x97, 0x25, 0x50,
+	0xfe, 0x0b, 0x18, 0xff, 0x30, 0xa5, 0x76, 0x45, 0xc1, 0xc4, 0x3f, 0xfe, 0xd9, 0x82, 0xf2, 0xc1,
+	0xe0, 0x58, 0xe0, 0x7e, 0x0a, 0x19, 0xd7, 0x82, 0xe5, 0xbf, 0x80, 0x4d, 0xc3, 0x88, 0x6b, 0xc1,
+	0xf2, 0x5f, 0xc0, 0x66, 0x8c, 0x46, 0xb1, 0x5c, 0xf1, 0x8f, 0x7a, 0xb0, 0x32, 0x75, 0x18, 0xbb,
+	0x08, 0x23, 0x57, 0xcb, 0x4e, 0xd6, 0x02, 0xe7, 0x3a, 0xdc, 0x39, 0x73, 0x18, 0xb5, 0xab, 0x0a,
+	0x17, 0xaf, 0xd1, 0x37, 0xd0, 0x75, 0x5c, 0xd7, 0xe3, 0x5e, 0x18, 0x38, 0xbe, 0x34, 0x8f, 0xd9,
+	0xb5, 0xad, 0xf2, 0x6e, 0xb3, 0xdf, 0xd9, 0xd3, 0xce, 0xd9, 0x93, 0x60, 0x32, 0x47, 0x87, 0xff,
+	0x67, 0x41, 0x85, 0xcc, 0x7c, 0x69, 0x68, 0xe0, 0x4c, 0x68, 0xac, 0xb8, 0xf8, 0x4f, 0x8c, 0x2f,
+	0x19, 0xc6, 0xaf, 0x43, 0xd5, 0xa7, 0xef, 0xa9, 0xaf, 0x35, 0x57, 0x0b, 0xa1, 0xde, 0xd0, 0xe1,
+	0x74, 0x1c, 0x46, 0x1f, 0x62, 0xd5, 0xe3, 0x35, 0xda, 0x81, 0xda, 0x54, 0x29, 0x55, 0x2d, 0x54,
+	0x4a, 0x63, 0xd1, 0x4d, 0x00, 0x27, 0x08, 0x42, 0xee, 0x08, 0x05, 0xed, 0x9a, 0x94, 0x62, 0x40,
+	0xd0, 0x03, 0x68, 0xbc, 0x0b, 0xc2, 0x0b, 0x9f, 0xba, 0x63, 0x6a, 0xd7, 0xb7, 0xac, 0xdd, 0x66,
+	0x1f, 0x25, 0xa2, 0x5e, 0xc4, 0x18, 0x92, 0x12, 0xe1, 0xdb, 0xd0, 0x48, 0xe0, 0xc8, 0x86, 0xfa,
+	0x30, 0x0c, 0x38, 0x0d, 0xe2, 0xe0, 0xc4, 0x4b, 0xfc, 0x73, 0x09, 0xda, 0xaf, 0x28, 0x77, 0x18,
+	0xa1, 0x6c, 0x1a, 0x06, 0x8c, 0x0a, 0x55, 0xa6, 0xfe, 0x6c, 0xec, 0x05, 0xc7, 0xa9, 0x4b, 0x0c,
+	0x08, 0x7a, 0x00, 0x6b, 0xb1, 0xf7, 0x0f, 0xe8, 0xc8, 0x99, 0xf9, 0xfc, 0x24, 0x0e, 0x70, 0x99,
+	0x14, 0xa1, 0xd0, 0xf7, 0x60, 0xc7, 0xe0, 0xa7, 0xf9, 0x58, 0x95, 0x0b, 0xdd, 0xb2, 0x90, 0x1e,
+	0x6d, 0x43, 0x35, 0x9a, 0xf9, 0x94, 0xd9, 0x15, 0xc9, 0xd8, 0x4e, 0x18, 0x45, 0x20, 0x89, 0xc2,
+	0xa1, 0x57, 0xb0, 0x41, 0x03, 0xe7, 0xcc, 0xa7, 0xee, 0x5f, 0xa6, 0x8a, 0xfb, 0x55, 0xe8, 0xce,
+	0x7c, 0x2a, 0x83, 0xd0, 0xe9, 0x5f, 0x49, 0x98, 0xb2, 0x68, 0x52, 0xcc, 0x25, 0x52, 0xc1, 0x0f,
+	0xc7, 0xa1, 0x0c, 0x4b, 0x8b, 0xc8, 0x7f, 0x4c, 0xa0, 0x79, 0x14, 0x78, 0x9c, 0xd0, 0xf3, 0x19,
+	0x65, 0x1c, 0xdd, 0x84, 0xb2, 0xcb, 0x02, 0xe9, 0xad, 0x66, 0xbf, 0x95, 0xc8, 0x3f, 0x18, 0x1c,
+	0x13, 0x81, 0x48, 0xd5, 0x2e, 0x2d, 0x56, 0x1b, 0x7f, 0x03, 0x2d, 0x25, 0x53, 0x47, 0xe2, 0x2e,
+	0xd4, 0x99, 0xaa, 0x65, 0x2d, 0xb8, 0x9b, 0xb0, 0xe9, 0x1a, 0x27, 0x31, 0x81, 0xe0, 0xdd, 0xf7,
+	0x43, 0x46, 0x63, 0x85, 0x3e, 0x85, 0xf7, 0x09, 0xa0, 0x17, 0x9e, 0xef, 0x9f, 0x44, 0xe1, 0x90,
+	0x32, 0xb6, 0x8c, 0x84, 0xdf, 0x42, 0xe3, 0xc4, 0x89, 0x18, 0x75, 0x07, 0xaf, 0x5f, 0x8a, 0x2a,
+	0x39, 0x9f, 0xd1, 0x28, 0x6e, 0x30, 0x6a, 0x81, 0x7f, 0x84, 0x96, 0x24, 0x59, 0x42, 0x3c, 0xba,
+	0x05, 0x65, 0x76, 0xee, 0xdb, 0xa5, 0x5c, 0xde, 0x27, 0x5b, 0x12, 0x81, 0xc6, 0x01, 0x54, 0x8e,
+	0x43, 0x57, 0x86, 0x8b, 0xd3, 0xcb, 0xa4, 0x0d, 0x89, 0xff, 0xa4, 0x6d, 0x95, 0xd2, 0xb6, 0x85,
+	0xb6, 0xa0, 0x39, 0xf2, 0x82, 0x31, 0x8d, 0xa6, 0x91, 0x17, 0x70, 0x5d, 0xd3, 0x26, 0x08, 0x5d,
+	0x87, 0x06, 0xe3, 0x4e, 0xc4, 0x5f, 0x7a, 0x81, 0xea, 0x78, 0x15, 0x92, 0x02, 0xf0, 0xef, 0xa1,
+	0xad, 0x2d, 0xd2, 0xf1, 0xda, 0x86, 0x6a, 0x10, 0xba, 0x94, 0xd9, 0x56, 0x2e, 0xc8, 0x42, 0x2d,
+	0xa2, 0x70, 0x78, 0x0b, 0x56, 0x9e, 0xce, 0x5c, 0x8f, 0x2f, 0xf6, 0x94, 0x03, 0x2d, 0x49, 0xb1,
+	0x8c, 0xa7, 0x6e, 0x43, 0x85, 0x9d, 0xfb, 0x71, 0x9a, 0x7d, 0x96, 0x10, 0xc6, 0x5b, 0x12, 0x89,
+	0xc6, 0x6f, 0xa0, 0xfd, 0x9d, 0x77, 0x39, 0x98, 0x8d, 0xc7, 0x94, 0xc9, 0xfe, 0xb2, 0x05, 0x4d,
+	0xd1, 0xe1, 0x22, 0x6f, 0xca, 0xe3, 0x7d, 0x1a, 0xc4, 0x04, 0xa1, 0x6d, 0x68, 0x47, 0xf4, 0x22,
+	0xf2, 0x38, 0xa7, 0xc1, 0xdb, 0x38, 0x1a, 0x0d, 0xd2, 0x4a, 0x80, 0x83, 0x73, 0x1f, 0xff, 0xcb,
+	0x82, 0xa6, 0xd6, 0x9d, 0xcd, 0x7c, 0x2e, 0xfa, 0xce, 0x84, 0x32, 0xe6, 0x8c, 0xe3, 0x46, 0x12,
+	0x2f, 0xd3, 0x56, 0x5a, 0x32, 0x5b, 0xe9, 0x35, 0x68, 0x88, 0x52, 0x78, 0x2b, 0xbb, 0xb1, 0x0a,
+	0xc8, 0x8a, 0x00, 0xc8, 0xc6, 0xf3, 0x2d, 0x74, 0x46, 0xde, 0xe5, 0x5b, 0x96, 0x68, 0x2d, 0x43,
+	0xd2, 0xec, 0x6f, 0x26, 0x56, 0x66, 0x6c, 0x22, 0xed, 0x91, 0xb9, 0xc4, 0x7f, 0x82, 0x96, 0xa1,
+	0x1a, 0x43, 0x7b, 0x50, 0x8f, 0xd4, 0xaf, 0x8e, 0xd7, 0x7a, 0xd6, 0x5b, 0x8a, 0x8e, 0xc4, 0x44,
+	0xf8, 0x7b, 0x68, 0xc7, 0x70, 0x15, 0xee, 0x47, 0xd0, 0x72, 0x0c, 0x81, 0x5a, 0xca, 0x46, 0x91,
+	0x14, 0x46, 0x32, 0xa4, 0xf8, 0x0e, 0xac, 0x1e, 0x53, 0xea, 0x92, 0xd0, 0xf7, 0xcf, 0x9c, 0xe1,
+	0xbb, 0xc5, 0xb9, 0x10, 0xc2, 0xc6, 0x33, 0x1a, 0x18, 0x74, 0xcb, 0x24, 0xc5, 0x5d, 0xb3, 0x7c,
+	0xec, 0x34, 0x2b, 0xb3, 0x1a, 0xa8, 0x22, 0xfa, 0x16, 0x9a, 0xbf, 0xaa, 0x95, 0x19, 0xd6, 0x52,
+	0x26, 0xac, 0xf8, 0x09, 0x6c, 0xe6, 0xf5, 0xd5, 0xde, 0xda, 0x51, 0x4a, 0x28, 0x65, 0x53, 0x57,
+	0xcf, 0x29, 0xf0, 0x08, 0x9a, 0x27, 0x5e, 0x30, 0x5e, 0xa6, 0x0b, 0x7d, 0x0e, 0xf5, 0xc3, 0x4b,
+	0x3a, 0x5c, 0xec, 0xcd, 0x1f, 0xa0, 0x29, 0x08, 0x96, 0xf1, 0x21, 0x36, 0x7d, 0x98, 0xd2, 0xe9,
+	0xfd, 0x94, 0xea, 0xff, 0xb6, 0x00, 0x94, 0x7c, 0x99, 0xfc, 0x18, 0x5a, 0xbe, 0xc3, 0xf8, 0x51,
+	0xc0, 0x68, 0xc4, 0x8f, 0xd4, 0x24, 0x56, 0x26, 0x19, 0x18, 0xba, 0x07, 0x9f, 0x99, 0xeb, 0xc3,
+	0x28, 0x0a, 0x23, 0xed, 0xd3, 0x79, 0x84, 0x90, 0x18, 0x85, 0x17, 0xec, 0xe9, 0x68, 0x44, 0x87,
+	0x9c, 0xba, 0xb2, 0x42, 0xca, 0x24, 0x03, 0x13, 0x12, 0xcd, 0xb5, 0x92, 0xa8, 0xc6, 0x92, 0x79,
+	0x04, 0x7e, 0x0c, 0x2d, 0xad, 0xb1, 0x8a, 0xd2, 0x17, 0x50, 0x53, 0xf9, 0xae, 0x3d, 0xb2, 0x96,
+	0xb1, 0x54, 0x97, 0x84, 0x26, 0xc1, 0x3f, 0x40, 0xe3, 0xf4, 0x72, 0xb9, 0x7e, 0x6e, 0x76, 0xa9,
+	0x79, 0x6f, 0xaa, 0x26, 0xf5, 0x18, 0xe0, 0xf4, 0x32, 0xd1, 0xec, 0x77, 0xf9, 0x72, 0x2d, 0x54,
+	0x2d, 0xa9, 0xd6, 0x2d, 0x58, 0x79, 0x2d, 0x62, 0xbe, 0x38, 0x19, 0xbe, 0x84, 0x86, 0xa4, 0xd8,
+	0x0f, 0x83, 0x11, 0xba, 0x05, 0x6d, 0xee, 0x4d, 0x68, 0x38, 0xe3, 0x03, 0x3a, 0x0c, 0x03, 0x15,
+	0xac, 0x36, 0xc9, 0x02, 0xf1, 0x3f, 0x2c, 0x68, 0x49, 0x9e, 0x65, 0x8c, 0xde, 0x36, 0x33, 0x28,
+	0xed, 0xcc, 0xb1, 0x96, 0x32, 0x85, 0xd0, 0x0e, 0x54, 0x86, 0x61, 0x30, 0xb2, 0xcb, 0xb9, 0xa3,
+	0x2e, 0xd1, 0x94, 0x48, 0x3c, 0x76, 0xa1, 0xad, 0x15, 0x49, 0xca, 0xab, 0x36, 0x0c, 0xfd, 0xd9,
+	0x24, 0xb0, 0xad, 0xc2, 0x89, 0x4a, 0x63, 0xd1, 0x17, 0x50, 0x11, 0x59, 0xa0, 0x5d, 0x7f, 0x25,
+	0xbb, 0x81, 0x76, 0x62, 0x78, 0x41, 0x24, 0x11, 0xde, 0x87, 0x4e, 0x16, 0x8e, 0xbe, 0x84, 0x9a,
+	0xbc, 0x1b, 0xc4, 0x41, 0xb8, 0x5a, 0x24, 0xe0, 0x8d, 0xa0, 0x20, 0x9a, 0x10, 0xef, 0x42, 0x37,
+	0x8f, 0x4b, 0xef, 0x1b, 0x96, 0x71, 0xdf, 0xc0, 0x58, 0x94, 0xcf, 0xd4, 0x77, 0xbc, 0x60, 0x71,
+	0xd4, 0x86, 0xd0, 0xd1, 0x34, 0xcb, 0x1d, 0x8f, 0x46, 0x0c, 0xcc, 0x04, 0x8a, 0x77, 0x55, 0x85,
+	0xfc, 0x06, 0x56, 0x93, 0x4d, 0xb4, 0x7f, 0xf7, 0xa1, 0x3d, 0xf4, 0x1d, 0xc6, 0x3c, 0x9d, 0x69,
+	0x7a, 0xaf, 0x1b, 0x79, 0x19, 0xfb, 0x26, 0x11, 0xc9, 0xf2, 0xe0, 0x27, 0xb0, 0x5e, 0x44, 0x86,
+	0x76, 0xa1, 0x22, 0x06, 0xde, 0xb9, 0xe6, 0x78, 0xea, 0x9c, 0xcd, 0x7c, 0x27, 0x3a, 0x70, 0xb8,
+	0x43, 0x24, 0x05, 0x7e, 0x0a, 0x6b, 0xcf, 0x28, 0x3f, 0xd0, 0xd3, 0xf1, 0x52, 0xb3, 0xda, 0x4d,
+	0x58, 0x89, 0xf9, 0x8b, 0x2e, 0x3e, 0xf8, 0x19, 0xac, 0x67, 0xb7, 0xd0, 0x1e, 0xb8, 0x0f, 0x8d,
+	0x78, 0x2a, 0x8f, 0xa3, 0x9f, 0x66, 0x71, 0x4c, 0x4e, 0x52, 0x1a, 0xfc, 0x10, 0xaa, 0xa7, 0x62,
+	0x9c, 0x2e, 0xda, 0x05, 0x6d, 0x42, 0x8d, 0x0d, 0x7f, 0xa2, 0x13, 0x47, 0x77, 0x3b, 0xbd, 0xc2,
+	0x63, 0x69, 0xa0, 0xe4, 0x13, 0xd7, 0x92, 0xe5, 0xba, 0x4b, 0x95, 0x0b, 0x7e, 0x1d, 0xe6, 0x8e,
+	0xe9, 0x4e, 0x31, 0x6c, 0x4b, 0x24, 0x7e, 0x2e, 0xcd, 0x34, 0x36, 0xd2, 0x66, 0x3e, 0x80, 0x06,
+	0x8f, 0x81, 0xb6, 0x95, 0x2b, 0xc3, 0x94, 0x3c, 0x25, 0xc2, 0xff, 0xb1, 0xa0, 0x91, 0x20, 0xd0,
+	0x57, 0xd0, 0x54, 0xa5, 0xc6, 0x8e, 0x82, 0x51, 0x38, 0x17, 0xd2, 0xfd, 0x14, 0x47, 0x4c, 0x42,
+	0xc1, 0xe7, 0x05, 0x2e, 0xbd, 0xa4, 0x8a, 0xaf, 0x94, 0xe3, 0x3b, 0x4a, 0x71, 0xc4, 0x24, 0x44,
+	0x3b, 0xd0, 0x19, 0x46, 0xd4, 0xe1, 0x54, 0xaa, 0x30, 0x78, 0xfd, 0x52, 0xcf, 0x4d, 0x39, 0xa8,
+	0x79, 0x66, 0x57, 0xb2, 0x67, 0xf6, 0xd7, 0xd0, 0x34, 0xb4, 0xfa, 0x84, 0x64, 0xfc, 0x5a, 0xdc,
+	0x81, 0x52, 0x4d, 0x3e, 0x9e, 0xf1, 0x11, 0xac, 0x1a, 0xc0, 0xe7, 0xd4, 0x71, 0x3f, 0xf6, 0x0a,
+	0x8e, 0xef, 0x64, 0x58, 0x49, 0x78, 0xc1, 0x44, 0xa3, 0xf0, 0x38, 0x9d, 0xa8, 0xa4, 0x6c, 0x10,
+	0xb5, 0xc0, 0x21, 0x34, 0x0d, 0x42, 0xd4, 0x17, 0x37, 0x60, 0x69, 0xa4, 0xce, 0x5d, 0xbb, 0x48,
+	0x3f, 0xa1, 0x0a, 0x89, 0x09, 0xd1, 0xbd, 0x4c, 0xaf, 0x2c, 0x64, 0x10, 0x0a, 0xe8, 0x66, 0x79,
+	0x4b, 0x1c, 0xa5, 0x3c, 0x72, 0xc4, 0xe1, 0xba, 0xb8, 0x7f, 0x9d, 0x43, 0x4f, 0x53, 0xc9, 0xc8,
+	0x7c, 0x17, 0x85, 0x93, 0x25, 0xa7, 0xba, 0x3b, 0x66, 0x2f, 0xdb, 0x30, 0xfa, 0x50, 0xaa, 0x83,
+	0xea, 0x66, 0x87, 0x70, 0xad, 0x70, 0xcb, 0xf4, 0xe4, 0x90, 0xb9, 0xcc, 0xe6, 0x4e, 0x0e, 0x55,
+	0x2f, 0x1a, 0x8b, 0x6f, 0x43, 0x5b, 0xcd, 0x0e, 0xc2, 0xe6, 0xc5, 0x06, 0x72, 0xb8, 0x7e, 0xc8,
+	0xb8, 0x37, 0x71, 0xb8, 0x48, 0xbb, 0x94, 0x63, 0x19, 0x13, 0x77, 0x4d, 0x13, 0xd3, 0x31, 0x3f,
+	0xa3, 0x86, 0xb2, 0xf1, 0xaf, 0x70, 0x63, 0xc1, 0xae, 0xda, 0xca, 0x75, 0xa8, 0x0e, 0xc3, 0x99,
+	0x7e, 0xff, 0x28, 0x13, 0xb5, 0x10, 0x6f, 0x1d, 0x34, 0x8a, 0x5e, 0x65, 0x66, 0x59, 0x03, 0x82,
+	0xff, 0x00, 0x6b, 0x99, 0x9b, 0x71, 0xfa, 0x44, 0x62, 0xb0, 0x59, 0x79, 0xb6, 0xbb, 0xff, 0xb4,
+	0xa0, 0x33, 0xf7, 0x86, 0xd0, 0xc9, 0x0e, 0xc6, 0xdd, 0xdf, 0xa0, 0x06, 0x54, 0xe5, 0xc9, 0xd8,
+	0xb5, 0x50, 0x13, 0xea, 0xfa, 0x64, 0xe8, 0x96, 0x50, 0x17, 0x5a, 0x66, 0x6b, 0xea, 0x96, 0xd1,
+	0x15, 0x58, 0x2b, 0x08, 0x61, 0xb7, 0x82, 0xae, 0xc2, 0x46, 0xa1, 0xdd, 0xdd, 0x2a, 0x5a, 0x85,
+	0xa6, 0xa1, 0x7b, 0xb7, 0xd6, 0xff, 0xef, 0x0a, 0xd4, 0x0e, 0xe4, 0x03, 0x22, 0xba, 0x0f, 0x55,
+	0x21, 0x99, 0xa1, 0x34, 0xd8, 0xf2, 0xf9, 0xb0, 0x97, 0x3a, 0x39, 0xfb, 0x28, 0xf4, 0x10, 0x2a,
+	0xe2, 0x69, 0x02, 0x99, 0x0d, 0x29, 0xb9, 0xa1, 0xf6, 0x36, 0x72, 0x50, 0xcd, 0xb4, 0x07, 0x55,
+	0xf9, 0x26, 0x81, 0x52, 0xbc, 0xf9, 0x46, 0xd1, 0xcb, 0x6d, 0x8e, 0x9e, 0x67, 0x34, 0x46, 0xd7,
+	0xd2, 0x07, 0xae, 0xb9, 0xd7, 0x89, 0xde, 0xf5, 0x62, 0xa4, 0xde, 0xf9, 0x2b, 0xf9, 0xd4, 0x99,
+	0xd9, 0xd9, 0x7c, 0x7c, 0xe8, 0x6d, 0xe6, 0xc1, 0x29, 0x9f, 0xbc, 0xb5, 0xa1, 0xb9, 0x5b, 0x5c,
+	0x9e, 0x2f, 0x7b, 0x15, 0x7c, 0xa2, 0xaf, 0xbd, 0x03, 0x1e, 0x51, 0x67, 0xf2, 0x89, 0xdc, 0xbb,
+	0xd6, 0x03, 0x0b, 0xbd, 0xce, 0xe7, 0x07, 0xba, 0x99, 0x50, 0x17, 0xde, 0x00, 0x7b, 0x9f, 0x2f,
+	0xc4, 0x6b, 0xa5, 0xee, 0x41, 0x45, 0xdc, 0xa4, 0x8c, 0x98, 0x19, 0x17, 0xab, 0x39, 0xe7, 0x3f,
+	0x84, 0x8a, 0x98, 0xa3, 0x0d, 0x6a, 0xe3, 0xaa, 0xd4, 0xdb, 0xc8, 0x41, 0x93, 0xa1, 0xbc, 0x74,
+	0x7a, 0x89, 0x8c, 0xf3, 0x31, 0xbe, 0x0e, 0xf4, 0xd6, 0x32, 0xb0, 0xd4, 0xbd, 0x32, 0xe1, 0x0d,
+	0x07, 0x99, 0xe3, 0x74, 0x6f, 0x33, 0x0f, 0xd6, 0x7c, 0x7f, 0x4c, 0xaa, 0x03, 0x5d, 0xc9, 0x0f,
+	0x5c, 0x31, 0xaf, 0x3d, 0x8f, 0xd0, 0xdc, 0x2f, 0x64, 0x39, 0x25, 0x03, 0x0d, 0xba, 0x6e, 0x38,
+	0x6e, 0x6e, 0x94, 0xea, 0xdd, 0x58, 0x80, 0xcd, 0x08, 0x4b, 0x8f, 0xfb, 0x8c, 0xb0, 0xfc, 0xd8,
+	0xd2, 0xbb, 0xb1, 0x00, 0xab, 0x85, 0xfd, 0x58, 0x58, 0xd6, 0x68, 0x3b, 0xdf, 0xcc, 0x0b, 0x8e,
+	0x8a, 0xde, 0xad, 0x5f, 0x26, 0xd2, 0x3b, 0x8c, 0x16, 0xf4, 0x07, 0x74, 0x3b, 0x65, 0xff, 0x85,
+	0x6e, 0xdd, 0xdb, 0xf9, 0x35, 0x32, 0xb5, 0xcf, 0x9f, 0x5b, 0x7f, 0x87, 0xbd, 0xfb, 0x8f, 0x35,
+	0xed, 0x59, 0x4d, 0xfe, 0x3c, 0xfc, 0xff, 0x00, 0x37, 0xa1, 0x60, 0xa4, 0xb0, 0x18, 0x00, 0x00,
 }
@@ -0,0 +1,137 @@
+package driverV2
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RuleSuppression is the set of rules disabled by the inline comment annotations
+// of a SQL, the annotations look like:
+//
+//	/* sqle:disable=ddl_check_index_count,ddl_check_pk_not_exist */
+//	-- sqle:disable-next-line=dml_check_limit_must_exist
+//	-- sqle:disable-next-line
+//
+// The annotation without rule list disables all rules. Rules are audited per SQL,
+// so "disable" and "disable-next-line" take effect on the SQL the comment belongs to.
+// The annotation is written by the SQL submitter, so it can not disable the rules of
+// error level, which must be waived by the whitelist instead.
+type RuleSuppression struct {
+	AllRules bool
+	Rules    map[string]struct{}
+	// MaxLevel is the highest level of rules which can be disabled, empty means no limit.
+	MaxLevel RuleLevel
+}
+
+// MaxAnnotationSuppressedLevel is the highest level of rules which can be disabled by annotation.
+const MaxAnnotationSuppressedLevel = RuleLevelWarn
+
+var suppressionAnnotationRegexp = regexp.MustCompile(`^sqle:(disable|disable-next-line)(?:\s*=\s*([\w\s,]*))?$`)
+
+// hashCommentDBTypes are the databases which take "#" as the beginning of a comment,
+// it is an operator or part of identifier in the other databases, e.g. PostgreSQL.
+var hashCommentDBTypes = []string{DriverTypeMySQL, DriverTypeTiDB, DriverTypeOceanBase, DriverTypeTDSQLForInnoDB}
+
+func isHashCommentDBType(dbType string) bool {
+	for _, typ := range hashCommentDBTypes {
+		if strings.EqualFold(typ, dbType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRuleSuppression parses the inline comment annotations of sql of dbType, it
+// returns nil if there is no annotation.
+func ParseRuleSuppression(sql, dbType string) *RuleSuppression {
+	var suppression *RuleSuppression
+	for _, comment := range extractComments(sql, isHashCommentDBType(dbType)) {
+		matches := suppressionAnnotationRegexp.FindStringSubmatch(strings.TrimSpace(comment))
+		if matches == nil {
+			continue
+		}
+		if suppression == nil {
+			suppression = &RuleSuppression{Rules: map[string]struct{}{}, MaxLevel: MaxAnnotationSuppressedLevel}
+		}
+		hasRule := false
+		for _, rule := range strings.Split(matches[2], ",") {
+			rule = strings.TrimSpace(rule)
+			if rule == "" {
+				continue
+			}
+			hasRule = true
+			suppression.Rules[rule] = struct{}{}
+		}
+		if !hasRule {
+			suppression.AllRules = true
+		}
+	}
+	return suppression
+}
+
+// IsSuppressed returns true if the rule of level is disabled. The SQL result without
+// rule name is never suppressed, e.g. the syntax error.
+func (s *RuleSuppression) IsSuppressed(ruleName string, level RuleLevel) bool {
+	if s == nil || ruleName == "" {
+		return false
+	}
+	if s.MaxLevel != "" && level.More(s.MaxLevel) {
+		return false
+	}
+	if s.AllRules {
+		return true
+	}
+	_, ok := s.Rules[ruleName]
+	return ok
+}
+
+// RuleNames returns the sorted names of disabled rules.
+func (s *RuleSuppression) RuleNames() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.Rules))
+	for name := range s.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// extractComments returns the body of "--", "/* */" and "#" comments in sql, "#"
+// is taken as comment only if hashComment is true. The quoted strings and identifiers
+// are skipped.
+func extractComments(sql string, hashComment bool) []string {
+	comments := []string{}
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			for i++; i < len(sql) && sql[i] != c; i++ {
+				if sql[i] == '\\' {
+					i++
+				}
+			}
+		case c == '-' && strings.HasPrefix(sql[i:], "--"), c == '#' && hashComment:
+			start := i + 2
+			if c == '#' {
+				start = i + 1
+			}
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			comments = append(comments, sql[start:i+end])
+			i += end
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				comments = append(comments, sql[i+2:])
+				return comments
+			}
+			comments = append(comments, sql[i+2:i+2+end])
+			i += end + 3
+		}
+	}
+	return comments
+}
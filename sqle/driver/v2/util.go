@@ -215,6 +215,54 @@ func ConvertProtoTableMetaToDriver(meta *protoV2.TableMeta) *TableMeta {
 	}
 }
 
+func ConvertAuditResultsFromDriverToProto(auditResults []*AuditResults) []*protoV2.AuditResults {
+	rets := make([]*protoV2.AuditResults, 0, len(auditResults))
+	for _, results := range auditResults {
+		ret := &protoV2.AuditResults{
+			Results: []*protoV2.AuditResult{},
+		}
+		for _, result := range results.Results {
+			r := &protoV2.AuditResult{
+				Level:    string(result.Level),
+				Message:  result.Message,
+				RuleName: result.RuleName,
+			}
+			if result.FixSuggestion != nil {
+				r.FixSuggestion = &protoV2.FixSuggestion{
+					Description:  result.FixSuggestion.Description,
+					RewrittenSql: result.FixSuggestion.RewrittenSQL,
+				}
+			}
+			ret.Results = append(ret.Results, r)
+		}
+		rets = append(rets, ret)
+	}
+	return rets
+}
+
+func ConvertAuditResultsFromProtoToDriver(auditResults []*protoV2.AuditResults) []*AuditResults {
+	rets := make([]*AuditResults, 0, len(auditResults))
+	for _, results := range auditResults {
+		ret := &AuditResults{}
+		for _, result := range results.Results {
+			r := &AuditResult{
+				Level:    RuleLevel(result.Level),
+				Message:  result.Message,
+				RuleName: result.RuleName,
+			}
+			if result.FixSuggestion != nil {
+				r.FixSuggestion = &FixSuggestion{
+					Description:  result.FixSuggestion.Description,
+					RewrittenSQL: result.FixSuggestion.RewrittenSql,
+				}
+			}
+			ret.Results = append(ret.Results, r)
+		}
+		rets = append(rets, ret)
+	}
+	return rets
+}
+
 func RandStr(length int) string {
 	str := "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 	bytes := []byte(str)
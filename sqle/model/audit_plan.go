@@ -36,6 +36,9 @@ type AuditPlan struct {
 
 	ProjectStatus string `gorm:"default:'active'"` // dms-todo: 暂时将项目状态放在这里
 
+	// RegressionAnalyzedAt is the bucket of SQL metrics which is analyzed for regressions last.
+	RegressionAnalyzedAt *time.Time `json:"regression_analyzed_at"`
+
 	// CreateUser    *User             // TODO 移除 `gorm:"foreignkey:CreateUserId"`
 	Instance      *Instance         `gorm:"foreignkey:InstanceName;association_foreignkey:Name"`
 	AuditPlanSQLs []*AuditPlanSQLV2 `gorm:"foreignkey:AuditPlanID"`
@@ -181,7 +184,11 @@ func (s *Storage) GetLatestStartTimeAuditPlanSQL(auditPlanId uint) (string, erro
 }
 
 func (s *Storage) OverrideAuditPlanSQLs(auditPlanId uint, sqls []*AuditPlanSQLV2) error {
-	err := s.db.Unscoped().
+	prevInfos, err := s.getPrevAuditPlanSQLInfos(auditPlanId, sqls)
+	if err != nil {
+		return err
+	}
+	err = s.db.Unscoped().
 		Model(AuditPlanSQLV2{}).
 		Where("audit_plan_id = ?", auditPlanId).
 		Delete(&AuditPlanSQLV2{}).Error
@@ -189,7 +196,10 @@ func (s *Storage) OverrideAuditPlanSQLs(auditPlanId uint, sqls []*AuditPlanSQLV2
 		return errors.New(errors.ConnectStorageError, err)
 	}
 	raw, args := getBatchInsertRawSQL(auditPlanId, sqls)
-	return errors.New(errors.ConnectStorageError, s.db.Exec(fmt.Sprintf("%v;", raw), args...).Error)
+	if err := s.db.Exec(fmt.Sprintf("%v;", raw), args...).Error; err != nil {
+		return errors.New(errors.ConnectStorageError, err)
+	}
+	return s.saveAuditPlanSQLMetrics(auditPlanId, sqls, prevInfos)
 }
 
 func (s *Storage) UpdateDefaultAuditPlanSQLs(auditPlanId uint, sqls []*AuditPlanSQLV2) error {
@@ -207,7 +217,75 @@ func (s *Storage) UpdateDefaultAuditPlanSQLs(auditPlanId uint, sqls []*AuditPlan
 		'$.last_receive_timestamp', JSON_EXTRACT(values(info), '$.last_receive_timestamp')
 		);`
 
-	return errors.New(errors.ConnectStorageError, s.db.Exec(raw, args...).Error)
+	if err := s.db.Exec(raw, args...).Error; err != nil {
+		return errors.New(errors.ConnectStorageError, err)
+	}
+	return s.saveAuditPlanSQLMetrics(auditPlanId, sqls, nil)
+}
+
+// UpdateSnapshotAuditPlanSQLs replaces the info of SQLs with the latest snapshot, it is used by
+// the SQLs whose statistics are accumulated by the database itself, e.g. pg_stat_statements.
+func (s *Storage) UpdateSnapshotAuditPlanSQLs(auditPlanId uint, sqls []*AuditPlanSQLV2) error {
+	prevInfos, err := s.getPrevAuditPlanSQLInfos(auditPlanId, sqls)
+	if err != nil {
+		return err
+	}
+	raw, args := getBatchInsertRawSQL(auditPlanId, sqls)
+	raw += `ON DUPLICATE KEY UPDATE 
+	sql_content = VALUES(sql_content), 
+	info        = VALUES(info);`
+
+	if err := s.db.Exec(raw, args...).Error; err != nil {
+		return errors.New(errors.ConnectStorageError, err)
+	}
+	return s.saveAuditPlanSQLMetrics(auditPlanId, sqls, prevInfos)
+}
+
+// UpdateDigestAuditPlanSQLs accumulates the statistics of SQLs collected from the digest table,
+// the average latency is recalculated by the accumulated count and total latency.
+func (s *Storage) UpdateDigestAuditPlanSQLs(auditPlanId uint, sqls []*AuditPlanSQLV2) error {
+	raw, args := getBatchInsertRawSQL(auditPlanId, sqls)
+	raw += `ON DUPLICATE KEY UPDATE 
+	sql_content = VALUES(sql_content), 
+	info        = JSON_SET(
+		COALESCE(info, '{}'),
+		'$.counter', CAST(
+			COALESCE(JSON_EXTRACT(values(info), '$.counter'), 0) 
+			+ COALESCE(JSON_EXTRACT(info, '$.counter'), 0) 
+			AS SIGNED
+		),
+		'$.total_latency_ms', CAST(
+			COALESCE(JSON_EXTRACT(values(info), '$.total_latency_ms'), 0) 
+			+ COALESCE(JSON_EXTRACT(info, '$.total_latency_ms'), 0) 
+			AS DECIMAL(20,3)
+		),
+		'$.avg_latency_ms', CAST(
+			(
+				COALESCE(JSON_EXTRACT(values(info), '$.total_latency_ms'), 0) 
+				+ COALESCE(JSON_EXTRACT(info, '$.total_latency_ms'), 0)
+			)/(
+				COALESCE(JSON_EXTRACT(values(info), '$.counter'), 1) 
+				+ COALESCE(JSON_EXTRACT(info, '$.counter'), 0)
+			)
+			AS DECIMAL(20,3)
+		),
+		'$.rows_examined', CAST(
+			COALESCE(JSON_EXTRACT(values(info), '$.rows_examined'), 0) 
+			+ COALESCE(JSON_EXTRACT(info, '$.rows_examined'), 0) 
+			AS SIGNED
+		),
+		'$.no_index_used', CAST(
+			COALESCE(JSON_EXTRACT(values(info), '$.no_index_used'), 0) 
+			+ COALESCE(JSON_EXTRACT(info, '$.no_index_used'), 0) 
+			AS SIGNED
+		),
+		'$.last_receive_timestamp', JSON_EXTRACT(values(info), '$.last_receive_timestamp')
+		);`
+
+	if err := s.db.Exec(raw, args...).Error; err != nil {
+		return errors.New(errors.ConnectStorageError, err)
+	}
+	return s.saveAuditPlanSQLMetrics(auditPlanId, sqls, nil)
 }
 
 func (s *Storage) UpdateSlowLogAuditPlanSQLs(auditPlanId uint, sqls []*AuditPlanSQLV2) error {
@@ -265,7 +343,10 @@ func (s *Storage) UpdateSlowLogAuditPlanSQLs(auditPlanId uint, sqls []*AuditPlan
             )
 	  	);`
 
-	return errors.New(errors.ConnectStorageError, s.db.Exec(raw, args...).Error)
+	if err := s.db.Exec(raw, args...).Error; err != nil {
+		return errors.New(errors.ConnectStorageError, err)
+	}
+	return s.saveAuditPlanSQLMetrics(auditPlanId, sqls, nil)
 }
 
 func (s *Storage) UpdateSlowLogCollectAuditPlanSQLs(auditPlanId uint, sqls []*AuditPlanSQLV2) error {
@@ -0,0 +1,250 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/errors"
+
+	"github.com/jinzhu/gorm"
+)
+
+// AuditPlanSQLMetricBucket is the time span of a bucket of the audit plan SQL metrics.
+const AuditPlanSQLMetricBucket = time.Hour
+
+// AuditPlanSQLMetric is the metrics of audit plan SQL collected in a time bucket, the info of
+// AuditPlanSQLV2 only keeps the accumulated metrics, so the trend of SQL is kept here.
+type AuditPlanSQLMetric struct {
+	Model
+	AuditPlanId    uint      `json:"audit_plan_id" gorm:"unique_index:uniq_audit_plan_sql_metric; not null"`
+	FingerprintMD5 string    `json:"fingerprint_md5" gorm:"column:fingerprint_md5; type:varchar(255); unique_index:uniq_audit_plan_sql_metric; not null"`
+	BucketAt       time.Time `json:"bucket_at" gorm:"unique_index:uniq_audit_plan_sql_metric; index; not null"`
+	Count          int64     `json:"count"`
+	// QueryTimeTotal and QueryTimeMax are in seconds.
+	QueryTimeTotal float64 `json:"query_time_total"`
+	QueryTimeMax   float64 `json:"query_time_max"`
+	RowsExamined   int64   `json:"rows_examined"`
+}
+
+// newAuditPlanSQLMetric returns the metric of the SQL collected in one collection, the metrics
+// are read from the info of SQL written by the collectors. It returns nil if the SQL is not
+// executed in the collection.
+func newAuditPlanSQLMetric(auditPlanId uint, sql *AuditPlanSQLV2, bucketAt time.Time) *AuditPlanSQLMetric {
+	info := struct {
+		Counter        int64    `json:"counter"`
+		QueryTimeAvg   *float64 `json:"query_time_avg"`
+		QueryTimeMax   float64  `json:"query_time_max"`
+		TotalLatencyMs *float64 `json:"total_latency_ms"`
+		RowsExamined   int64    `json:"rows_examined"`
+	}{}
+	if len(sql.Info) > 0 {
+		if err := json.Unmarshal(sql.Info, &info); err != nil {
+			return nil
+		}
+	}
+	if info.Counter <= 0 {
+		return nil
+	}
+	metric := &AuditPlanSQLMetric{
+		AuditPlanId:    auditPlanId,
+		FingerprintMD5: sql.GetFingerprintMD5(),
+		BucketAt:       bucketAt,
+		Count:          info.Counter,
+		QueryTimeMax:   info.QueryTimeMax,
+		RowsExamined:   info.RowsExamined,
+	}
+	if info.QueryTimeAvg != nil {
+		metric.QueryTimeTotal = *info.QueryTimeAvg * float64(info.Counter)
+	} else if info.TotalLatencyMs != nil {
+		metric.QueryTimeTotal = *info.TotalLatencyMs / 1000
+	}
+	return metric
+}
+
+// auditPlanSQLSnapshot is the statistics of SQL accumulated by the database itself, such as
+// pg_stat_statements of PostgreSQL and V$SQLAREA of Oracle, the info of AuditPlanSQLV2 is
+// replaced with the latest snapshot.
+type auditPlanSQLSnapshot struct {
+	count          int64
+	queryTimeTotal float64 // in seconds
+	rowsExamined   int64
+}
+
+func parseAuditPlanSQLSnapshot(info []byte) (*auditPlanSQLSnapshot, bool) {
+	if len(info) == 0 {
+		return nil, false
+	}
+	stat := struct {
+		// pg_stat_statements
+		Calls       *int64  `json:"calls"`
+		TotalTimeMs float64 `json:"total_time_ms"`
+		Rows        int64   `json:"rows"`
+		// V$SQLAREA, the elapsed time is in microseconds
+		Executions  *int64  `json:"executions"`
+		ElapsedTime float64 `json:"elapsed_time"`
+	}{}
+	if err := json.Unmarshal(info, &stat); err != nil {
+		return nil, false
+	}
+	switch {
+	case stat.Calls != nil:
+		return &auditPlanSQLSnapshot{count: *stat.Calls, queryTimeTotal: stat.TotalTimeMs / 1000, rowsExamined: stat.Rows}, true
+	case stat.Executions != nil:
+		return &auditPlanSQLSnapshot{count: *stat.Executions, queryTimeTotal: stat.ElapsedTime / 1000 / 1000}, true
+	}
+	return nil, false
+}
+
+// newAuditPlanSQLSnapshotMetric returns the metric of the SQL between the previous snapshot and
+// the current snapshot. It returns nil if the SQL is seen for the first time, its statistics
+// before the previous collection are unknown, so it is the baseline only. The statistics are
+// reset if they are less than the previous, then the current snapshot is the metric.
+func newAuditPlanSQLSnapshotMetric(auditPlanId uint, sql *AuditPlanSQLV2, prevInfo []byte, bucketAt time.Time) *AuditPlanSQLMetric {
+	cur, ok := parseAuditPlanSQLSnapshot(sql.Info)
+	if !ok {
+		return nil
+	}
+	prev, ok := parseAuditPlanSQLSnapshot(prevInfo)
+	if !ok {
+		return nil
+	}
+	delta := &auditPlanSQLSnapshot{
+		count:          cur.count - prev.count,
+		queryTimeTotal: cur.queryTimeTotal - prev.queryTimeTotal,
+		rowsExamined:   cur.rowsExamined - prev.rowsExamined,
+	}
+	if delta.count < 0 || delta.queryTimeTotal < 0 || delta.rowsExamined < 0 {
+		delta = cur
+	}
+	if delta.count <= 0 {
+		return nil
+	}
+	return &AuditPlanSQLMetric{
+		AuditPlanId:    auditPlanId,
+		FingerprintMD5: sql.GetFingerprintMD5(),
+		BucketAt:       bucketAt,
+		Count:          delta.count,
+		QueryTimeTotal: delta.queryTimeTotal,
+		RowsExamined:   delta.rowsExamined,
+	}
+}
+
+// getPrevAuditPlanSQLInfos returns the info of SQLs saved by the previous collection, which is
+// indexed by fingerprint md5. It returns nil if there are no snapshot statistics in the SQLs.
+func (s *Storage) getPrevAuditPlanSQLInfos(auditPlanId uint, sqls []*AuditPlanSQLV2) (map[string][]byte, error) {
+	hasSnapshot := false
+	for _, sql := range sqls {
+		if _, ok := parseAuditPlanSQLSnapshot(sql.Info); ok {
+			hasSnapshot = true
+			break
+		}
+	}
+	if !hasSnapshot {
+		return nil, nil
+	}
+	rows, err := s.db.Raw("SELECT `fingerprint_md5`, `info` FROM `audit_plan_sqls_v2` WHERE `audit_plan_id` = ? AND `deleted_at` IS NULL", auditPlanId).Rows()
+	if err != nil {
+		return nil, errors.New(errors.ConnectStorageError, err)
+	}
+	defer rows.Close()
+	infos := map[string][]byte{}
+	for rows.Next() {
+		var fingerprintMD5 string
+		var info []byte
+		if err := rows.Scan(&fingerprintMD5, &info); err != nil {
+			return nil, errors.New(errors.ConnectStorageError, err)
+		}
+		infos[fingerprintMD5] = info
+	}
+	return infos, errors.New(errors.ConnectStorageError, rows.Err())
+}
+
+// saveAuditPlanSQLMetrics accumulates the metrics of SQLs collected in one collection into the
+// current bucket. The prevInfos is used by the SQLs with snapshot statistics only.
+func (s *Storage) saveAuditPlanSQLMetrics(auditPlanId uint, sqls []*AuditPlanSQLV2, prevInfos map[string][]byte) error {
+	bucketAt := time.Now().Truncate(AuditPlanSQLMetricBucket)
+	pattern := make([]string, 0, len(sqls))
+	args := make([]interface{}, 0, len(sqls)*9)
+	for _, sql := range sqls {
+		metric := newAuditPlanSQLMetric(auditPlanId, sql, bucketAt)
+		if metric == nil {
+			metric = newAuditPlanSQLSnapshotMetric(auditPlanId, sql, prevInfos[sql.GetFingerprintMD5()], bucketAt)
+		}
+		if metric == nil {
+			continue
+		}
+		pattern = append(pattern, "(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, metric.AuditPlanId, metric.FingerprintMD5, metric.BucketAt, metric.Count,
+			metric.QueryTimeTotal, metric.QueryTimeMax, metric.RowsExamined, bucketAt, bucketAt)
+	}
+	if len(pattern) == 0 {
+		return nil
+	}
+	raw := fmt.Sprintf("INSERT INTO `audit_plan_sql_metrics` (`audit_plan_id`, `fingerprint_md5`, `bucket_at`, `count`, "+
+		"`query_time_total`, `query_time_max`, `rows_examined`, `created_at`, `updated_at`) VALUES %s ", strings.Join(pattern, ", "))
+	raw += `ON DUPLICATE KEY UPDATE 
+	count            = count + VALUES(count), 
+	query_time_total = query_time_total + VALUES(query_time_total), 
+	query_time_max   = GREATEST(query_time_max, VALUES(query_time_max)), 
+	rows_examined    = rows_examined + VALUES(rows_examined), 
+	updated_at       = NOW();`
+	return errors.New(errors.ConnectStorageError, s.db.Exec(raw, args...).Error)
+}
+
+func (s *Storage) GetAuditPlanSQLMetrics(auditPlanId uint, fingerprintMD5 string, start, end time.Time) ([]*AuditPlanSQLMetric, error) {
+	metrics := []*AuditPlanSQLMetric{}
+	err := s.db.Where("audit_plan_id = ? AND fingerprint_md5 = ?", auditPlanId, fingerprintMD5).
+		Where("bucket_at >= ? AND bucket_at <= ?", start, end).
+		Order("bucket_at ASC").Find(&metrics).Error
+	return metrics, errors.New(errors.ConnectStorageError, err)
+}
+
+// GetLatestAuditPlanSQLMetricBucket returns the latest bucket of the audit plan SQL metrics before
+// the time.
+func (s *Storage) GetLatestAuditPlanSQLMetricBucket(auditPlanId uint, before time.Time) (time.Time, bool, error) {
+	metric := &AuditPlanSQLMetric{}
+	err := s.db.Where("audit_plan_id = ? AND bucket_at < ?", auditPlanId, before).
+		Order("bucket_at DESC").First(metric).Error
+	if err == gorm.ErrRecordNotFound {
+		return time.Time{}, false, nil
+	}
+	return metric.BucketAt, true, errors.New(errors.ConnectStorageError, err)
+}
+
+// AuditPlanSQLMetricStat is the metrics of a SQL fingerprint summed over the buckets in a time range.
+type AuditPlanSQLMetricStat struct {
+	FingerprintMD5 string
+	Fingerprint    string
+	Schema         string
+	// Buckets is the number of buckets in which the SQL is executed.
+	Buckets        uint
+	Count          int64
+	QueryTimeTotal float64
+}
+
+// GetAuditPlanSQLMetricStats returns the metrics of SQL fingerprints summed over the buckets in
+// [start, end), which is indexed by fingerprint md5.
+func (s *Storage) GetAuditPlanSQLMetricStats(auditPlanId uint, start, end time.Time) (map[string] /*fingerprint md5*/ *AuditPlanSQLMetricStat, error) {
+	stats := []*AuditPlanSQLMetricStat{}
+	err := s.db.Raw("SELECT m.fingerprint_md5, MAX(s.fingerprint) AS fingerprint, MAX(s.schema) AS `schema`, "+
+		"COUNT(*) AS buckets, SUM(m.count) AS count, SUM(m.query_time_total) AS query_time_total "+
+		"FROM audit_plan_sql_metrics AS m "+
+		"LEFT JOIN audit_plan_sqls_v2 AS s ON s.audit_plan_id = m.audit_plan_id AND s.fingerprint_md5 = m.fingerprint_md5 AND s.deleted_at IS NULL "+
+		"WHERE m.audit_plan_id = ? AND m.bucket_at >= ? AND m.bucket_at < ? AND m.deleted_at IS NULL "+
+		"GROUP BY m.fingerprint_md5", auditPlanId, start, end).Scan(&stats).Error
+	if err != nil {
+		return nil, errors.New(errors.ConnectStorageError, err)
+	}
+	res := make(map[string]*AuditPlanSQLMetricStat, len(stats))
+	for _, stat := range stats {
+		res[stat.FingerprintMD5] = stat
+	}
+	return res, nil
+}
+
+func (s *Storage) DeleteExpiredAuditPlanSQLMetrics(expiredTime time.Time) (int64, error) {
+	db := s.db.Unscoped().Where("bucket_at < ?", expiredTime).Delete(&AuditPlanSQLMetric{})
+	return db.RowsAffected, errors.New(errors.ConnectStorageError, db.Error)
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditPlanSQLMetric(t *testing.T) {
+	bucketAt := time.Now().Truncate(AuditPlanSQLMetricBucket)
+
+	// slow log
+	sql := &AuditPlanSQLV2{
+		Fingerprint: "select * from t1 where id = ?",
+		Info:        []byte(`{"counter": 4, "query_time_avg": 0.5, "query_time_max": 2, "rows_examined": 100}`),
+	}
+	metric := newAuditPlanSQLMetric(1, sql, bucketAt)
+	assert.NotNil(t, metric)
+	assert.Equal(t, uint(1), metric.AuditPlanId)
+	assert.Equal(t, sql.GetFingerprintMD5(), metric.FingerprintMD5)
+	assert.Equal(t, bucketAt, metric.BucketAt)
+	assert.Equal(t, int64(4), metric.Count)
+	assert.Equal(t, float64(2), metric.QueryTimeTotal)
+	assert.Equal(t, float64(2), metric.QueryTimeMax)
+	assert.Equal(t, int64(100), metric.RowsExamined)
+
+	// performance_schema digest
+	sql = &AuditPlanSQLV2{
+		Fingerprint: "select * from t1 where id = ?",
+		Info:        []byte(`{"counter": 2, "total_latency_ms": 3000, "rows_examined": 10}`),
+	}
+	metric = newAuditPlanSQLMetric(1, sql, bucketAt)
+	assert.NotNil(t, metric)
+	assert.Equal(t, int64(2), metric.Count)
+	assert.Equal(t, float64(3), metric.QueryTimeTotal)
+	assert.Equal(t, int64(10), metric.RowsExamined)
+
+	// not executed in the collection
+	assert.Nil(t, newAuditPlanSQLMetric(1, &AuditPlanSQLV2{Info: []byte(`{"counter": 0}`)}, bucketAt))
+	assert.Nil(t, newAuditPlanSQLMetric(1, &AuditPlanSQLV2{}, bucketAt))
+	assert.Nil(t, newAuditPlanSQLMetric(1, &AuditPlanSQLV2{Info: []byte(`invalid`)}, bucketAt))
+}
+
+func TestNewAuditPlanSQLSnapshotMetric(t *testing.T) {
+	bucketAt := time.Now().Truncate(AuditPlanSQLMetricBucket)
+	newSQL := func(info string) *AuditPlanSQLV2 {
+		return &AuditPlanSQLV2{Fingerprint: "select * from t1 where id = $1", Info: []byte(info)}
+	}
+
+	// pg_stat_statements
+	sql := newSQL(`{"calls": 10, "total_time_ms": 5000, "rows": 100}`)
+	metric := newAuditPlanSQLSnapshotMetric(1, sql, []byte(`{"calls": 4, "total_time_ms": 2000, "rows": 40}`), bucketAt)
+	assert.NotNil(t, metric)
+	assert.Equal(t, sql.GetFingerprintMD5(), metric.FingerprintMD5)
+	assert.Equal(t, int64(6), metric.Count)
+	assert.Equal(t, float64(3), metric.QueryTimeTotal)
+	assert.Equal(t, int64(60), metric.RowsExamined)
+
+	// V$SQLAREA of Oracle
+	metric = newAuditPlanSQLSnapshotMetric(1, newSQL(`{"executions": 5, "elapsed_time": 3000000}`),
+		[]byte(`{"executions": 3, "elapsed_time": 1000000}`), bucketAt)
+	assert.NotNil(t, metric)
+	assert.Equal(t, int64(2), metric.Count)
+	assert.Equal(t, float64(2), metric.QueryTimeTotal)
+
+	// the statistics are reset
+	metric = newAuditPlanSQLSnapshotMetric(1, newSQL(`{"calls": 2, "total_time_ms": 1000}`), []byte(`{"calls": 4, "total_time_ms": 2000}`), bucketAt)
+	assert.NotNil(t, metric)
+	assert.Equal(t, int64(2), metric.Count)
+	assert.Equal(t, float64(1), metric.QueryTimeTotal)
+
+	// the SQL seen for the first time is the baseline only
+	assert.Nil(t, newAuditPlanSQLSnapshotMetric(1, newSQL(`{"calls": 2}`), nil, bucketAt))
+	// the SQL is not executed since the previous collection
+	assert.Nil(t, newAuditPlanSQLSnapshotMetric(1, newSQL(`{"calls": 2}`), []byte(`{"calls": 2}`), bucketAt))
+	// the SQL has no snapshot statistics
+	assert.Nil(t, newAuditPlanSQLSnapshotMetric(1, newSQL(`{"counter": 2}`), []byte(`{"counter": 1}`), bucketAt))
+}
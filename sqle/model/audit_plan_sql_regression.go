@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/actiontech/sqle/sqle/errors"
+)
+
+const (
+	AuditPlanSQLRegressionTypeNew       = "new"
+	AuditPlanSQLRegressionTypeLatency   = "latency"
+	AuditPlanSQLRegressionTypeFrequency = "frequency"
+)
+
+// AuditPlanSQLRegression is the SQL fingerprint flagged by the regression analysis of a bucket of
+// the audit plan SQL metrics.
+type AuditPlanSQLRegression struct {
+	Type        string
+	Fingerprint string
+	Schema      string
+	// Baseline and Current are the values compared, they are the executions in one bucket for
+	// frequency regression and the average query time in seconds for latency regression.
+	Baseline float64
+	Current  float64
+}
+
+// ClaimAuditPlanSQLRegressionBucket marks the bucket as analyzed for the audit plan. It returns
+// false if the bucket or a later one has been analyzed, so a bucket is analyzed only once even
+// if the SQLs in it are uploaded by many requests.
+func (s *Storage) ClaimAuditPlanSQLRegressionBucket(auditPlanId uint, bucketAt time.Time) (bool, error) {
+	db := s.db.Model(&AuditPlan{}).
+		Where("id = ? AND (regression_analyzed_at IS NULL OR regression_analyzed_at < ?)", auditPlanId, bucketAt).
+		UpdateColumn("regression_analyzed_at", bucketAt)
+	return db.RowsAffected > 0, errors.New(errors.ConnectStorageError, db.Error)
+}
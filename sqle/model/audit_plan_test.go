@@ -152,6 +152,11 @@ func TestStorage_OverrideAuditPlanSQLs(t *testing.T) {
 	mock.ExpectExec("INSERT INTO `audit_plan_sqls_v2` (`audit_plan_id`,`fingerprint_md5`, `fingerprint`, `sql_content`, `info`, `schema`) VALUES (?, ?, ?, ?, ?, ?);").
 		WithArgs(ap.ID, sqls[0].GetFingerprintMD5(), sqls[0].Fingerprint, sqls[0].SQLContent, sqls[0].Info, sqls[0].Schema).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO `audit_plan_sql_metrics` (`audit_plan_id`, `fingerprint_md5`, `bucket_at`, `count`, `query_time_total`, `query_time_max`, `rows_examined`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) "+
+		"ON DUPLICATE KEY UPDATE \n\tcount            = count + VALUES(count), \n\tquery_time_total = query_time_total + VALUES(query_time_total), "+
+		"\n\tquery_time_max   = GREATEST(query_time_max, VALUES(query_time_max)), \n\trows_examined    = rows_examined + VALUES(rows_examined), \n\tupdated_at       = NOW();").
+		WithArgs(ap.ID, sqls[0].GetFingerprintMD5(), sqlmock.AnyArg(), int64(1), float64(0), float64(0), int64(0), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err = GetStorage().OverrideAuditPlanSQLs(ap.ID, sqls)
 	assert.NoError(t, err)
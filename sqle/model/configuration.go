@@ -14,13 +14,15 @@ import (
 
 const globalConfigurationTablePrefix = "global_configuration"
 const (
-	SystemVariableWorkflowExpiredHours        = "system_variable_workflow_expired_hours"
-	SystemVariableSqleUrl                     = "system_variable_sqle_url"
-	SystemVariableOperationRecordExpiredHours = "system_variable_operation_record_expired_hours"
+	SystemVariableWorkflowExpiredHours           = "system_variable_workflow_expired_hours"
+	SystemVariableSqleUrl                        = "system_variable_sqle_url"
+	SystemVariableOperationRecordExpiredHours    = "system_variable_operation_record_expired_hours"
+	SystemVariableAuditPlanSQLMetricExpiredHours = "system_variable_audit_plan_sql_metric_expired_hours"
 )
 
 const (
-	DefaultOperationRecordExpiredHours = 90 * 24
+	DefaultOperationRecordExpiredHours    = 90 * 24
+	DefaultAuditPlanSQLMetricExpiredHours = 30 * 24
 )
 
 // SystemVariable store misc K-V.
@@ -65,6 +67,13 @@ func (s *Storage) GetAllSystemVariables() (map[string]SystemVariable, error) {
 		}
 	}
 
+	if _, ok := sysVariables[SystemVariableAuditPlanSQLMetricExpiredHours]; !ok {
+		sysVariables[SystemVariableAuditPlanSQLMetricExpiredHours] = SystemVariable{
+			Key:   SystemVariableAuditPlanSQLMetricExpiredHours,
+			Value: strconv.Itoa(DefaultAuditPlanSQLMetricExpiredHours),
+		}
+	}
+
 	return sysVariables, nil
 }
 
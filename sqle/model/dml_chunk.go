@@ -0,0 +1,35 @@
+package model
+
+import (
+	"database/sql"
+
+	"github.com/actiontech/sqle/sqle/errors"
+)
+
+// ExecuteSQLChunk is a chunk of the DML executed in chunks ranged by primary key, the range is
+// (LowerBound, UpperBound]. The rollback SQL of chunk is generated before the chunk is executed.
+type ExecuteSQLChunk struct {
+	Model
+	TaskId              uint   `json:"task_id" gorm:"index; not null"`
+	ExecuteSQLId        uint   `json:"execute_sql_id" gorm:"index; not null"`
+	Number              uint   `json:"number"`
+	Content             string `json:"sql" gorm:"type:longtext"`
+	LowerBound          string `json:"lower_bound"`
+	UpperBound          string `json:"upper_bound"`
+	ExecStatus          string `json:"exec_status" gorm:"default:\"initialized\""`
+	ExecResult          string `json:"exec_result" gorm:"type:text"`
+	RowAffects          int64  `json:"row_affects"`
+	RollbackSQL         string `json:"rollback_sql" gorm:"type:longtext"`
+	RollbackDescription string `json:"rollback_description" gorm:"type:text"`
+}
+
+func (s *Storage) GetExecuteSQLChunksByExecuteSQLId(executeSQLId uint) ([]*ExecuteSQLChunk, error) {
+	chunks := []*ExecuteSQLChunk{}
+	err := s.db.Where("execute_sql_id = ?", executeSQLId).Order("number ASC").Find(&chunks).Error
+	return chunks, errors.New(errors.ConnectStorageError, err)
+}
+
+func deleteExecuteSQLChunksByTaskId(tx *sql.Tx, taskId uint) error {
+	_, err := tx.Exec("DELETE FROM execute_sql_chunks WHERE task_id = ?", taskId)
+	return err
+}
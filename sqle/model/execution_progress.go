@@ -0,0 +1,56 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/errors"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ExecuteSQLProgress is the progress of the statement running for an execute SQL, it is
+// reported by the node executing the task only if the statement is running for a while.
+type ExecuteSQLProgress struct {
+	Model
+	TaskId         uint       `json:"task_id" gorm:"index; not null"`
+	ExecuteSQLId   uint       `json:"execute_sql_id" gorm:"unique_index; not null"`
+	Number         uint       `json:"number"`
+	StartAt        *time.Time `json:"start_at"`
+	EndAt          *time.Time `json:"end_at"`
+	State          string     `json:"state"`
+	ElapsedSeconds int64      `json:"elapsed_seconds"`
+	RowsExamined   int64      `json:"rows_examined"`
+	RowsAffected   int64      `json:"rows_affected"`
+}
+
+func (s *Storage) SaveExecuteSQLProgress(p *ExecuteSQLProgress) error {
+	raw := "INSERT INTO execute_sql_progresses (task_id, execute_sql_id, number, start_at, end_at, state, " +
+		"elapsed_seconds, rows_examined, rows_affected) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) " +
+		"ON DUPLICATE KEY UPDATE end_at = VALUES(end_at), state = VALUES(state), elapsed_seconds = VALUES(elapsed_seconds), " +
+		"rows_examined = VALUES(rows_examined), rows_affected = VALUES(rows_affected)"
+	err := s.db.Exec(raw, p.TaskId, p.ExecuteSQLId, p.Number, p.StartAt, p.EndAt, p.State,
+		p.ElapsedSeconds, p.RowsExamined, p.RowsAffected).Error
+	return errors.New(errors.ConnectStorageError, err)
+}
+
+func (s *Storage) GetExecuteSQLProgressByExecuteSQLId(executeSQLId uint) (*ExecuteSQLProgress, bool, error) {
+	p := &ExecuteSQLProgress{}
+	err := s.db.Where("execute_sql_id = ?", executeSQLId).First(p).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, false, nil
+	}
+	return p, true, errors.New(errors.ConnectStorageError, err)
+}
+
+func (s *Storage) GetExecutingSQLsByTaskId(taskId uint) ([]*ExecuteSQL, error) {
+	executeSQLs := []*ExecuteSQL{}
+	err := s.db.Where("task_id = ? AND exec_status = ?", taskId, SQLExecuteStatusDoing).
+		Order("number ASC").Find(&executeSQLs).Error
+	return executeSQLs, errors.New(errors.ConnectStorageError, err)
+}
+
+func deleteExecuteSQLProgressesByTaskId(tx *sql.Tx, taskId uint) error {
+	_, err := tx.Exec("DELETE FROM execute_sql_progresses WHERE task_id = ?", taskId)
+	return err
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"database/sql"
+
+	"github.com/actiontech/sqle/sqle/errors"
+
+	"github.com/jinzhu/gorm"
+)
+
+// OnlineDDLExecution is the control and progress of the online DDL executed by an execute SQL.
+// The control fields are updated by user and applied to the online DDL by the node executing
+// the task, the progress fields are reported by the node executing the task.
+type OnlineDDLExecution struct {
+	Model
+	TaskId       uint     `json:"task_id" gorm:"index; not null"`
+	ExecuteSQLId uint     `json:"execute_sql_id" gorm:"unique_index; not null"`
+	Number       uint     `json:"number"`
+	Paused       bool     `json:"paused"`
+	NiceRatio    *float64 `json:"nice_ratio"`
+	MaxLagMillis int64    `json:"max_lag_millis"`
+	ChunkSize    int64    `json:"chunk_size"`
+
+	Running        bool    `json:"running"`
+	RowsCopied     int64   `json:"rows_copied"`
+	RowsEstimate   int64   `json:"rows_estimate"`
+	ProgressPct    float64 `json:"progress_pct"`
+	ETASeconds     int64   `json:"eta_seconds"`
+	Throttled      bool    `json:"throttled"`
+	ThrottleReason string  `json:"throttle_reason" gorm:"type:text"`
+}
+
+// SaveOnlineDDLControl saves the control fields only, the progress is not overwritten.
+func (s *Storage) SaveOnlineDDLControl(e *OnlineDDLExecution) error {
+	raw := "INSERT INTO online_ddl_executions (task_id, execute_sql_id, number, paused, nice_ratio, max_lag_millis, chunk_size) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?) " +
+		"ON DUPLICATE KEY UPDATE paused = VALUES(paused), nice_ratio = VALUES(nice_ratio), " +
+		"max_lag_millis = VALUES(max_lag_millis), chunk_size = VALUES(chunk_size)"
+	err := s.db.Exec(raw, e.TaskId, e.ExecuteSQLId, e.Number, e.Paused, e.NiceRatio, e.MaxLagMillis, e.ChunkSize).Error
+	return errors.New(errors.ConnectStorageError, err)
+}
+
+// SaveOnlineDDLProgress saves the progress fields only, the control is not overwritten.
+func (s *Storage) SaveOnlineDDLProgress(e *OnlineDDLExecution) error {
+	raw := "INSERT INTO online_ddl_executions (task_id, execute_sql_id, number, running, rows_copied, rows_estimate, " +
+		"progress_pct, eta_seconds, throttled, throttle_reason) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) " +
+		"ON DUPLICATE KEY UPDATE running = VALUES(running), rows_copied = VALUES(rows_copied), " +
+		"rows_estimate = VALUES(rows_estimate), progress_pct = VALUES(progress_pct), eta_seconds = VALUES(eta_seconds), " +
+		"throttled = VALUES(throttled), throttle_reason = VALUES(throttle_reason)"
+	err := s.db.Exec(raw, e.TaskId, e.ExecuteSQLId, e.Number, e.Running, e.RowsCopied, e.RowsEstimate,
+		e.ProgressPct, e.ETASeconds, e.Throttled, e.ThrottleReason).Error
+	return errors.New(errors.ConnectStorageError, err)
+}
+
+func (s *Storage) GetOnlineDDLExecutionByExecuteSQLId(executeSQLId uint) (*OnlineDDLExecution, bool, error) {
+	e := &OnlineDDLExecution{}
+	err := s.db.Where("execute_sql_id = ?", executeSQLId).First(e).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, false, nil
+	}
+	return e, true, errors.New(errors.ConnectStorageError, err)
+}
+
+func (s *Storage) GetOnlineDDLExecutionsByTaskId(taskId uint) ([]*OnlineDDLExecution, error) {
+	executions := []*OnlineDDLExecution{}
+	err := s.db.Where("task_id = ?", taskId).Order("number ASC").Find(&executions).Error
+	return executions, errors.New(errors.ConnectStorageError, err)
+}
+
+func deleteOnlineDDLExecutionsByTaskId(tx *sql.Tx, taskId uint) error {
+	_, err := tx.Exec("DELETE FROM online_ddl_executions WHERE task_id = ?", taskId)
+	return err
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/errors"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	TaskRehearsalStatusRunning   = "running"
+	TaskRehearsalStatusSucceeded = "succeeded"
+	TaskRehearsalStatusFailed    = "failed"
+)
+
+// TaskRehearsal is the report of rehearsing the SQLs of task on the sandbox instance,
+// the schema of target instance is recreated on the sandbox instance before rehearsal.
+type TaskRehearsal struct {
+	Model
+	TaskId       uint       `json:"task_id" gorm:"index; not null"`
+	InstanceName string     `json:"instance_name"`
+	Status       string     `json:"status" gorm:"default:\"running\""`
+	ErrorMessage string     `json:"error_message" gorm:"type:text"`
+	StartAt      *time.Time `json:"start_at"`
+	EndAt        *time.Time `json:"end_at"`
+
+	SQLs []*RehearsalSQL `json:"-" gorm:"foreignkey:TaskRehearsalId"`
+}
+
+// RehearsalSQL is the result of rehearsing an execute SQL, ExecStatus is the same as the
+// status of execute SQL, the SQLs after the failed SQL are not executed.
+type RehearsalSQL struct {
+	Model
+	TaskRehearsalId uint   `json:"-" gorm:"index; not null"`
+	ExecuteSQLId    uint   `json:"execute_sql_id"`
+	Number          uint   `json:"number"`
+	Content         string `json:"sql" gorm:"type:longtext"`
+	ExecStatus      string `json:"exec_status" gorm:"default:\"initialized\""`
+	ExecResult      string `json:"exec_result" gorm:"type:text"`
+	RowAffects      int64  `json:"row_affects"`
+	ExecDurationMs  int64  `json:"exec_duration_ms"`
+}
+
+const (
+	deleteRehearsalSQLsByTaskIdQuery = "DELETE rehearsal_sqls FROM rehearsal_sqls JOIN task_rehearsals " +
+		"ON rehearsal_sqls.task_rehearsal_id = task_rehearsals.id WHERE task_rehearsals.task_id = ?"
+	deleteTaskRehearsalsByTaskIdQuery = "DELETE FROM task_rehearsals WHERE task_id = ?"
+)
+
+// ReplaceTaskRehearsal removes the previous rehearsal of task and creates the new one.
+func (s *Storage) ReplaceTaskRehearsal(rehearsal *TaskRehearsal) error {
+	return s.Tx(func(tx *gorm.DB) error {
+		if err := tx.Exec(deleteRehearsalSQLsByTaskIdQuery, rehearsal.TaskId).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(deleteTaskRehearsalsByTaskIdQuery, rehearsal.TaskId).Error; err != nil {
+			return err
+		}
+		return tx.Create(rehearsal).Error
+	})
+}
+
+func (s *Storage) GetTaskRehearsalByTaskId(taskId uint) (*TaskRehearsal, bool, error) {
+	rehearsal := &TaskRehearsal{}
+	err := s.db.Where("task_id = ?", taskId).Preload("SQLs", func(db *gorm.DB) *gorm.DB {
+		return db.Order("number ASC")
+	}).Last(rehearsal).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, false, nil
+	}
+	return rehearsal, true, errors.New(errors.ConnectStorageError, err)
+}
+
+func deleteTaskRehearsalsByTaskId(tx *sql.Tx, taskId uint) error {
+	if _, err := tx.Exec(deleteRehearsalSQLsByTaskIdQuery, taskId); err != nil {
+		return err
+	}
+	_, err := tx.Exec(deleteTaskRehearsalsByTaskIdQuery, taskId)
+	return err
+}
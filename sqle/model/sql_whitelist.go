@@ -1,7 +1,11 @@
 package model
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/actiontech/sqle/sqle/errors"
 
@@ -11,6 +15,17 @@ import (
 const (
 	SQLWhitelistExactMatch = "exact_match"
 	SQLWhitelistFPMatch    = "fp_match"
+	// SQLWhitelistRegexMatch matches the whole SQL text with the regular expression, the
+	// expression is anchored at both ends.
+	SQLWhitelistRegexMatch = "regex_match"
+	// SQLWhitelistTableMatch matches the statement type and tables used by SQL,
+	// e.g. "SELECT audit_log" or "INSERT tmp_*".
+	SQLWhitelistTableMatch = "table_match"
+)
+
+const (
+	SQLWhitelistStatusEnabled  = "enabled"
+	SQLWhitelistStatusDisabled = "disabled"
 )
 
 type SqlWhitelist struct {
@@ -23,6 +38,50 @@ type SqlWhitelist struct {
 	// MessageDigest deprecated after 1.1.0, keep it for compatibility.
 	MessageDigest string `json:"message_digest" gorm:"type:char(32) not null comment 'md5 data';" `
 	MatchType     string `json:"match_type" gorm:"default:\"exact_match\""`
+	// InstanceName and InstanceSchema limit the whitelist to the SQL audited on the
+	// instance and schema, empty means no limit.
+	InstanceName   string `json:"instance_name"`
+	InstanceSchema string `json:"instance_schema"`
+	// RuleNames limits the whitelist to waive the specified rules only, the SQL is
+	// still audited by other rules. Empty means the whole SQL is waived.
+	RuleNames RowList `json:"rule_names" gorm:"type:text"`
+	// ExpiredAt is the time the whitelist expires, nil means never. The expired
+	// whitelist is disabled by the server job.
+	ExpiredAt    *time.Time `json:"expired_at"`
+	Status       string     `json:"status" gorm:"default:\"enabled\""`
+	CreateUserId string     `json:"create_user_id"`
+	// Fingerprints caches the fingerprint of Value for each DB type, it is used by
+	// fp_match whitelist so that audit does not parse the whitelist SQL again.
+	Fingerprints SqlWhitelistFingerprints `json:"-" gorm:"type:json"`
+}
+
+type SqlWhitelistFingerprints map[string] /*db type*/ string /*fingerprint*/
+
+// Scan impl sql.Scanner interface
+func (f *SqlWhitelistFingerprints) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal json value: %v", value)
+	}
+	if len(bytes) == 0 {
+		return nil
+	}
+	result := SqlWhitelistFingerprints{}
+	err := json.Unmarshal(bytes, &result)
+	*f = result
+	return err
+}
+
+// Value impl sql.driver.Valuer interface
+func (f SqlWhitelistFingerprints) Value() (driver.Value, error) {
+	if len(f) == 0 {
+		return nil, nil
+	}
+	v, err := json.Marshal(f)
+	return string(v), err
 }
 
 // BeforeSave is a hook implement gorm model before exec create
@@ -41,6 +100,25 @@ func (s SqlWhitelist) TableName() string {
 	return "sql_whitelist"
 }
 
+// IsEffective returns true if the whitelist is enabled and not expired.
+func (s *SqlWhitelist) IsEffective(now time.Time) bool {
+	if s.Status == SQLWhitelistStatusDisabled {
+		return false
+	}
+	return s.ExpiredAt == nil || s.ExpiredAt.After(now)
+}
+
+// InScope returns true if the SQL audited on the instance and schema is in the scope of whitelist.
+func (s *SqlWhitelist) InScope(instanceName, schema string) bool {
+	if s.InstanceName != "" && s.InstanceName != instanceName {
+		return false
+	}
+	if s.InstanceSchema != "" && s.InstanceSchema != schema {
+		return false
+	}
+	return true
+}
+
 // func (s *Storage) GetSqlWhitelistByIdAndProjectName(sqlWhiteId, projectName string) (*SqlWhitelist, bool, error) {
 // 	sqlWhitelist := &SqlWhitelist{}
 // 	err := s.db.Table("sql_whitelist").
@@ -109,6 +187,29 @@ func (s *Storage) GetSqlWhitelistByProjectId(projectId string) ([]SqlWhitelist,
 	return sqlWhitelist, errors.New(errors.ConnectStorageError, err)
 }
 
+func (s *Storage) GetExpiredSqlWhitelist(now time.Time) ([]*SqlWhitelist, error) {
+	sqlWhitelist := []*SqlWhitelist{}
+	err := s.db.Model(&SqlWhitelist{}).
+		Where("status = ?", SQLWhitelistStatusEnabled).
+		Where("expired_at IS NOT NULL AND expired_at <= ?", now).
+		Find(&sqlWhitelist).Error
+	return sqlWhitelist, errors.New(errors.ConnectStorageError, err)
+}
+
+func (s *Storage) UpdateSqlWhitelistFingerprints(id uint, fingerprints SqlWhitelistFingerprints) error {
+	err := s.db.Model(&SqlWhitelist{}).
+		Where("id = ?", id).
+		UpdateColumn("fingerprints", fingerprints).Error
+	return errors.New(errors.ConnectStorageError, err)
+}
+
+func (s *Storage) DisableSqlWhitelist(id uint) error {
+	err := s.db.Model(&SqlWhitelist{}).
+		Where("id = ?", id).
+		Update("status", SQLWhitelistStatusDisabled).Error
+	return errors.New(errors.ConnectStorageError, err)
+}
+
 // func (s *Storage) GetSqlWhitelistTotalByProjectName(projectName string) (uint64, error) {
 // 	var count uint64
 // 	err := s.db.
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqlWhitelist_IsEffective(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.True(t, (&SqlWhitelist{}).IsEffective(now))
+	assert.True(t, (&SqlWhitelist{Status: SQLWhitelistStatusEnabled, ExpiredAt: &future}).IsEffective(now))
+	assert.False(t, (&SqlWhitelist{Status: SQLWhitelistStatusEnabled, ExpiredAt: &past}).IsEffective(now))
+	assert.False(t, (&SqlWhitelist{Status: SQLWhitelistStatusDisabled}).IsEffective(now))
+}
+
+func TestSqlWhitelist_InScope(t *testing.T) {
+	assert.True(t, (&SqlWhitelist{}).InScope("inst_1", "db1"))
+	assert.True(t, (&SqlWhitelist{InstanceName: "inst_1"}).InScope("inst_1", "db1"))
+	assert.False(t, (&SqlWhitelist{InstanceName: "inst_1"}).InScope("inst_2", "db1"))
+	assert.True(t, (&SqlWhitelist{InstanceName: "inst_1", InstanceSchema: "db1"}).InScope("inst_1", "db1"))
+	assert.False(t, (&SqlWhitelist{InstanceName: "inst_1", InstanceSchema: "db1"}).InScope("inst_1", "db2"))
+}
+
+func TestStorage_GetExpiredSqlWhitelist(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	InitMockStorage(mockDB)
+	now := time.Now()
+	mock.ExpectQuery("SELECT * FROM `sql_whitelist`  WHERE `sql_whitelist`.`deleted_at` IS NULL AND ((status = ?) AND (expired_at IS NOT NULL AND expired_at <= ?))").
+		WithArgs(SQLWhitelistStatusEnabled, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "value", "rule_names"}).AddRow(1, "select 1", "rule_1,rule_2"))
+	mock.ExpectClose()
+	whitelist, err := GetStorage().GetExpiredSqlWhitelist(now)
+	assert.NoError(t, err)
+	assert.Len(t, whitelist, 1)
+	assert.Equal(t, RowList{"rule_1", "rule_2"}, whitelist[0].RuleNames)
+	mockDB.Close()
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -27,6 +27,18 @@ const (
 	TaskStatusTerminateSucc    = "terminate_succeeded"
 )
 
+const (
+	TaskBinlogRollbackStatusGenerating = "generating"
+	TaskBinlogRollbackStatusSucceeded  = "succeeded"
+	TaskBinlogRollbackStatusFailed     = "failed"
+)
+
+const (
+	TaskAutoRollbackStatusRollingBack = "rolling_back"
+	TaskAutoRollbackStatusSucceeded   = "succeeded"
+	TaskAutoRollbackStatusFailed      = "failed"
+)
+
 const (
 	TaskSQLSourceFromFormData       = "form_data"
 	TaskSQLSourceFromSQLFile        = "sql_file"
@@ -52,6 +64,12 @@ type Task struct {
 	CreateUserId uint64
 	ExecStartAt  *time.Time
 	ExecEndAt    *time.Time
+	// BinlogRollbackStatus is the status of generating rollback SQLs from binlog after execution,
+	// it is empty if the rollback SQLs are not generated from binlog.
+	BinlogRollbackStatus string
+	// AutoRollbackStatus is the status of rolling back the task automatically after its execution
+	// fails or is terminated, it is empty if the task is not rolled back automatically.
+	AutoRollbackStatus string
 
 	Instance     *Instance
 	ExecuteSQLs  []*ExecuteSQL  `json:"-" gorm:"foreignkey:TaskId"`
@@ -93,6 +111,11 @@ const (
 	SQLExecuteStatusManuallyExecuted = "manually_executed"
 	SQLExecuteStatusTerminateSucc    = "terminate_succeeded"
 	SQLExecuteStatusTerminateFailed  = "terminate_failed"
+	// SQLExecuteStatusSkipped is the failed SQL skipped by executor when resuming the task.
+	SQLExecuteStatusSkipped = "skipped"
+	// SQLExecuteStatusWaitingForConfirm is the DDL paused by the metadata lock check, it is
+	// executed after the executor confirms.
+	SQLExecuteStatusWaitingForConfirm = "waiting_for_confirm"
 )
 
 type BaseSQL struct {
@@ -137,9 +160,21 @@ func (s *BaseSQL) GetExecStatusDesc() string {
 }
 
 type AuditResult struct {
-	Level    string `json:"level"`
-	Message  string `json:"message"`
-	RuleName string `json:"rule_name"`
+	Level         string         `json:"level"`
+	Message       string         `json:"message"`
+	RuleName      string         `json:"rule_name"`
+	FixSuggestion *FixSuggestion `json:"fix_suggestion,omitempty"`
+	// Suppressed is true if the rule is disabled by the inline comment annotation of SQL,
+	// SuppressedBy is the id of the user who submitted the SQL.
+	Suppressed   bool   `json:"suppressed,omitempty"`
+	SuppressedBy string `json:"suppressed_by,omitempty"`
+}
+
+// FixSuggestion is a rewrite of the SQL offered by the rule, it can be applied
+// to replace the original SQL.
+type FixSuggestion struct {
+	Description  string `json:"description"`
+	RewrittenSQL string `json:"rewritten_sql"`
 }
 
 type AuditResults []AuditResult
@@ -164,13 +199,31 @@ func (a *AuditResults) String() string {
 }
 
 func (a *AuditResults) Append(level, ruleName, message string) {
+	a.AppendWithFixSuggestion(level, ruleName, message, nil)
+}
+
+func (a *AuditResults) AppendWithFixSuggestion(level, ruleName, message string, fix *FixSuggestion) {
 	for i := range *a {
 		ar := (*a)[i]
 		if ar.Level == level && ar.RuleName == ruleName && ar.Message == message {
+			if ar.FixSuggestion == nil && fix != nil {
+				(*a)[i].FixSuggestion = fix
+			}
 			return
 		}
 	}
-	*a = append(*a, AuditResult{Level: level, RuleName: ruleName, Message: message})
+	*a = append(*a, AuditResult{Level: level, RuleName: ruleName, Message: message, FixSuggestion: fix})
+}
+
+// FixSuggestions returns the fix suggestions of all the audit results.
+func (a AuditResults) FixSuggestions() []*FixSuggestion {
+	fixes := []*FixSuggestion{}
+	for i := range a {
+		if a[i].FixSuggestion != nil {
+			fixes = append(fixes, a[i].FixSuggestion)
+		}
+	}
+	return fixes
 }
 
 type ExecuteSQL struct {
@@ -182,6 +235,10 @@ type ExecuteSQL struct {
 	AuditFingerprint string `json:"audit_fingerprint" gorm:"index;type:char(32)"`
 	// AuditLevel has four level: error, warn, notice, normal.
 	AuditLevel string `json:"audit_level"`
+	// ReplicaLagGuardResult is the result of checking the replica lag before the SQL is executed.
+	ReplicaLagGuardResult string `json:"replica_lag_guard_result" gorm:"type:text"`
+	// MetadataLockCheckResult records the sessions blocking the DDL found before it is executed.
+	MetadataLockCheckResult string `json:"metadata_lock_check_result" gorm:"type:text"`
 }
 
 func (s ExecuteSQL) TableName() string {
@@ -350,6 +407,12 @@ func (s *Storage) UpdateExecuteSQLs(ExecuteSQLs []*ExecuteSQL) error {
 	return errors.New(errors.ConnectStorageError, tx.Commit().Error)
 }
 
+func (s *Storage) GetRollbackSQLsByTaskId(taskId uint) ([]*RollbackSQL, error) {
+	rollbackSQLs := []*RollbackSQL{}
+	err := s.db.Where("task_id = ?", taskId).Find(&rollbackSQLs).Error
+	return rollbackSQLs, errors.New(errors.ConnectStorageError, err)
+}
+
 func (s *Storage) UpdateRollbackSQLs(rollbackSQLs []*RollbackSQL) error {
 	tx := s.db.Begin()
 	for _, rollbackSQL := range rollbackSQLs {
@@ -401,6 +464,22 @@ func (s *Storage) UpdateExecuteSQLById(executeSQLId string, attrs ...interface{}
 	return errors.New(errors.ConnectStorageError, err)
 }
 
+// GetExecuteSQLStatusById returns the exec status of the SQL.
+func (s *Storage) GetExecuteSQLStatusById(executeSQLId uint) (string, error) {
+	executeSQL := &ExecuteSQL{}
+	err := s.db.Select("exec_status").Where("id = ?", executeSQLId).First(executeSQL).Error
+	return executeSQL.ExecStatus, errors.New(errors.ConnectStorageError, err)
+}
+
+// ConfirmExecuteSQLOfTask confirms to execute the SQL of task waiting for confirmation, it returns
+// false if no SQL is waiting.
+func (s *Storage) ConfirmExecuteSQLOfTask(taskId uint) (bool, error) {
+	db := s.db.Model(&ExecuteSQL{}).
+		Where("task_id = ? AND exec_status = ?", taskId, SQLExecuteStatusWaitingForConfirm).
+		Update("exec_status", SQLExecuteStatusDoing)
+	return db.RowsAffected > 0, errors.New(errors.ConnectStorageError, db.Error)
+}
+
 func (s *Storage) UpdateRollbackSqlStatus(baseSQL *BaseSQL, status, result string) error {
 	attr := map[string]interface{}{}
 	if status != "" {
@@ -449,6 +528,9 @@ type TaskSQLDetail struct {
 	ExecStatus    string         `json:"exec_status"`
 	RollbackSQL   sql.NullString `json:"rollback_sql"`
 	SQLType       sql.NullString `json:"sql_type"`
+
+	ReplicaLagGuardResult   string `json:"replica_lag_guard_result"`
+	MetadataLockCheckResult string `json:"metadata_lock_check_result"`
 }
 
 func (t *TaskSQLDetail) GetAuditResults() string {
@@ -460,7 +542,7 @@ func (t *TaskSQLDetail) GetAuditResults() string {
 }
 
 var taskSQLsQueryTpl = `SELECT e_sql.number, e_sql.description, e_sql.content AS exec_sql,  e_sql.source_file AS sql_source_file, e_sql.start_line AS sql_start_line, e_sql.sql_type, r_sql.content AS rollback_sql,
-e_sql.audit_results, e_sql.audit_level, e_sql.audit_status, e_sql.exec_result, e_sql.exec_status
+e_sql.audit_results, e_sql.audit_level, e_sql.audit_status, e_sql.exec_result, e_sql.exec_status, e_sql.replica_lag_guard_result, e_sql.metadata_lock_check_result
 
 {{- template "body" . -}}
 
@@ -529,7 +611,16 @@ func (s *Storage) DeleteTask(task *Task) error {
 		if err != nil {
 			return err
 		}
-		return nil
+		if err := deleteTaskRehearsalsByTaskId(tx, task.ID); err != nil {
+			return err
+		}
+		if err := deleteOnlineDDLExecutionsByTaskId(tx, task.ID); err != nil {
+			return err
+		}
+		if err := deleteExecuteSQLProgressesByTaskId(tx, task.ID); err != nil {
+			return err
+		}
+		return deleteExecuteSQLChunksByTaskId(tx, task.ID)
 	})
 }
 
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/actiontech/sqle/sqle/errors"
+)
+
+// TaskDependencies is the upstream tasks of the tasks of workflow, the key is the id of
+// downstream task. The downstream task is executed only after all upstream tasks succeed.
+type TaskDependencies map[uint][]uint
+
+// Check checks that all tasks are in the workflow and there is no cycle between them.
+func (d TaskDependencies) Check(taskIds []uint) error {
+	inWorkflow := map[uint]struct{}{}
+	for _, id := range taskIds {
+		inWorkflow[id] = struct{}{}
+	}
+	for taskId, upstreamIds := range d {
+		if _, ok := inWorkflow[taskId]; !ok {
+			return errors.New(errors.DataInvalid, fmt.Errorf("task %d is not in the workflow", taskId))
+		}
+		for _, upstreamId := range upstreamIds {
+			if _, ok := inWorkflow[upstreamId]; !ok {
+				return errors.New(errors.DataInvalid, fmt.Errorf("the upstream task %d of task %d is not in the workflow", upstreamId, taskId))
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := map[uint]int{}
+	var visit func(taskId uint) error
+	visit = func(taskId uint) error {
+		switch states[taskId] {
+		case visiting:
+			return errors.New(errors.DataInvalid, fmt.Errorf("there is a cycle in the dependencies of task %d", taskId))
+		case visited:
+			return nil
+		}
+		states[taskId] = visiting
+		for _, upstreamId := range d[taskId] {
+			if err := visit(upstreamId); err != nil {
+				return err
+			}
+		}
+		states[taskId] = visited
+		return nil
+	}
+	for taskId := range d {
+		if err := visit(taskId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatTaskIds(ids []uint) string {
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, strconv.FormatUint(uint64(id), 10))
+	}
+	return strings.Join(s, ",")
+}
+
+// GetDependsOnTaskIds returns the upstream tasks which must succeed before the task is executed.
+func (r *WorkflowInstanceRecord) GetDependsOnTaskIds() []uint {
+	ids := []uint{}
+	for _, s := range strings.Split(r.DependsOnTaskIds, ",") {
+		if s == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids
+}
+
+// GetTaskDependencies returns the dependencies between the tasks of workflow record.
+func (r *WorkflowRecord) GetTaskDependencies() TaskDependencies {
+	dependencies := TaskDependencies{}
+	for _, inst := range r.InstanceRecords {
+		if ids := inst.GetDependsOnTaskIds(); len(ids) > 0 {
+			dependencies[inst.TaskId] = ids
+		}
+	}
+	return dependencies
+}
+
+// SkipExecuteSQLsByTaskId records the reason on the SQLs of task which are not executed.
+func (s *Storage) SkipExecuteSQLsByTaskId(taskId uint, reason string) error {
+	err := s.db.Exec("UPDATE execute_sql_detail SET exec_result = ? WHERE task_id = ? AND exec_status = ?",
+		reason, taskId, SQLExecuteStatusInitialized).Error
+	return errors.New(errors.ConnectStorageError, err)
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskDependenciesCheck(t *testing.T) {
+	taskIds := []uint{1, 2, 3}
+	assert.NoError(t, TaskDependencies{}.Check(taskIds))
+	assert.NoError(t, TaskDependencies{2: {1}, 3: {1, 2}}.Check(taskIds))
+
+	assert.Error(t, TaskDependencies{4: {1}}.Check(taskIds))
+	assert.Error(t, TaskDependencies{2: {4}}.Check(taskIds))
+	assert.Error(t, TaskDependencies{1: {1}}.Check(taskIds))
+	assert.Error(t, TaskDependencies{1: {3}, 2: {1}, 3: {2}}.Check(taskIds))
+}
+
+func TestWorkflowRecordGetTaskDependencies(t *testing.T) {
+	record := &WorkflowRecord{
+		InstanceRecords: []*WorkflowInstanceRecord{
+			{TaskId: 1},
+			{TaskId: 2, DependsOnTaskIds: formatTaskIds([]uint{1})},
+			{TaskId: 3, DependsOnTaskIds: formatTaskIds([]uint{1, 2})},
+		},
+	}
+	assert.Equal(t, TaskDependencies{2: {1}, 3: {1, 2}}, record.GetTaskDependencies())
+}
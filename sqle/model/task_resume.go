@@ -0,0 +1,128 @@
+package model
+
+import (
+	e "errors"
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	TaskResumeOperationRetry = "retry"
+	TaskResumeOperationEdit  = "edit"
+	TaskResumeOperationSkip  = "skip"
+)
+
+// TaskResumeRecord records how the failed SQL is handled when the failed task is resumed.
+type TaskResumeRecord struct {
+	Model
+	TaskId           uint   `gorm:"index; not null"`
+	ExecuteSQLId     uint   `gorm:"not null"`
+	ExecuteSQLNumber uint   `gorm:"not null"`
+	Operation        string `gorm:"not null"`
+	// OriginalSQL is the failed SQL before it is edited.
+	OriginalSQL     string `gorm:"type:text"`
+	Reason          string `gorm:"type:text"`
+	OperationUserId string
+}
+
+// GetFailedExecuteSQL returns the SQL which the execution of task stops at, it returns nil if
+// there is no such SQL.
+func (t *Task) GetFailedExecuteSQL() *ExecuteSQL {
+	for _, executeSQL := range t.ExecuteSQLs {
+		switch executeSQL.ExecStatus {
+		case SQLExecuteStatusFailed, SQLExecuteStatusTerminateSucc, SQLExecuteStatusTerminateFailed:
+			return executeSQL
+		}
+	}
+	return nil
+}
+
+// CanResumeExecution returns whether the workflow is allowed to go back to executing from
+// exec_failed, by resuming its failed tasks.
+func (w *Workflow) CanResumeExecution() bool {
+	return w.Record != nil && w.Record.Status == WorkflowStatusExecFailed
+}
+
+// errTaskResumed rolls back the transaction of ResumeTask if the task has been resumed by others.
+var errTaskResumed = e.New("task has been resumed")
+
+// ResumeTask prepares the failed SQL to be executed again or skipped by the resume record, and
+// updates the workflow status to executing. The editedSQL is the audited SQL which replaces the
+// failed SQL and editedRollbackSQL is generated for it, they are used by the edit operation only.
+// It returns false if the workflow or the failed SQL has been changed by others, e.g. the task is
+// resumed concurrently.
+func (s *Storage) ResumeTask(workflow *Workflow, failedSQL *ExecuteSQL, record *TaskResumeRecord, editedSQL *ExecuteSQL,
+	editedRollbackSQL *RollbackSQL) (bool, error) {
+	attrs := map[string]interface{}{
+		"exec_status": SQLExecuteStatusInitialized,
+		"exec_result": "",
+	}
+	switch record.Operation {
+	case TaskResumeOperationSkip:
+		attrs["exec_status"] = SQLExecuteStatusSkipped
+		attrs["exec_result"] = fmt.Sprintf("恢复上线时跳过执行，原因: %s", record.Reason)
+	case TaskResumeOperationEdit:
+		attrs["content"] = editedSQL.Content
+		attrs["audit_status"] = editedSQL.AuditStatus
+		attrs["audit_level"] = editedSQL.AuditLevel
+		attrs["audit_fingerprint"] = editedSQL.AuditFingerprint
+		attrs["audit_results"] = editedSQL.AuditResults
+	}
+	resumed := true
+	err := s.Tx(func(tx *gorm.DB) error {
+		// the status is checked again in transaction, only one of the concurrent resumptions succeeds.
+		db := tx.Model(&WorkflowRecord{}).Where("id = ? AND status = ?", workflow.Record.ID, WorkflowStatusExecFailed).
+			Update("status", WorkflowStatusExecuting)
+		if db.Error != nil {
+			return db.Error
+		}
+		if db.RowsAffected == 0 {
+			resumed = false
+			return errTaskResumed
+		}
+		db = tx.Model(&ExecuteSQL{}).Where("id = ? AND exec_status = ?", failedSQL.ID, failedSQL.ExecStatus).Updates(attrs)
+		if db.Error != nil {
+			return db.Error
+		}
+		if db.RowsAffected == 0 {
+			resumed = false
+			return errTaskResumed
+		}
+		// the rollback SQL generated for the original SQL is replaced by the one of edited SQL.
+		if record.Operation == TaskResumeOperationEdit {
+			if err := s.replaceRollbackSQL(tx, failedSQL, editedRollbackSQL); err != nil {
+				return err
+			}
+			// the chunks executed before belong to the original SQL.
+			err := tx.Unscoped().Where("execute_sql_id = ?", failedSQL.ID).Delete(&ExecuteSQLChunk{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Save(record).Error
+	})
+	if !resumed {
+		return false, nil
+	}
+	return true, err
+}
+
+func (s *Storage) replaceRollbackSQL(tx *gorm.DB, executeSQL *ExecuteSQL, rollbackSQL *RollbackSQL) error {
+	db := tx.Model(&RollbackSQL{}).Where("execute_sql_id = ?", executeSQL.ID).Updates(map[string]interface{}{
+		"content":     rollbackSQL.Content,
+		"description": rollbackSQL.Description,
+	})
+	if db.Error != nil || db.RowsAffected > 0 || rollbackSQL.Content == "" {
+		return db.Error
+	}
+	return tx.Create(&RollbackSQL{
+		BaseSQL: BaseSQL{
+			TaskId:      executeSQL.TaskId,
+			Number:      executeSQL.Number,
+			Content:     rollbackSQL.Content,
+			Description: rollbackSQL.Description,
+		},
+		ExecuteSQLId: executeSQL.ID,
+	}).Error
+}
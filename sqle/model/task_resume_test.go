@@ -0,0 +1,70 @@
+package model
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_ResumeTask(t *testing.T) {
+	workflow := &Workflow{Record: &WorkflowRecord{Model: Model{ID: 1}, Status: WorkflowStatusExecFailed}}
+	failedSQL := &ExecuteSQL{BaseSQL: BaseSQL{Model: Model{ID: 2}, TaskId: 3, Number: 1, ExecStatus: SQLExecuteStatusFailed}}
+	newRecord := func() *TaskResumeRecord {
+		return &TaskResumeRecord{TaskId: 3, ExecuteSQLId: 2, ExecuteSQLNumber: 1, Operation: TaskResumeOperationRetry}
+	}
+	expectUpdateWorkflowStatus := func(mock sqlmock.Sqlmock, rows int64) {
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `workflow_records` SET `status` = ?")).
+			WithArgs(WorkflowStatusExecuting, sqlmock.AnyArg(), 1, WorkflowStatusExecFailed).
+			WillReturnResult(sqlmock.NewResult(0, rows))
+	}
+
+	t.Run("resumed", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		InitMockStorage(mockDB)
+		mock.ExpectBegin()
+		expectUpdateWorkflowStatus(mock, 1)
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `execute_sql_detail` SET")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `task_resume_records`")).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		resumed, err := GetStorage().ResumeTask(workflow, failedSQL, newRecord(), nil, nil)
+		assert.NoError(t, err)
+		assert.True(t, resumed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("workflow is resumed by others", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		InitMockStorage(mockDB)
+		mock.ExpectBegin()
+		expectUpdateWorkflowStatus(mock, 0)
+		mock.ExpectRollback()
+
+		resumed, err := GetStorage().ResumeTask(workflow, failedSQL, newRecord(), nil, nil)
+		assert.NoError(t, err)
+		assert.False(t, resumed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed SQL is resumed by others", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		InitMockStorage(mockDB)
+		mock.ExpectBegin()
+		expectUpdateWorkflowStatus(mock, 1)
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `execute_sql_detail` SET")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		resumed, err := GetStorage().ResumeTask(workflow, failedSQL, newRecord(), nil, nil)
+		assert.NoError(t, err)
+		assert.False(t, resumed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
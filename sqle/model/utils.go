@@ -145,6 +145,14 @@ var autoMigrateList = []interface{}{
 	&BlackListAuditPlanSQL{},
 	&CompanyNotice{},
 	&SqlManageEndpoint{},
+	&TaskRehearsal{},
+	&RehearsalSQL{},
+	&OnlineDDLExecution{},
+	&ExecuteSQLProgress{},
+	&ExecuteSQLChunk{},
+	&WorkflowAutoRollback{},
+	&TaskResumeRecord{},
+	&AuditPlanSQLMetric{},
 }
 
 func (s *Storage) AutoMigrate() error {
@@ -19,6 +19,15 @@ type WorkflowTemplate struct {
 	Name                          string
 	Desc                          string
 	AllowSubmitWhenLessAuditLevel string
+	// RehearsalInstanceName is the sandbox instance which the SQLs of workflow are rehearsed on
+	// before approval, the rehearsal is disabled if it is empty.
+	RehearsalInstanceName string
+	// TaskExecutionTimeoutSeconds is the deadline of executing a task of workflow, the task is
+	// terminated if it is exceeded. There is no deadline if it is 0.
+	TaskExecutionTimeoutSeconds uint
+	// EnableAutoRollback rolls back the task automatically when its execution fails or is
+	// terminated, including the committed chunks of the SQL executed in chunks.
+	EnableAutoRollback bool
 
 	Steps []*WorkflowStepTemplate `json:"-" gorm:"foreignkey:workflowTemplateId"`
 	// Instances []*Instance             `gorm:"foreignkey:WorkflowTemplateId"`
@@ -29,6 +38,7 @@ const (
 	WorkflowStepTypeSQLExecute     = "sql_execute"
 	WorkflowStepTypeCreateWorkflow = "create_workflow"
 	WorkflowStepTypeUpdateWorkflow = "update_workflow"
+	WorkflowStepTypeAutoRollback   = "auto_rollback"
 )
 
 type WorkflowStepTemplate struct {
@@ -225,6 +235,8 @@ type WorkflowRecord struct {
 	// 当workflow只有部分数据源已上线时，current step仍处于"sql_execute"步骤
 	CurrentStep *WorkflowStep   `gorm:"foreignkey:CurrentWorkflowStepId"`
 	Steps       []*WorkflowStep `gorm:"foreignkey:WorkflowRecordId"`
+
+	AutoRollbacks []*WorkflowAutoRollback `gorm:"-"`
 }
 
 type WorkflowInstanceRecord struct {
@@ -242,6 +254,9 @@ type WorkflowInstanceRecord struct {
 	Task     *Task     `gorm:"foreignkey:TaskId"`
 	// User     *User     `gorm:"foreignkey:ExecutionUserId"`
 	ExecutionAssignees string
+	// DependsOnTaskIds is the comma separated upstream tasks which must succeed before
+	// the task is executed.
+	DependsOnTaskIds string
 }
 
 func (s *Storage) GetWorkInstanceRecordByTaskId(id string) (instanceRecord WorkflowInstanceRecord, err error) {
@@ -388,7 +403,7 @@ func (w *Workflow) GetTaskIds() []uint {
 	return taskIds
 }
 
-func (s *Storage) CreateWorkflowV2(subject, workflowId, desc string, user *User, tasks []*Task, stepTemplates []*WorkflowStepTemplate, projectId ProjectUID, getOpExecUser func([]*Task) (canAuditUsers [][]*User, canExecUsers [][]*User)) error {
+func (s *Storage) CreateWorkflowV2(subject, workflowId, desc string, user *User, tasks []*Task, taskDependencies TaskDependencies, stepTemplates []*WorkflowStepTemplate, projectId ProjectUID, getOpExecUser func([]*Task) (canAuditUsers [][]*User, canExecUsers [][]*User)) error {
 	if len(tasks) <= 0 {
 		return errors.New(errors.DataConflict, fmt.Errorf("there is no task for creating workflow"))
 	}
@@ -402,6 +417,20 @@ func (s *Storage) CreateWorkflowV2(subject, workflowId, desc string, user *User,
 		}
 	}
 
+	// 任务间的执行顺序仅适用于不同sql模式
+	if len(taskDependencies) > 0 {
+		if workflowMode != WorkflowModeDifferentSQLs {
+			return errors.New(errors.DataConflict, fmt.Errorf("the dependencies between tasks are only allowed in the workflow of different sqls"))
+		}
+		taskIds := make([]uint, 0, len(tasks))
+		for _, task := range tasks {
+			taskIds = append(taskIds, task.ID)
+		}
+		if err := taskDependencies.Check(taskIds); err != nil {
+			return err
+		}
+	}
+
 	// 相同sql模式下，数据源类型必须相同
 	if workflowMode == WorkflowModeSameSQLs && len(tasks) > 1 {
 		dbType := tasks[0].Instance.DbType
@@ -473,6 +502,7 @@ func (s *Storage) CreateWorkflowV2(subject, workflowId, desc string, user *User,
 		for _, instanceRecord := range instanceRecords {
 			instRecord := instanceRecord
 			instRecord.WorkflowRecordId = record.ID
+			instRecord.DependsOnTaskIds = formatTaskIds(taskDependencies[instRecord.TaskId])
 			err = tx.Save(instRecord).Error
 			if err != nil {
 				tx.Rollback()
@@ -535,12 +565,22 @@ func (s *Storage) UpdateWorkflowRecord(w *Workflow, tasks []*Task) error {
 		return e.New("task and instRecord are not equal in length")
 	}
 
+	// the tasks are replaced in order, so are the upstream tasks
+	newTaskIds := make(map[uint]uint, len(tasks))
+	for i, task := range tasks {
+		newTaskIds[instRecords[i].TaskId] = task.ID
+	}
 	instanceRecords := make([]*WorkflowInstanceRecord, len(tasks))
 	for i, task := range tasks {
+		dependsOnTaskIds := []uint{}
+		for _, id := range instRecords[i].GetDependsOnTaskIds() {
+			dependsOnTaskIds = append(dependsOnTaskIds, newTaskIds[id])
+		}
 		instanceRecords[i] = &WorkflowInstanceRecord{
 			TaskId:             task.ID,
 			InstanceId:         task.InstanceId,
 			ExecutionAssignees: instRecords[i].ExecutionAssignees,
+			DependsOnTaskIds:   formatTaskIds(dependsOnTaskIds),
 		}
 	}
 
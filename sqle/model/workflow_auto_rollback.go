@@ -0,0 +1,50 @@
+package model
+
+import (
+	"github.com/actiontech/sqle/sqle/errors"
+)
+
+const (
+	WorkflowAutoRollbackStateSucceeded = "succeeded"
+	WorkflowAutoRollbackStateFailed    = "failed"
+)
+
+// WorkflowAutoRollback is the record of rolling back a task automatically after its
+// execution fails or is terminated, it is shown as a step of workflow record.
+type WorkflowAutoRollback struct {
+	Model
+	WorkflowRecordId uint   `gorm:"index; not null"`
+	TaskId           uint   `gorm:"index; not null"`
+	State            string `gorm:"not null"`
+	Reason           string `gorm:"type:text"`
+}
+
+func (s *Storage) GetWorkflowAutoRollbacksByRecordIds(recordIds []uint) ([]*WorkflowAutoRollback, error) {
+	rollbacks := []*WorkflowAutoRollback{}
+	err := s.db.Where("workflow_record_id IN (?)", recordIds).Order("id ASC").Find(&rollbacks).Error
+	return rollbacks, errors.New(errors.ConnectStorageError, err)
+}
+
+// FillWorkflowAutoRollbacks fills the automatic rollbacks of workflow records.
+func (s *Storage) FillWorkflowAutoRollbacks(records []*WorkflowRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	recordIds := make([]uint, 0, len(records))
+	for _, record := range records {
+		recordIds = append(recordIds, record.ID)
+	}
+	rollbacks, err := s.GetWorkflowAutoRollbacksByRecordIds(recordIds)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		record.AutoRollbacks = []*WorkflowAutoRollback{}
+		for _, rollback := range rollbacks {
+			if rollback.WorkflowRecordId == record.ID {
+				record.AutoRollbacks = append(record.AutoRollbacks, rollback)
+			}
+		}
+	}
+	return nil
+}
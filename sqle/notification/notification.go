@@ -353,6 +353,107 @@ func (a *AuditPlanNotification) NotificationBody() string {
 	return builder.String()
 }
 
+type AuditPlanSQLRegressionNotification struct {
+	auditPlan   *model.AuditPlan
+	regressions []*model.AuditPlanSQLRegression
+	config      AuditPlanNotifyConfig
+}
+
+func NewAuditPlanSQLRegressionNotification(auditPlan *model.AuditPlan, regressions []*model.AuditPlanSQLRegression, config AuditPlanNotifyConfig) *AuditPlanSQLRegressionNotification {
+	return &AuditPlanSQLRegressionNotification{
+		auditPlan:   auditPlan,
+		regressions: regressions,
+		config:      config,
+	}
+}
+
+func (a *AuditPlanSQLRegressionNotification) NotificationSubject() string {
+	return fmt.Sprintf("SQLE扫描任务[%v]发现%v条SQL性能回退", a.auditPlan.Name, len(a.regressions))
+}
+
+func (a *AuditPlanSQLRegressionNotification) NotificationBody() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf(`
+- 扫描任务: %v
+- 审核类型: %v
+- 数据源: %v
+- 数据库名: %v`,
+		a.auditPlan.Name,
+		a.auditPlan.Type,
+		a.auditPlan.InstanceName,
+		a.auditPlan.InstanceDatabase,
+	))
+
+	for _, regression := range a.regressions {
+		builder.WriteString(fmt.Sprintf("\n- SQL指纹: %v", regression.Fingerprint))
+		if regression.Schema != "" {
+			builder.WriteString(fmt.Sprintf("\n  - 库名: %v", regression.Schema))
+		}
+		switch regression.Type {
+		case model.AuditPlanSQLRegressionTypeNew:
+			builder.WriteString("\n  - 回退类型: 新增SQL")
+		case model.AuditPlanSQLRegressionTypeLatency:
+			builder.WriteString(fmt.Sprintf("\n  - 回退类型: 平均执行时间上升，基线: %.6fs，本次: %.6fs",
+				regression.Baseline, regression.Current))
+		case model.AuditPlanSQLRegressionTypeFrequency:
+			builder.WriteString(fmt.Sprintf("\n  - 回退类型: 执行次数上升，基线: %.2f，本次: %.0f",
+				regression.Baseline, regression.Current))
+		}
+	}
+
+	if a.config.SQLEUrl != nil && a.auditPlan.ProjectId != "" {
+		builder.WriteString(fmt.Sprintf("\n- 扫描任务链接: %v/project/%v/auditPlan/detail/%v",
+			strings.TrimRight(*a.config.SQLEUrl, "/"),
+			a.auditPlan.ProjectId,
+			a.auditPlan.Name,
+		))
+	}
+
+	return builder.String()
+}
+
+type SqlWhitelistExpiredNotification struct {
+	whitelist *model.SqlWhitelist
+}
+
+func NewSqlWhitelistExpiredNotification(whitelist *model.SqlWhitelist) *SqlWhitelistExpiredNotification {
+	return &SqlWhitelistExpiredNotification{
+		whitelist: whitelist,
+	}
+}
+
+func (n *SqlWhitelistExpiredNotification) NotificationSubject() string {
+	return fmt.Sprintf("SQLE审核白名单[%v]已过期", n.whitelist.ID)
+}
+
+func (n *SqlWhitelistExpiredNotification) NotificationBody() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf(`
+- 白名单SQL: %v
+- 匹配方式: %v
+- 描述: %v`,
+		n.whitelist.Value,
+		n.whitelist.MatchType,
+		n.whitelist.Desc,
+	))
+	if n.whitelist.ExpiredAt != nil {
+		builder.WriteString(fmt.Sprintf("\n- 过期时间: %v", n.whitelist.ExpiredAt.Format(time.RFC3339)))
+	}
+	if len(n.whitelist.RuleNames) > 0 {
+		builder.WriteString(fmt.Sprintf("\n- 豁免规则: %v", strings.Join(n.whitelist.RuleNames, ", ")))
+	}
+	builder.WriteString("\n白名单已被禁用，如仍需豁免请重新设置过期时间并启用")
+	return builder.String()
+}
+
+// NotifySqlWhitelistExpired notifies the creator of whitelist that it is expired and disabled.
+func NotifySqlWhitelistExpired(whitelist *model.SqlWhitelist) error {
+	if whitelist.CreateUserId == "" {
+		return nil
+	}
+	return Notify(NewSqlWhitelistExpiredNotification(whitelist), []string{whitelist.CreateUserId})
+}
+
 type TestNotify struct {
 }
 
@@ -364,7 +465,22 @@ func (t *TestNotify) NotificationBody() string {
 	return "This is a SQLE test notification\nIf you receive this message, it only means that the message can be pushed"
 }
 
-func NotifyAuditPlan(auditPlanId uint, report *model.AuditPlanReportV2) error {
+type AuditPlanNotifyType int
+
+const (
+	AuditPlanNotifyTypeAuditReport AuditPlanNotifyType = iota
+	AuditPlanNotifyTypeSQLRegression
+)
+
+// AuditPlanNotifyData is the content of audit plan notification, Report is required by
+// AuditPlanNotifyTypeAuditReport and Regressions is required by AuditPlanNotifyTypeSQLRegression.
+type AuditPlanNotifyData struct {
+	Type        AuditPlanNotifyType
+	Report      *model.AuditPlanReportV2
+	Regressions []*model.AuditPlanSQLRegression
+}
+
+func NotifyAuditPlan(auditPlanId uint, data AuditPlanNotifyData) error {
 	s := model.GetStorage()
 	ap, _, err := s.GetAuditPlanById(auditPlanId)
 	if err != nil {
@@ -391,9 +507,17 @@ func NotifyAuditPlan(auditPlanId uint, report *model.AuditPlanReportV2) error {
 		// config.ProjectName = &project.Name
 	}
 
-	if driverV2.RuleLevelLessOrEqual(ap.NotifyLevel, report.AuditLevel) {
-		n := NewAuditPlanNotification(ap, report, config)
-		return GetAuditPlanNotifier().Notify(n, ap)
+	switch data.Type {
+	case AuditPlanNotifyTypeAuditReport:
+		if driverV2.RuleLevelLessOrEqual(ap.NotifyLevel, data.Report.AuditLevel) {
+			n := NewAuditPlanNotification(ap, data.Report, config)
+			return GetAuditPlanNotifier().Notify(n, ap, data.Type)
+		}
+	case AuditPlanNotifyTypeSQLRegression:
+		if len(data.Regressions) > 0 {
+			n := NewAuditPlanSQLRegressionNotification(ap, data.Regressions, config)
+			return GetAuditPlanNotifier().Notify(n, ap, data.Type)
+		}
 	}
 
 	return nil
@@ -405,22 +529,29 @@ func GetAuditPlanNotifier() *AuditPlanNotifier {
 	return stdAuditPlanNotifier
 }
 
+type auditPlanNotifyRecordKey struct {
+	auditPlanName string
+	notifyType    AuditPlanNotifyType
+}
+
 type AuditPlanNotifier struct {
-	lastSend map[string] /*audit plan name*/ time.Time /*last send time*/
+	lastSend map[auditPlanNotifyRecordKey]time.Time /*last send time*/
 	mutex    *sync.RWMutex
 	// emailNotifier *EmailNotifier
 }
 
 func NewAuditPlanNotifier() *AuditPlanNotifier {
 	return &AuditPlanNotifier{
-		lastSend: map[string]time.Time{},
+		lastSend: map[auditPlanNotifyRecordKey]time.Time{},
 		mutex:    &sync.RWMutex{},
 		// emailNotifier: &EmailNotifier{},
 	}
 }
 
-func (n *AuditPlanNotifier) Notify(notification Notification, auditPlan *model.AuditPlan) error {
-	if !n.shouldNotify(auditPlan) {
+// Notify sends the notification if the notify interval of audit plan is passed, the notify
+// interval is counted separately for each notify type.
+func (n *AuditPlanNotifier) Notify(notification Notification, auditPlan *model.AuditPlan, notifyType AuditPlanNotifyType) error {
+	if !n.shouldNotify(auditPlan, notifyType) {
 		return nil
 	}
 
@@ -429,13 +560,13 @@ func (n *AuditPlanNotifier) Notify(notification Notification, auditPlan *model.A
 		return err
 	}
 
-	n.updateRecord(auditPlan.Name)
+	n.updateRecord(auditPlan.Name, notifyType)
 	return nil
 }
 
-func (n *AuditPlanNotifier) shouldNotify(auditPlan *model.AuditPlan) bool {
+func (n *AuditPlanNotifier) shouldNotify(auditPlan *model.AuditPlan, notifyType AuditPlanNotifyType) bool {
 	n.mutex.RLock()
-	last := n.lastSend[auditPlan.Name]
+	last := n.lastSend[auditPlanNotifyRecordKey{auditPlanName: auditPlan.Name, notifyType: notifyType}]
 	n.mutex.RUnlock()
 	return time.Now().After(last.Add(time.Duration(auditPlan.NotifyInterval) * time.Minute))
 }
@@ -472,8 +603,8 @@ func (n *AuditPlanNotifier) sendEmail(notification Notification, user *model.Use
 	})
 }
 
-func (n *AuditPlanNotifier) updateRecord(auditPlanName string) {
+func (n *AuditPlanNotifier) updateRecord(auditPlanName string, notifyType AuditPlanNotifyType) {
 	n.mutex.Lock()
-	n.lastSend[auditPlanName] = time.Now()
+	n.lastSend[auditPlanNotifyRecordKey{auditPlanName: auditPlanName, notifyType: notifyType}] = time.Now()
 	n.mutex.Unlock()
 }
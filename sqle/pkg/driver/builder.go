@@ -20,31 +20,31 @@ type AuditHandler struct {
 }
 
 func (a *AuditHandler) Audit(ctx context.Context, rule *driverV2.Rule, sql string, nextSQL []string) (*driverV2.AuditResult, error) {
+	var ast interface{}
+	if _, ok := a.RuleToASTHandler[rule.Name]; ok && a.SqlParserFn != nil {
+		var err error
+		ast, err = a.SqlParserFn(sql)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse sql")
+		}
+	}
+	return a.AuditWithAST(ctx, rule, sql, ast, nextSQL)
+}
+
+// AuditWithAST audits the sql with the ast parsed by the caller, so the sql can be
+// parsed once for all rules.
+func (a *AuditHandler) AuditWithAST(ctx context.Context, rule *driverV2.Rule, sql string, ast interface{}, nextSQL []string) (*driverV2.AuditResult, error) {
 	result := &driverV2.AuditResult{}
 	message := ""
 	var err error
 
-	handler, ok := a.RuleToRawHandler[rule.Name]
-	if ok {
+	if handler, ok := a.RuleToRawHandler[rule.Name]; ok {
 		message, err = handler(ctx, rule, sql, nextSQL)
-		if err != nil {
-			return nil, errors.Wrapf(err, "audit SQL %s in driver adaptor", sql)
-		}
-	} else {
-		handler, ok := a.RuleToASTHandler[rule.Name]
-		if ok {
-			var ast interface{}
-			if a.SqlParserFn != nil {
-				ast, err = a.SqlParserFn(sql)
-				if err != nil {
-					return nil, errors.Wrap(err, "parse sql")
-				}
-			}
-			message, err = handler(ctx, rule, ast, nextSQL)
-			if err != nil {
-				return nil, errors.Wrapf(err, "audit SQL %s in driver adaptor", sql)
-			}
-		}
+	} else if handler, ok := a.RuleToASTHandler[rule.Name]; ok {
+		message, err = handler(ctx, rule, ast, nextSQL)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "audit SQL %s in driver adaptor", sql)
 	}
 	if message != "" {
 		result.Level = rule.Level
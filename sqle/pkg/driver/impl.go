@@ -14,7 +14,6 @@ import (
 	
 	"github.com/percona/go-mysql/query"
 	"github.com/pkg/errors"
-	"vitess.io/vitess/go/vt/sqlparser"
 )
 
 type DriverImpl struct {
@@ -185,10 +184,7 @@ func (p *DriverImpl) Query(ctx context.Context, query string, conf *driverV2.Que
 }
 
 func (p *DriverImpl) Parse(ctx context.Context, sql string) ([]driverV2.Node, error) {
-	sqls, err := sqlparser.SplitStatementToPieces(sql)
-	if err != nil {
-		return nil, errors.Wrap(err, "split sql")
-	}
+	sqls, err := splitStatements(sql)
 	if err != nil {
 		return nil, errors.Wrapf(err, "split sql %s error", sql)
 	}
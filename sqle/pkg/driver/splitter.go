@@ -0,0 +1,69 @@
+package driver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitStatements splits the sql by semicolons which are not in quotes or comments,
+// the pieces are trimmed and the empty pieces are dropped.
+func splitStatements(sql string) ([]string, error) {
+	pieces := []string{}
+	add := func(piece string) {
+		if piece = strings.TrimSpace(piece); piece != "" {
+			pieces = append(pieces, piece)
+		}
+	}
+	start := 0
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			end := closingQuote(sql, i+1, c)
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed quote %c at position %d", c, i)
+			}
+			i = end
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed comment at position %d", i)
+			}
+			i += end + 3
+		case c == ';':
+			add(sql[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(sql) {
+		add(sql[start:])
+	}
+	return pieces, nil
+}
+
+// closingQuote returns the position of the quote closing the quoted text which starts
+// from pos, the quote is escaped by doubling or backslash. It returns -1 if the quote
+// is not closed.
+func closingQuote(sql string, pos int, quote byte) int {
+	for i := pos; i < len(sql); i++ {
+		switch sql[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
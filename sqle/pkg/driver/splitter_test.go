@@ -0,0 +1,30 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitStatements(t *testing.T) {
+	for _, c := range []struct {
+		sql    string
+		pieces []string
+	}{
+		{"select 1", []string{"select 1"}},
+		{"select 1; select 2;", []string{"select 1", "select 2"}},
+		{" ; ;select 1;;", []string{"select 1"}},
+		{"select ';', \"a;b\", `c;d` from t; select 2", []string{"select ';', \"a;b\", `c;d` from t", "select 2"}},
+		{"select 'it''s;' ; select 'a\\';'", []string{"select 'it''s;'", "select 'a\\';'"}},
+		{"select 1 -- comment;\n; select 2 /* ; */", []string{"select 1 -- comment;", "select 2 /* ; */"}},
+	} {
+		pieces, err := splitStatements(c.sql)
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.pieces, pieces, c.sql)
+	}
+
+	_, err := splitStatements("select 'a; select 2")
+	assert.Error(t, err)
+	_, err = splitStatements("select 1 /* a; select 2")
+	assert.Error(t, err)
+}
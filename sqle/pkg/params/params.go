@@ -38,6 +38,8 @@ func (r *Params) SetParamValue(key, value string) error {
 				_, err = strconv.ParseBool(value)
 			case ParamTypeInt:
 				_, err = strconv.Atoi(value)
+			case ParamTypeFloat64:
+				_, err = strconv.ParseFloat(value, 64)
 			default:
 			}
 			if err != nil {
@@ -0,0 +1,86 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	_ "github.com/jackc/pgx/v4/stdlib"
+)
+
+type DSN struct {
+	Host         string
+	Port         string
+	User         string
+	Password     string
+	DatabaseName string
+}
+
+func (d *DSN) String() string {
+	return fmt.Sprintf("%s:%s/%s", d.Host, d.Port, d.DatabaseName)
+}
+
+type DB struct {
+	db *sql.DB
+}
+
+func NewDB(dsn *DSN) (*DB, error) {
+	if dsn.DatabaseName == "" {
+		dsn.DatabaseName = "postgres"
+	}
+	u := &url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(dsn.User, dsn.Password),
+		Host:   fmt.Sprintf("%s:%s", dsn.Host, dsn.Port),
+		Path:   dsn.DatabaseName,
+	}
+	sqlDB, err := sql.Open("pgx", u.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", dsn.String())
+	}
+	err = sqlDB.Ping()
+	if err != nil {
+		sqlDB.Close()
+		return nil, errors.Wrapf(err, "failed to ping %s", dsn.String())
+	}
+
+	return &DB{db: sqlDB}, nil
+}
+
+func (p *DB) Close() error {
+	return p.db.Close()
+}
+
+// QueryStatStatements returns the top N statements ordered by total execution time from
+// pg_stat_statements, the extension pg_stat_statements must be created.
+func (p *DB) QueryStatStatements(ctx context.Context, topN int) ([]*StatStatement, error) {
+	var versionNum int
+	if err := p.db.QueryRowContext(ctx, "SHOW server_version_num").Scan(&versionNum); err != nil {
+		return nil, errors.Wrap(err, "failed to get server version")
+	}
+	query := fmt.Sprintf(StatStatementsTpl, statStatementsTotalTimeColumn(versionNum), topN)
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", query)
+	}
+	defer rows.Close()
+
+	var ret []*StatStatement
+	for rows.Next() {
+		res := StatStatement{}
+		err = rows.Scan(&res.QueryId, &res.Query, &res.DatabaseName, &res.Calls, &res.TotalTimeMs, &res.Rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan %s", query)
+		}
+		ret = append(ret, &res)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to iterate %s", query)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,79 @@
+package postgresql
+
+// StatStatement ref to https://www.postgresql.org/docs/current/pgstatstatements.html
+type StatStatement struct {
+	QueryId      string  `json:"queryid"`
+	Query        string  `json:"query"`
+	DatabaseName string  `json:"datname"`
+	Calls        int64   `json:"calls"`
+	TotalTimeMs  float64 `json:"total_time_ms"`
+	Rows         int64   `json:"rows"`
+}
+
+// MeanTimeMs returns the mean execution time in milliseconds.
+func (s *StatStatement) MeanTimeMs() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalTimeMs / float64(s.Calls)
+}
+
+// Note:
+// The statistics are kept per user and database, so the same statement may have several rows.
+// The queryid is NULL if compute_query_id is off, the statement is identified by its normalized
+// text in this case.
+const (
+	StatStatementsTpl = `
+	SELECT
+		COALESCE(s.queryid::text, ''),
+		s.query,
+		d.datname,
+		s.calls,
+		s.%[1]v,
+		s.rows
+	FROM
+		pg_stat_statements s
+		JOIN pg_database d ON d.oid = s.dbid
+	WHERE
+		s.calls > 0
+		AND s.query NOT ILIKE '%%pg_stat_statements%%'
+	ORDER BY s.%[1]v DESC
+	LIMIT %[2]v
+	`
+	StatStatementsColumnCalls      = "calls"
+	StatStatementsColumnMeanTimeMs = "mean_time_ms"
+	StatStatementsColumnTotalTime  = "total_time_ms"
+	StatStatementsColumnRows       = "rows"
+)
+
+// statStatementsTotalTimeColumn returns the column of total execution time, it is renamed
+// from total_time to total_exec_time since PostgreSQL 13.
+func statStatementsTotalTimeColumn(serverVersionNum int) string {
+	if serverVersionNum >= 130000 {
+		return "total_exec_time"
+	}
+	return "total_time"
+}
+
+// MergeStatStatements merges the statistics of the same statement by queryid, or by the
+// normalized text if the queryid is unknown. The order of statements is kept.
+func MergeStatStatements(statements []*StatStatement) []*StatStatement {
+	merged := []*StatStatement{}
+	index := map[string]int /*slice subscript*/ {}
+	for _, s := range statements {
+		key := s.QueryId
+		if key == "" {
+			key = s.Query
+		}
+		if i, exist := index[key]; exist {
+			merged[i].Calls += s.Calls
+			merged[i].TotalTimeMs += s.TotalTimeMs
+			merged[i].Rows += s.Rows
+			continue
+		}
+		statement := *s
+		merged = append(merged, &statement)
+		index[key] = len(merged) - 1
+	}
+	return merged
+}
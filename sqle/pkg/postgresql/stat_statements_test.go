@@ -0,0 +1,40 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeStatStatements(t *testing.T) {
+	statements := []*StatStatement{
+		{QueryId: "1", Query: "SELECT * FROM t1 WHERE id = $1", DatabaseName: "db1", Calls: 10, TotalTimeMs: 100, Rows: 10},
+		{QueryId: "2", Query: "UPDATE t1 SET a = $1", DatabaseName: "db1", Calls: 1, TotalTimeMs: 50, Rows: 5},
+		{QueryId: "1", Query: "SELECT * FROM t1 WHERE id = $1", DatabaseName: "db1", Calls: 30, TotalTimeMs: 100, Rows: 30},
+		{QueryId: "", Query: "SELECT 1", DatabaseName: "db2", Calls: 1, TotalTimeMs: 1, Rows: 1},
+		{QueryId: "", Query: "SELECT 1", DatabaseName: "db2", Calls: 3, TotalTimeMs: 3, Rows: 3},
+	}
+	merged := MergeStatStatements(statements)
+	assert.Len(t, merged, 3)
+
+	assert.Equal(t, "1", merged[0].QueryId)
+	assert.Equal(t, int64(40), merged[0].Calls)
+	assert.Equal(t, float64(200), merged[0].TotalTimeMs)
+	assert.Equal(t, int64(40), merged[0].Rows)
+	assert.Equal(t, float64(5), merged[0].MeanTimeMs())
+
+	assert.Equal(t, "2", merged[1].QueryId)
+	assert.Equal(t, int64(1), merged[1].Calls)
+
+	assert.Equal(t, "SELECT 1", merged[2].Query)
+	assert.Equal(t, int64(4), merged[2].Calls)
+
+	// the statements to be merged are not modified
+	assert.Equal(t, int64(10), statements[0].Calls)
+}
+
+func TestStatStatementsTotalTimeColumn(t *testing.T) {
+	assert.Equal(t, "total_time", statStatementsTotalTimeColumn(120005))
+	assert.Equal(t, "total_exec_time", statStatementsTotalTimeColumn(130000))
+	assert.Equal(t, "total_exec_time", statStatementsTotalTimeColumn(160002))
+}
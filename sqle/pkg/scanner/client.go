@@ -2,10 +2,11 @@ package scanner
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"encoding/json"
-	"errors"
+	_errors "errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -17,6 +18,8 @@ import (
 	"github.com/actiontech/sqle/sqle/api/controller"
 	v1 "github.com/actiontech/sqle/sqle/api/controller/v1"
 	v2 "github.com/actiontech/sqle/sqle/api/controller/v2"
+	"github.com/actiontech/sqle/sqle/errors"
+	"github.com/actiontech/sqle/sqle/utils/retry"
 )
 
 // %s = audit plan name
@@ -42,11 +45,29 @@ type (
 	TriggerAuditPlanRes         = v1.TriggerAuditPlanResV1
 )
 
+const (
+	DefaultUploadRetryAttempts = 3
+	DefaultUploadRetryDelay    = time.Second
+	maxUploadRetryDelay        = 30 * time.Second
+)
+
+// ErrUploadSpooled means the SQLs are not uploaded but saved in the spool directory,
+// they will be uploaded with the next upload or FlushSpool.
+var ErrUploadSpooled = _errors.New("sqls are not uploaded and saved in spool dir")
+
 type Client struct {
 	baseURL    string
 	httpClient *client
 	token      string
 	project    string
+
+	// uploadBatchSize is the max number of SQLs in one upload request, SQLs are uploaded
+	// in one request if it is 0.
+	uploadBatchSize     int
+	gzip                bool
+	uploadRetryAttempts uint
+	uploadRetryDelay    time.Duration
+	spool               *spool
 }
 
 func NewSQLEClient(timeout time.Duration, host, port string) *Client {
@@ -56,8 +77,10 @@ func NewSQLEClient(timeout time.Duration, host, port string) *Client {
 	}
 
 	client := &Client{
-		baseURL:    baseURL,
-		httpClient: newClient(timeout, nil),
+		baseURL:             baseURL,
+		httpClient:          newClient(timeout, nil),
+		uploadRetryAttempts: DefaultUploadRetryAttempts,
+		uploadRetryDelay:    DefaultUploadRetryDelay,
 	}
 
 	return client
@@ -75,20 +98,222 @@ func (sc *Client) WithProject(project string) *Client {
 	return &sc2
 }
 
+func (sc *Client) WithUploadBatchSize(size int) *Client {
+	sc.uploadBatchSize = size
+	sc2 := *sc
+	return &sc2
+}
+
+// WithGzip compresses the body of upload request, it requires SQLE to support
+// the request with "Content-Encoding: gzip".
+func (sc *Client) WithGzip(enable bool) *Client {
+	sc.gzip = enable
+	sc2 := *sc
+	return &sc2
+}
+
+// WithUploadRetry sets the retry of upload request, the delay is doubled after each attempt.
+func (sc *Client) WithUploadRetry(attempts uint, delay time.Duration) *Client {
+	sc.uploadRetryAttempts = attempts
+	sc.uploadRetryDelay = delay
+	sc2 := *sc
+	return &sc2
+}
+
+// WithSpoolDir saves the upload requests in the dir before they are uploaded, so the
+// SQLs failed to upload will survive the restart of scanner and be uploaded in order.
+func (sc *Client) WithSpoolDir(dir string) (*Client, error) {
+	s, err := newSpool(dir)
+	if err != nil {
+		return nil, err
+	}
+	sc.spool = s
+	sc2 := *sc
+	return &sc2, nil
+}
+
+// UploadReq uploads the SQLs in batches. If uri is FullUpload, only the first batch is
+// uploaded by FullUpload and the others are uploaded by PartialUpload, so the SQLs of
+// audit plan are the same as uploading them in one request once all batches are uploaded.
+// The batched full sync is not atomic: if a batch fails, the SQLs of audit plan are the
+// first batch and the batches uploaded after it, the SQLs of the last full sync are lost
+// until the rest batches are uploaded by the next upload or FlushSpool. The batch rejected
+// as too large by SQLE is split in half and uploaded again. If the spool dir is set, the
+// SQLs failed to upload are saved and ErrUploadSpooled is returned.
 func (sc *Client) UploadReq(uri string, auditPlanName string, sqlList []*AuditPlanSQLReq) error {
+	reqs := sc.splitUploadReq(uri, auditPlanName, sqlList)
+	if sc.spool == nil {
+		for _, req := range reqs {
+			if err := sc.uploadWithSplit(req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := sc.spool.push(reqs); err != nil {
+		return err
+	}
+	return sc.FlushSpool()
+}
+
+// FlushSpool uploads the requests saved in spool dir in order, it stops at the first
+// request failed to upload and returns ErrUploadSpooled. The request rejected by SQLE
+// is discarded, because it will never succeed. The request too large is replaced by its
+// halves in spool dir, a single SQL too large is kept until SQLE accepts it.
+func (sc *Client) FlushSpool() error {
+	if sc.spool == nil {
+		return nil
+	}
+	sc.spool.mutex.Lock()
+	defer sc.spool.mutex.Unlock()
+
+	names, err := sc.spool.list()
+	if err != nil {
+		return err
+	}
+	for len(names) > 0 {
+		name := names[0]
+		names = names[1:]
+		req, err := sc.spool.load(name)
+		if err == nil {
+			err = sc.uploadWithRetry(req)
+			if err == nil {
+				if err := sc.spool.remove(name); err != nil {
+					return err
+				}
+				continue
+			}
+			if first, second, ok := splitUploadReqInHalf(req); ok && _errors.Is(err, errUploadTooLarge) {
+				halves, err := sc.spool.split(name, first, second)
+				if err != nil {
+					return err
+				}
+				names = append(halves, names...)
+				continue
+			}
+			if !_errors.Is(err, errUploadRejected) {
+				return fmt.Errorf("%w: %v", ErrUploadSpooled, err)
+			}
+		}
+		if err := sc.spool.discard(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sc *Client) splitUploadReq(uri string, auditPlanName string, sqlList []*AuditPlanSQLReq) []*uploadReq {
+	newReq := func(uri string, sqls []*AuditPlanSQLReq) *uploadReq {
+		return &uploadReq{
+			URI:           uri,
+			Project:       sc.project,
+			AuditPlanName: auditPlanName,
+			SQLs:          sqls,
+		}
+	}
+	if sc.uploadBatchSize <= 0 || len(sqlList) <= sc.uploadBatchSize {
+		return []*uploadReq{newReq(uri, sqlList)}
+	}
+
+	reqs := []*uploadReq{}
+	for start := 0; start < len(sqlList); start += sc.uploadBatchSize {
+		end := start + sc.uploadBatchSize
+		if end > len(sqlList) {
+			end = len(sqlList)
+		}
+		batchURI := uri
+		if uri == FullUpload && start > 0 {
+			batchURI = PartialUpload
+		}
+		reqs = append(reqs, newReq(batchURI, sqlList[start:end]))
+	}
+	return reqs
+}
+
+// splitUploadReqInHalf splits the SQLs of request in half, the second half is uploaded by
+// PartialUpload if the request is FullUpload. It returns false if the request has one SQL.
+func splitUploadReqInHalf(req *uploadReq) (*uploadReq, *uploadReq, bool) {
+	if len(req.SQLs) <= 1 {
+		return nil, nil, false
+	}
+	mid := len(req.SQLs) / 2
+	first, second := *req, *req
+	first.SQLs = req.SQLs[:mid]
+	second.SQLs = req.SQLs[mid:]
+	if req.URI == FullUpload {
+		second.URI = PartialUpload
+	}
+	return &first, &second, true
+}
+
+// uploadWithSplit uploads the request, it is split in half and uploaded again if it is
+// too large for SQLE.
+func (sc *Client) uploadWithSplit(req *uploadReq) error {
+	err := sc.uploadWithRetry(req)
+	first, second, ok := splitUploadReqInHalf(req)
+	if !ok || !_errors.Is(err, errUploadTooLarge) {
+		return err
+	}
+	if err := sc.uploadWithSplit(first); err != nil {
+		return err
+	}
+	return sc.uploadWithSplit(second)
+}
+
+var (
+	// errUploadRejected means the upload request is rejected by SQLE, retry is useless.
+	errUploadRejected = _errors.New("upload request is rejected")
+	// errUploadTooLarge means the body of upload request exceeds the limit of SQLE, the
+	// request should be split and retry with the same request is useless.
+	errUploadTooLarge = _errors.New("upload request is too large")
+)
+
+func (sc *Client) uploadWithRetry(req *uploadReq) error {
+	var stopErr error
+	err := retry.Do(func() error {
+		err := sc.upload(req)
+		if _errors.Is(err, errUploadRejected) || _errors.Is(err, errUploadTooLarge) {
+			stopErr = err
+			return nil
+		}
+		return err
+	}, make(chan struct{}),
+		retry.Attempts(sc.uploadRetryAttempts),
+		retry.Delay(sc.uploadRetryDelay),
+		retry.Backoff(2, maxUploadRetryDelay))
+	if stopErr != nil {
+		return stopErr
+	}
+	return err
+}
+
+func (sc *Client) upload(req *uploadReq) error {
 	bodyBuf := &bytes.Buffer{}
 	encoder := json.NewEncoder(bodyBuf)
 	encoder.SetEscapeHTML(false)
 	err := encoder.Encode(&FullSyncAuditPlanSQLsReq{
-		SQLs: sqlList,
+		SQLs: req.SQLs,
 	})
 	if err != nil {
 		return err
 	}
 
-	url := sc.baseURL + fmt.Sprintf(uri, sc.project, auditPlanName)
-	resBody, err := sc.httpClient.sendRequest(context.TODO(), url, http.MethodPost, sc.token, bytes.NewBuffer(bodyBuf.Bytes()))
+	url := sc.baseURL + fmt.Sprintf(req.URI, req.Project, req.AuditPlanName)
+	var resBody []byte
+	if sc.gzip {
+		resBody, err = sc.httpClient.sendGzipRequest(context.TODO(), url, sc.token, bodyBuf.Bytes())
+	} else {
+		resBody, err = sc.httpClient.sendRequest(context.TODO(), url, http.MethodPost, sc.token, bytes.NewBuffer(bodyBuf.Bytes()))
+	}
 	if err != nil {
+		var statusErr *httpStatusError
+		if _errors.As(err, &statusErr) && statusErr.statusCode == http.StatusRequestEntityTooLarge {
+			return fmt.Errorf("%w: %v", errUploadTooLarge, err)
+		}
+		if _errors.As(err, &statusErr) && statusErr.isClientError() {
+			return fmt.Errorf("%w: %v", errUploadRejected, err)
+		}
 		return err
 	}
 
@@ -98,11 +323,26 @@ func (sc *Client) UploadReq(uri string, auditPlanName string, sqlList []*AuditPl
 		return err
 	}
 	if baseRes.Code != 0 {
-		return fmt.Errorf("failed to request %s, error:%s", url, baseRes.Message)
+		err = fmt.Errorf("failed to request %s, error:%s", url, baseRes.Message)
+		if isUploadRejectedCode(baseRes.Code) {
+			return fmt.Errorf("%w: %v", errUploadRejected, err)
+		}
+		return err
 	}
 	return nil
 }
 
+// isUploadRejectedCode returns true if the request is rejected because the audit plan does not
+// exist or the request is invalid. The other errors, such as failing to connect storage, may be
+// recovered later, so the request is retried or kept in spool dir.
+func isUploadRejectedCode(code int) bool {
+	switch errors.ErrorCode(code) {
+	case errors.HttpRequestFormatError, errors.DataNotExist, errors.DataInvalid, errors.DataParseFail:
+		return true
+	}
+	return false
+}
+
 func (sc *Client) TriggerAuditReq(auditPlanName string) (string, error) {
 	url := sc.baseURL + fmt.Sprintf(TriggerAudit, sc.project, auditPlanName)
 
@@ -127,7 +367,7 @@ func (sc *Client) GetAuditReportReq(auditPlanName string, reportID string) error
 	pageIndex, pageSize = 1, 10
 	cursor = pageIndex * pageSize
 	var finalErr error
-	auditError := errors.New("audit result error")
+	auditError := _errors.New("audit result error")
 
 	for {
 		url := sc.baseURL + fmt.Sprintf(GetAuditReport, sc.project, auditPlanName, reportID, pageIndex, pageSize)
@@ -194,12 +434,26 @@ func (c *client) sendRequest(ctx context.Context, url, method, token string, bod
 	case http.MethodGet:
 		return c.get(ctx, url, token)
 	case http.MethodPost:
-		return c.post(ctx, url, token, body)
+		return c.post(ctx, url, token, body, "")
 	default:
 		return nil, fmt.Errorf("invalid request method")
 	}
 }
 
+// sendGzipRequest sends a POST request with the body compressed by gzip
+func (c *client) sendGzipRequest(ctx context.Context, url, token string, body []byte) ([]byte, error) {
+	defer c.CloseIdleConnections()
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(body); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return c.post(ctx, url, token, buf, "gzip")
+}
+
 // get fetch a URL with GET method and returns the response
 func (c *client) get(ctx context.Context, url, token string) ([]byte, error) {
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -222,13 +476,16 @@ func (c *client) get(ctx context.Context, url, token string) ([]byte, error) {
 }
 
 // post send a POST request to the url and returns the response
-func (c *client) post(ctx context.Context, url, token string, body io.Reader) ([]byte, error) {
+func (c *client) post(ctx context.Context, url, token string, body io.Reader, contentEncoding string) ([]byte, error) {
 	req, err := http.NewRequest(http.MethodPost, url, body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", token)
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
 
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -249,7 +506,32 @@ func checkHTTPResponse(res *http.Response) ([]byte, error) {
 		return nil, err
 	}
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		return body, fmt.Errorf("error requesting %s, response: %s, code %d", res.Request.URL, string(body), res.StatusCode)
+		return body, &httpStatusError{
+			url:        res.Request.URL.String(),
+			body:       string(body),
+			statusCode: res.StatusCode,
+		}
 	}
 	return body, nil
 }
+
+type httpStatusError struct {
+	url        string
+	body       string
+	statusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("error requesting %s, response: %s, code %d", e.url, e.body, e.statusCode)
+}
+
+// isClientError returns true if the request is invalid. The request which is unauthorized,
+// timeout, too large or too many is not included, because it may succeed later.
+func (e *httpStatusError) isClientError() bool {
+	switch e.statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusRequestEntityTooLarge,
+		http.StatusTooManyRequests:
+		return false
+	}
+	return e.statusCode >= http.StatusBadRequest && e.statusCode < http.StatusInternalServerError
+}
@@ -0,0 +1,232 @@
+package scanner
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	_errors "errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSQLE struct {
+	mutex   sync.Mutex
+	uris    []string
+	sqls    [][]string
+	gzip    []bool
+	status  int
+	code    int
+	pending int // the number of requests failed before succeeding
+	maxSQLs int // the request with more SQLs is too large
+}
+
+func (m *mockSQLE) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.pending > 0 {
+		m.pending--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if m.status != 0 {
+		w.WriteHeader(m.status)
+		return
+	}
+
+	var body io.Reader = r.Body
+	isGzip := r.Header.Get("Content-Encoding") == "gzip"
+	if isGzip {
+		gr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body = gr
+	}
+	req := &FullSyncAuditPlanSQLsReq{}
+	if err := json.NewDecoder(body).Decode(req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if m.maxSQLs > 0 && len(req.SQLs) > m.maxSQLs {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	sqls := []string{}
+	for _, sql := range req.SQLs {
+		sqls = append(sqls, sql.LastReceiveText)
+	}
+	m.uris = append(m.uris, r.URL.Path)
+	m.sqls = append(m.sqls, sqls)
+	m.gzip = append(m.gzip, isGzip)
+	_, _ = w.Write([]byte(fmt.Sprintf(`{"code": %d, "message": "mock"}`, m.code)))
+}
+
+func newTestClient(t *testing.T, m *mockSQLE) *Client {
+	server := httptest.NewServer(m)
+	t.Cleanup(server.Close)
+	addr := strings.Split(strings.TrimPrefix(server.URL, "http://"), ":")
+	return NewSQLEClient(time.Second, addr[0], addr[1]).WithProject("p1").WithUploadRetry(0, time.Millisecond)
+}
+
+func mockSQLs(n int) []*AuditPlanSQLReq {
+	sqls := make([]*AuditPlanSQLReq, 0, n)
+	for i := 0; i < n; i++ {
+		sqls = append(sqls, &AuditPlanSQLReq{LastReceiveText: fmt.Sprintf("select %d", i), Counter: "1"})
+	}
+	return sqls
+}
+
+func TestClientUploadReqInBatches(t *testing.T) {
+	m := &mockSQLE{}
+	client := newTestClient(t, m).WithUploadBatchSize(2).WithGzip(true)
+
+	assert.NoError(t, client.UploadReq(FullUpload, "ap1", mockSQLs(5)))
+	assert.Equal(t, []string{
+		"/v2/projects/p1/audit_plans/ap1/sqls/full",
+		"/v2/projects/p1/audit_plans/ap1/sqls/partial",
+		"/v2/projects/p1/audit_plans/ap1/sqls/partial",
+	}, m.uris)
+	assert.Equal(t, [][]string{{"select 0", "select 1"}, {"select 2", "select 3"}, {"select 4"}}, m.sqls)
+	assert.Equal(t, []bool{true, true, true}, m.gzip)
+
+	// no batch
+	m = &mockSQLE{}
+	client = newTestClient(t, m)
+	assert.NoError(t, client.UploadReq(FullUpload, "ap1", mockSQLs(5)))
+	assert.Len(t, m.uris, 1)
+	assert.Len(t, m.sqls[0], 5)
+	assert.Equal(t, []bool{false}, m.gzip)
+}
+
+func TestClientUploadReqWithRetry(t *testing.T) {
+	m := &mockSQLE{pending: 2}
+	client := newTestClient(t, m)
+	assert.Error(t, client.UploadReq(PartialUpload, "ap1", mockSQLs(1)))
+
+	m = &mockSQLE{pending: 2}
+	client = newTestClient(t, m).WithUploadRetry(3, time.Millisecond)
+	assert.NoError(t, client.UploadReq(PartialUpload, "ap1", mockSQLs(1)))
+	assert.Len(t, m.uris, 1)
+
+	// the rejected request is not retried
+	m = &mockSQLE{code: int(errors.DataNotExist)}
+	client = newTestClient(t, m).WithUploadRetry(3, time.Millisecond)
+	err := client.UploadReq(PartialUpload, "ap1", mockSQLs(1))
+	assert.True(t, _errors.Is(err, errUploadRejected))
+	assert.Len(t, m.uris, 1)
+
+	// the request failed by SQLE internal error is retried
+	m = &mockSQLE{code: int(errors.ConnectStorageError)}
+	client = newTestClient(t, m).WithUploadRetry(3, time.Millisecond)
+	err = client.UploadReq(PartialUpload, "ap1", mockSQLs(1))
+	assert.Error(t, err)
+	assert.False(t, _errors.Is(err, errUploadRejected))
+	assert.Len(t, m.uris, 3)
+}
+
+func TestClientUploadReqWithSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scanner_spool")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// SQLE is unavailable, the SQLs are saved in spool dir
+	m := &mockSQLE{status: http.StatusServiceUnavailable}
+	client, err := newTestClient(t, m).WithUploadBatchSize(2).WithSpoolDir(dir)
+	assert.NoError(t, err)
+	err = client.UploadReq(FullUpload, "ap1", mockSQLs(3))
+	assert.True(t, _errors.Is(err, ErrUploadSpooled))
+	err = client.UploadReq(PartialUpload, "ap1", mockSQLs(1))
+	assert.True(t, _errors.Is(err, ErrUploadSpooled))
+	names, err := client.spool.list()
+	assert.NoError(t, err)
+	assert.Len(t, names, 3)
+
+	// the spooled SQLs are replayed in order by the new client
+	m = &mockSQLE{}
+	client, err = newTestClient(t, m).WithSpoolDir(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, client.FlushSpool())
+	assert.Equal(t, []string{
+		"/v2/projects/p1/audit_plans/ap1/sqls/full",
+		"/v2/projects/p1/audit_plans/ap1/sqls/partial",
+		"/v2/projects/p1/audit_plans/ap1/sqls/partial",
+	}, m.uris)
+	assert.Equal(t, [][]string{{"select 0", "select 1"}, {"select 2"}, {"select 0"}}, m.sqls)
+	names, err = client.spool.list()
+	assert.NoError(t, err)
+	assert.Len(t, names, 0)
+
+	// the rejected request is discarded
+	m = &mockSQLE{code: int(errors.DataNotExist)}
+	client, err = newTestClient(t, m).WithSpoolDir(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, client.UploadReq(PartialUpload, "ap1", mockSQLs(1)))
+	names, err = client.spool.list()
+	assert.NoError(t, err)
+	assert.Len(t, names, 0)
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0].Name(), spoolFailedFileExt))
+
+	// the request failed by SQLE internal error is kept in spool dir
+	m = &mockSQLE{code: int(errors.ConnectStorageError)}
+	client, err = newTestClient(t, m).WithSpoolDir(dir)
+	assert.NoError(t, err)
+	err = client.UploadReq(PartialUpload, "ap1", mockSQLs(1))
+	assert.True(t, _errors.Is(err, ErrUploadSpooled))
+	names, err = client.spool.list()
+	assert.NoError(t, err)
+	assert.Len(t, names, 1)
+}
+
+func TestClientUploadReqTooLarge(t *testing.T) {
+	// the request too large is split in half and not retried
+	m := &mockSQLE{maxSQLs: 2}
+	client := newTestClient(t, m).WithUploadRetry(3, time.Millisecond)
+	assert.NoError(t, client.UploadReq(FullUpload, "ap1", mockSQLs(5)))
+	assert.Equal(t, []string{
+		"/v2/projects/p1/audit_plans/ap1/sqls/full",
+		"/v2/projects/p1/audit_plans/ap1/sqls/partial",
+		"/v2/projects/p1/audit_plans/ap1/sqls/partial",
+	}, m.uris)
+	assert.Equal(t, [][]string{{"select 0", "select 1"}, {"select 2"}, {"select 3", "select 4"}}, m.sqls)
+
+	dir, err := ioutil.TempDir("", "scanner_spool")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the halves replace the request in spool dir
+	m = &mockSQLE{maxSQLs: 1}
+	client, err = newTestClient(t, m).WithSpoolDir(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, client.UploadReq(PartialUpload, "ap1", mockSQLs(3)))
+	assert.Equal(t, [][]string{{"select 0"}, {"select 1"}, {"select 2"}}, m.sqls)
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 0)
+
+	// a single SQL too large is kept in spool dir
+	m = &mockSQLE{status: http.StatusRequestEntityTooLarge}
+	client, err = newTestClient(t, m).WithSpoolDir(dir)
+	assert.NoError(t, err)
+	err = client.UploadReq(PartialUpload, "ap1", mockSQLs(2))
+	assert.True(t, _errors.Is(err, ErrUploadSpooled))
+	names, err := client.spool.list()
+	assert.NoError(t, err)
+	assert.Len(t, names, 2)
+	assert.True(t, strings.HasSuffix(names[0], "-0"+spoolFileExt))
+}
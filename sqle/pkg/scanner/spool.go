@@ -0,0 +1,121 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	spoolFileExt       = ".json"
+	spoolTmpFileExt    = ".tmp"
+	spoolFailedFileExt = ".failed"
+)
+
+// uploadReq is a batch of SQLs to be uploaded, it is saved in the spool
+// directory until it is uploaded.
+type uploadReq struct {
+	URI           string             `json:"uri"`
+	Project       string             `json:"project"`
+	AuditPlanName string             `json:"audit_plan_name"`
+	SQLs          []*AuditPlanSQLReq `json:"sqls"`
+}
+
+// spool saves the upload requests as files in the directory, the file name
+// starts with the time it is created, so the requests can be replayed in order.
+type spool struct {
+	dir string
+	seq uint64
+	// mutex makes the requests replayed one by one.
+	mutex sync.Mutex
+}
+
+func newSpool(dir string) (*spool, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create spool dir %s failed: %v", dir, err)
+	}
+	return &spool{dir: dir}, nil
+}
+
+func (s *spool) push(reqs []*uploadReq) error {
+	for _, req := range reqs {
+		name := fmt.Sprintf("%019d-%06d%s", time.Now().UnixNano(), atomic.AddUint64(&s.seq, 1)%1000000, spoolFileExt)
+		if err := s.write(name, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *spool) write(name string, req *uploadReq) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first, so a broken file will not be replayed if scannerd exits.
+	tmp := filepath.Join(s.dir, name+spoolTmpFileExt)
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("write spool file failed: %v", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("rename spool file failed: %v", err)
+	}
+	return nil
+}
+
+// split replaces the request with its halves, the names of halves are sorted at the position
+// of the request, e.g. "1-2.json" is replaced by "1-2-0.json" and "1-2-1.json".
+func (s *spool) split(name string, first, second *uploadReq) ([]string, error) {
+	prefix := strings.TrimSuffix(name, spoolFileExt)
+	halves := []string{prefix + "-0" + spoolFileExt, prefix + "-1" + spoolFileExt}
+	if err := s.write(halves[0], first); err != nil {
+		return nil, err
+	}
+	if err := s.write(halves[1], second); err != nil {
+		return nil, err
+	}
+	return halves, s.remove(name)
+}
+
+// list returns the names of spooled requests in the order they are pushed.
+func (s *spool) list() ([]string, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read spool dir %s failed: %v", s.dir, err)
+	}
+	names := []string{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), spoolFileExt) {
+			continue
+		}
+		names = append(names, file.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *spool) load(name string) (*uploadReq, error) {
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	req := &uploadReq{}
+	return req, json.Unmarshal(data, req)
+}
+
+func (s *spool) remove(name string) error {
+	return os.Remove(filepath.Join(s.dir, name))
+}
+
+// discard keeps the request which can not be uploaded for troubleshooting,
+// and it will not be replayed.
+func (s *spool) discard(name string) error {
+	return os.Rename(filepath.Join(s.dir, name), filepath.Join(s.dir, name+spoolFailedFileExt))
+}
@@ -140,76 +140,143 @@ func hookAudit(l *logrus.Entry, task *model.Task, p driver.Plugin, hook AuditHoo
 		return err
 	}
 
-	auditSqls := []*model.ExecuteSQL{}
-	sqls := []string{}
-	nodes := []driverV2.Node{}
-	for _, executeSQL := range task.ExecuteSQLs {
-		// We always trust the ExecuteSQL.Content is single SQL.
-		//
-		// The audit() function has two producers for now:
-		// 1. from API controller
-		//		- the API controller should call Parse before audit.
-		//      - If Parse() can not splits SQL to expected case, user can add SQL to whitelist for workaround.
-		// 2. from audit plan
-		//		- the audit plan may collect SQLs which plugins can not Parse.
-		//      - In these case, we pass the raw SQL to plugins, it's ok.
-		node, err := parse(l, p, strings.TrimSpace(executeSQL.Content))
-		if err != nil {
-			return err
-		}
-		var whitelistMatch bool
-		for _, wl := range whitelist {
-			if wl.MatchType == model.SQLWhitelistFPMatch {
-				wlNode, err := parse(l, p, wl.Value)
-				if err != nil {
-					l.Errorf("parse whitelist sql error: %v,please check the accuracy of whitelist SQL: %s", err, wl.Value)
-				}
-				if node.Fingerprint == wlNode.Fingerprint {
+	whitelistMatcher := newSqlWhitelistMatcher(l, task, p, whitelist)
+	// the rules disabled by inline comment annotations are waived by the user who submitted the SQL,
+	// SQLs collected by audit plan have no submitter.
+	submitter := ""
+	if task.CreateUserId != 0 {
+		submitter = fmt.Sprintf("%d", task.CreateUserId)
+	}
+	// the task built from SQLs directly has no db type.
+	dbType := task.DBType
+	if dbType == "" && task.Instance != nil {
+		dbType = task.Instance.DbType
+	}
+
+	// audit SQLs batch by batch, each batch is built when it is required by the plugin and its
+	// results are handled and saved as soon as they are returned, so a huge task is neither kept
+	// in memory as a whole nor waits for the whole task.
+	var batch *auditBatch
+	next := 0
+	source := func() ([]string, error) {
+		batch = &auditBatch{}
+		for next < len(task.ExecuteSQLs) && len(batch.sqls) < driver.DefaultAuditStreamBatchSize {
+			executeSQL := task.ExecuteSQLs[next]
+			next++
+			// We always trust the ExecuteSQL.Content is single SQL.
+			//
+			// The audit() function has two producers for now:
+			// 1. from API controller
+			//		- the API controller should call Parse before audit.
+			//      - If Parse() can not splits SQL to expected case, user can add SQL to whitelist for workaround.
+			// 2. from audit plan
+			//		- the audit plan may collect SQLs which plugins can not Parse.
+			//      - In these case, we pass the raw SQL to plugins, it's ok.
+			node, err := parse(l, p, strings.TrimSpace(executeSQL.Content))
+			if err != nil {
+				return nil, err
+			}
+			var whitelistMatch bool
+			// ruleScopedWhitelist waives the specified rules of SQL only, the SQL is still audited.
+			var ruleScopedWhitelist []model.SqlWhitelist
+			for _, wl := range whitelistMatcher.match(node) {
+				if len(wl.RuleNames) == 0 {
 					whitelistMatch = true
+				} else {
+					ruleScopedWhitelist = append(ruleScopedWhitelist, wl)
 				}
-			} else {
-				if wl.CapitalizedValue == strings.ToUpper(node.Text) {
-					whitelistMatch = true
+			}
+			if whitelistMatch {
+				result := driverV2.NewAuditResults()
+				result.Add(driverV2.RuleLevelNormal, "", "白名单")
+				executeSQL.AuditStatus = model.SQLAuditStatusFinished
+				executeSQL.AuditLevel = string(result.Level())
+				executeSQL.AuditFingerprint = utils.Md5String(string(append([]byte(result.Message()), []byte(node.Fingerprint)...)))
+				appendExecuteSqlResults(executeSQL, result)
+				batch.whitelistedSQLs = append(batch.whitelistedSQLs, executeSQL)
+				if len(batch.whitelistedSQLs) >= driver.DefaultAuditStreamBatchSize {
+					if err := saveAuditedSQLs(batch.whitelistedSQLs); err != nil {
+						return nil, err
+					}
+					batch.whitelistedSQLs = nil
 				}
+			} else {
+				batch.auditSQLs = append(batch.auditSQLs, executeSQL)
+				batch.sqls = append(batch.sqls, executeSQL.Content)
+				batch.nodes = append(batch.nodes, node)
+				batch.suppressions = append(batch.suppressions, driverV2.ParseRuleSuppression(executeSQL.Content, dbType))
+				batch.whitelistSuppressions = append(batch.whitelistSuppressions, ruleScopedWhitelist)
 			}
 		}
-		if whitelistMatch {
-			result := driverV2.NewAuditResults()
-			result.Add(driverV2.RuleLevelNormal, "", "白名单")
-			executeSQL.AuditStatus = model.SQLAuditStatusFinished
-			executeSQL.AuditLevel = string(result.Level())
-			executeSQL.AuditFingerprint = utils.Md5String(string(append([]byte(result.Message()), []byte(node.Fingerprint)...)))
-			appendExecuteSqlResults(executeSQL, result)
-		} else {
-			auditSqls = append(auditSqls, executeSQL)
-			sqls = append(sqls, executeSQL.Content)
-			nodes = append(nodes, node)
+		// the stream is finished if there are no SQLs to be audited, the whitelisted SQLs are
+		// saved here since there is no handler called for them.
+		if len(batch.sqls) == 0 {
+			return nil, saveAuditedSQLs(batch.whitelistedSQLs)
 		}
-	}
-	for _, sql := range auditSqls {
-		hook.BeforeAudit(sql)
+		for _, sql := range batch.auditSQLs {
+			hook.BeforeAudit(sql)
+		}
+		return batch.sqls, nil
 	}
 
-	results, err := p.Audit(context.TODO(), sqls)
+	err = p.AuditStream(context.TODO(), source, func(results []*driverV2.AuditResults) error {
+		CustomRuleAudit(l, task, batch.sqls, results, customRules)
+		for i, sql := range batch.auditSQLs {
+			hook.AfterAudit(sql)
+			suppression := batch.suppressions[i]
+			suppressedRules := suppressAuditResults(results[i], suppression)
+			whitelistSuppressedRules := make([]map[string]struct{}, len(batch.whitelistSuppressions[i]))
+			for j, wl := range batch.whitelistSuppressions[i] {
+				whitelistSuppressedRules[j] = suppressAuditResults(results[i], newWhitelistSuppression(wl))
+			}
+			sql.AuditStatus = model.SQLAuditStatusFinished
+			sql.AuditLevel = string(results[i].Level())
+			sql.AuditFingerprint = utils.Md5String(string(append([]byte(results[i].Message()), []byte(batch.nodes[i].Fingerprint)...)))
+			appendExecuteSqlResults(sql, results[i])
+			recordRuleSuppression(sql, suppression, suppressedRules, "注释", submitter)
+			for j, wl := range batch.whitelistSuppressions[i] {
+				recordRuleSuppression(sql, newWhitelistSuppression(wl), whitelistSuppressedRules[j], "白名单", wl.CreateUserId)
+			}
+		}
+		return saveAuditedSQLs(append(batch.whitelistedSQLs, batch.auditSQLs...))
+	})
 	if err != nil {
 		return err
 	}
-	if len(results) != len(sqls) {
-		return fmt.Errorf("audit results [%d] does not match the number of SQL [%d]", len(results), len(sqls))
-	}
-	CustomRuleAudit(l, task, sqls, results, customRules)
-	for i, sql := range auditSqls {
-		hook.AfterAudit(sql)
-		sql.AuditStatus = model.SQLAuditStatusFinished
-		sql.AuditLevel = string(results[i].Level())
-		sql.AuditFingerprint = utils.Md5String(string(append([]byte(results[i].Message()), []byte(nodes[i].Fingerprint)...)))
-		appendExecuteSqlResults(sql, results[i])
-	}
 
 	ReplenishTaskStatistics(task)
 	return nil
 }
 
+// auditBatch is a batch of SQLs of task audited by AuditStream.
+type auditBatch struct {
+	// auditSQLs are audited by plugin, the other fields are in the same order.
+	auditSQLs             []*model.ExecuteSQL
+	sqls                  []string
+	nodes                 []driverV2.Node
+	suppressions          []*driverV2.RuleSuppression
+	whitelistSuppressions [][]model.SqlWhitelist
+	// whitelistedSQLs are not audited since they match the whitelist, they are saved with the
+	// audited SQLs of the batch.
+	whitelistedSQLs []*model.ExecuteSQL
+}
+
+// saveAuditedSQLs saves the audit results of a batch of SQLs. SQLs which are not
+// stored yet are skipped, such as the SQLs of a temporary task built by audit plan,
+// their results are saved by the caller after audit.
+func saveAuditedSQLs(executeSQLs []*model.ExecuteSQL) error {
+	storedSQLs := make([]*model.ExecuteSQL, 0, len(executeSQLs))
+	for _, executeSQL := range executeSQLs {
+		if executeSQL.ID != 0 {
+			storedSQLs = append(storedSQLs, executeSQL)
+		}
+	}
+	if len(storedSQLs) == 0 {
+		return nil
+	}
+	return model.GetStorage().UpdateExecuteSQLs(storedSQLs)
+}
+
 func ReplenishTaskStatistics(task *model.Task) {
 	var normalCount float64
 	maxAuditLevel := driverV2.RuleLevelNull
@@ -338,10 +405,16 @@ func genRollbackSQL(l *logrus.Entry, task *model.Task, p driver.Plugin) ([]*mode
 		executeSQL.AuditLevel = string(result.Level())
 		appendExecuteSqlResults(executeSQL, result)
 
+		// the reason returned with rollback SQL means the rollback is lossy, e.g. DROP PARTITION.
+		description := ""
+		if rollbackSQL != "" {
+			description = reason
+		}
 		rollbackSQLs = append(rollbackSQLs, &model.RollbackSQL{
 			BaseSQL: model.BaseSQL{
-				TaskId:  executeSQL.TaskId,
-				Content: rollbackSQL,
+				TaskId:      executeSQL.TaskId,
+				Content:     rollbackSQL,
+				Description: description,
 			},
 			ExecuteSQLId: executeSQL.ID,
 		})
@@ -349,9 +422,77 @@ func genRollbackSQL(l *logrus.Entry, task *model.Task, p driver.Plugin) ([]*mode
 	return rollbackSQLs, nil
 }
 
+// newWhitelistSuppression returns the rules waived by the rule scoped whitelist.
+func newWhitelistSuppression(wl model.SqlWhitelist) *driverV2.RuleSuppression {
+	suppression := &driverV2.RuleSuppression{Rules: map[string]struct{}{}}
+	for _, rule := range wl.RuleNames {
+		suppression.Rules[rule] = struct{}{}
+	}
+	return suppression
+}
+
+// suppressAuditResults downgrades the results of the rules which are disabled by the
+// inline comment annotations of SQL or the rule scoped whitelist to normal level, and
+// returns the rules whose results are downgraded. The annotations are applied here for
+// all plugins rather than skipping the rules in plugins, so the waived results can be
+// recorded.
+func suppressAuditResults(result *driverV2.AuditResults, suppression *driverV2.RuleSuppression) map[string] /*rule name*/ struct{} {
+	suppressed := map[string]struct{}{}
+	if suppression == nil {
+		return suppressed
+	}
+	for _, ar := range result.Results {
+		if ar.Level.More(driverV2.RuleLevelNormal) && suppression.IsSuppressed(ar.RuleName, ar.Level) {
+			ar.Level = driverV2.RuleLevelNormal
+			suppressed[ar.RuleName] = struct{}{}
+		}
+	}
+	result.SortByLevel()
+	return suppressed
+}
+
+// recordRuleSuppression marks the waived results of executeSQL and records which rules
+// are waived by whom and how, so that reviewers can see it. The suppressedRules are the
+// rules whose results are downgraded by suppressAuditResults, nothing is recorded if it
+// is empty.
+func recordRuleSuppression(executeSQL *model.ExecuteSQL, suppression *driverV2.RuleSuppression,
+	suppressedRules map[string]struct{}, source, userId string) {
+	if suppression == nil || len(suppressedRules) == 0 {
+		return
+	}
+	for i := range executeSQL.AuditResults {
+		if _, ok := suppressedRules[executeSQL.AuditResults[i].RuleName]; ok {
+			executeSQL.AuditResults[i].Suppressed = true
+			executeSQL.AuditResults[i].SuppressedBy = userId
+		}
+	}
+	message := fmt.Sprintf("已通过%s豁免全部规则", source)
+	if !suppression.AllRules {
+		message = fmt.Sprintf("已通过%s豁免规则: %s", source, strings.Join(suppression.RuleNames(), ", "))
+	}
+	for _, ar := range executeSQL.AuditResults {
+		if ar.RuleName == "" && ar.Message == message {
+			return
+		}
+	}
+	executeSQL.AuditResults = append(executeSQL.AuditResults, model.AuditResult{
+		Level:        string(driverV2.RuleLevelNormal),
+		Message:      message,
+		Suppressed:   true,
+		SuppressedBy: userId,
+	})
+}
+
 func appendExecuteSqlResults(executeSQL *model.ExecuteSQL, result *driverV2.AuditResults) {
 	for i := range result.Results {
 		ar := result.Results[i]
-		executeSQL.AuditResults.Append(string(ar.Level), ar.RuleName, ar.Message)
+		var fix *model.FixSuggestion
+		if ar.FixSuggestion != nil {
+			fix = &model.FixSuggestion{
+				Description:  ar.FixSuggestion.Description,
+				RewrittenSQL: ar.FixSuggestion.RewrittenSQL,
+			}
+		}
+		executeSQL.AuditResults.AppendWithFixSuggestion(string(ar.Level), ar.RuleName, ar.Message, fix)
 	}
 }
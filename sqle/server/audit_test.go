@@ -0,0 +1,71 @@
+package server
+
+import (
+	"testing"
+
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRuleSuppression(t *testing.T) {
+	suppression := driverV2.ParseRuleSuppression("/* sqle:disable=a, b */ select 1 -- sqle:disable-next-line=c", driverV2.DriverTypeMySQL)
+	assert.False(t, suppression.AllRules)
+	assert.Equal(t, []string{"a", "b", "c"}, suppression.RuleNames())
+	assert.False(t, suppression.IsSuppressed("", driverV2.RuleLevelNotice))
+	assert.True(t, suppression.IsSuppressed("a", driverV2.RuleLevelWarn))
+	// the rule of error level can not be disabled by annotation
+	assert.False(t, suppression.IsSuppressed("a", driverV2.RuleLevelError))
+	assert.Nil(t, driverV2.ParseRuleSuppression("select 1 /* disable=a */", driverV2.DriverTypeMySQL))
+	assert.True(t, driverV2.ParseRuleSuppression("-- sqle:disable-next-line\nselect 1", driverV2.DriverTypeMySQL).AllRules)
+
+	suppression = driverV2.ParseRuleSuppression("# sqle:disable=a\nselect 1", driverV2.DriverTypeMySQL)
+	assert.Equal(t, []string{"a"}, suppression.RuleNames())
+	assert.Nil(t, driverV2.ParseRuleSuppression("select '# sqle:disable=a'", driverV2.DriverTypeMySQL))
+	assert.Nil(t, driverV2.ParseRuleSuppression("select 1 from t1 where v1 = '/* sqle:disable */'", driverV2.DriverTypeMySQL))
+	// "#" is not a comment of PostgreSQL
+	assert.Nil(t, driverV2.ParseRuleSuppression("select 1 # sqle:disable=a", driverV2.DriverTypePostgreSQL))
+	assert.Equal(t, []string{"a"}, driverV2.ParseRuleSuppression("select 1 -- sqle:disable=a", driverV2.DriverTypePostgreSQL).RuleNames())
+}
+
+func TestRecordRuleSuppression(t *testing.T) {
+	newResults := func() *driverV2.AuditResults {
+		result := driverV2.NewAuditResults()
+		result.Add(driverV2.RuleLevelWarn, "a", "warn a")
+		result.Add(driverV2.RuleLevelError, "b", "error b")
+		result.Add(driverV2.RuleLevelNormal, "c", "normal c")
+		return result
+	}
+	audit := func(suppression *driverV2.RuleSuppression) *model.ExecuteSQL {
+		result := newResults()
+		suppressed := suppressAuditResults(result, suppression)
+		sql := &model.ExecuteSQL{}
+		appendExecuteSqlResults(sql, result)
+		recordRuleSuppression(sql, suppression, suppressed, "注释", "1")
+		return sql
+	}
+
+	// the warn result is waived, the error result is kept
+	sql := audit(driverV2.ParseRuleSuppression("/* sqle:disable */ select 1", driverV2.DriverTypeMySQL))
+	assert.Len(t, sql.AuditResults, 4)
+	for _, ar := range sql.AuditResults {
+		switch ar.RuleName {
+		case "a":
+			assert.True(t, ar.Suppressed)
+			assert.Equal(t, "1", ar.SuppressedBy)
+			assert.Equal(t, string(driverV2.RuleLevelNormal), ar.Level)
+		case "b", "c":
+			assert.False(t, ar.Suppressed)
+		case "":
+			assert.Equal(t, "已通过注释豁免全部规则", ar.Message)
+		}
+	}
+
+	// nothing is recorded if no result is waived
+	sql = audit(driverV2.ParseRuleSuppression("/* sqle:disable=b,c,d */ select 1", driverV2.DriverTypeMySQL))
+	assert.Len(t, sql.AuditResults, 3)
+	for _, ar := range sql.AuditResults {
+		assert.False(t, ar.Suppressed)
+	}
+}
@@ -54,7 +54,10 @@ func audit(auditPlanId uint, task Task) (*model.AuditPlanReportV2, error) {
 		}
 	}()
 
-	return auditPlanReport, notification.NotifyAuditPlan(auditPlanId, auditPlanReport)
+	return auditPlanReport, notification.NotifyAuditPlan(auditPlanId, notification.AuditPlanNotifyData{
+		Type:   notification.AuditPlanNotifyTypeAuditReport,
+		Report: auditPlanReport,
+	})
 }
 
 func Audit(entry *logrus.Entry, ap *model.AuditPlan) (*model.AuditPlanReportV2, error) {
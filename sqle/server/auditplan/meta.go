@@ -32,6 +32,9 @@ const (
 	TypeAllAppExtract         = "all_app_extract"
 	TypeBaiduRdsMySQLSlowLog  = "baidu_rds_mysql_slow_log"
 	TypeSQLFile               = "sql_file"
+
+	TypePostgreSQLPgStatStatements   = "postgresql_pg_stat_statements"
+	TypeMySQLPerformanceSchemaDigest = "mysql_performance_schema_digest"
 )
 
 const (
@@ -39,6 +42,8 @@ const (
 	InstanceTypeMySQL  = "MySQL"
 	InstanceTypeOracle = "Oracle"
 	InstanceTypeTiDB   = "TiDB"
+
+	InstanceTypePostgreSQL = "PostgreSQL"
 )
 
 const (
@@ -54,8 +59,21 @@ const (
 	paramKeyFirstSqlsScrappedInLastPeriodHours  = "first_sqls_scrapped_in_last_period_hours"
 	paramKeyProjectId                           = "project_id"
 	paramKeyRegion                              = "region"
+	paramKeyTopN                                = "top_n"
+	paramKeyRegressionRatio                     = "regression_ratio"
 )
 
+// newRegressionRatioParam returns the param shared by the audit plans which detect the
+// regressions of SQLs.
+func newRegressionRatioParam() *params.Param {
+	return &params.Param{
+		Key:   paramKeyRegressionRatio,
+		Desc:  "SQL 性能回退告警倍数（执行次数或平均执行时间超过基线的倍数，0 表示不检测）",
+		Value: "0",
+		Type:  params.ParamTypeFloat64,
+	}
+}
+
 var Metas = []Meta{
 	{
 		Type:         TypeDefault,
@@ -86,6 +104,7 @@ var Metas = []Meta{
 				Value: "0",
 				Type:  params.ParamTypeInt,
 			},
+			newRegressionRatioParam(),
 		},
 		CreateTask: NewSlowLogTask,
 	},
@@ -139,6 +158,27 @@ var Metas = []Meta{
 				Value: "0",
 				Type:  params.ParamTypeInt,
 			},
+			newRegressionRatioParam(),
+		},
+	},
+	{
+		Type:         TypeMySQLPerformanceSchemaDigest,
+		Desc:         "performance_schema 语句摘要",
+		InstanceType: InstanceTypeMySQL,
+		CreateTask:   NewMySQLPerformanceSchemaDigestTask,
+		Params: []*params.Param{
+			{
+				Key:   paramKeyCollectIntervalMinute,
+				Desc:  "采集周期（分钟）",
+				Value: "10",
+				Type:  params.ParamTypeInt,
+			},
+			{
+				Key:   paramKeyAuditSQLsScrappedInLastPeriodMinute,
+				Desc:  "审核过去时间段内抓取的SQL（分钟）",
+				Value: "0",
+				Type:  params.ParamTypeInt,
+			},
 		},
 	},
 	{
@@ -332,7 +372,7 @@ var Metas = []Meta{
 				Type:  params.ParamTypeInt,
 			},
 			{
-				Key:   "top_n",
+				Key:   paramKeyTopN,
 				Desc:  "Top N",
 				Value: "3",
 				Type:  params.ParamTypeInt,
@@ -343,6 +383,7 @@ var Metas = []Meta{
 				Value: oracle.DynPerformanceViewSQLAreaColumnElapsedTime,
 				Type:  params.ParamTypeString,
 			},
+			newRegressionRatioParam(),
 		},
 	},
 	{
@@ -371,6 +412,32 @@ var Metas = []Meta{
 		InstanceType: InstanceTypeAll,
 		CreateTask:   NewDefaultTask,
 	},
+	{
+		Type:         TypePostgreSQLPgStatStatements,
+		Desc:         "PostgreSQL pg_stat_statements",
+		InstanceType: InstanceTypePostgreSQL,
+		CreateTask:   NewPostgreSQLPgStatStatementsTask,
+		Params: []*params.Param{
+			{
+				Key:   paramKeyCollectIntervalMinute,
+				Desc:  "采集周期（分钟）",
+				Value: "60",
+				Type:  params.ParamTypeInt,
+			},
+			{
+				Key:   paramKeyTopN,
+				Desc:  "采集总执行时间最长的 Top N 条 SQL",
+				Value: "100",
+				Type:  params.ParamTypeInt,
+			},
+			{
+				Key:   paramKeyAuditSQLsScrappedInLastPeriodMinute,
+				Desc:  "审核过去时间段内抓取的SQL（分钟）",
+				Value: "0",
+				Type:  params.ParamTypeInt,
+			},
+		},
+	},
 }
 
 var MetaMap = map[string]Meta{}
@@ -0,0 +1,264 @@
+package auditplan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/dms"
+	"github.com/actiontech/sqle/sqle/driver/mysql/executor"
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/model"
+	"github.com/actiontech/sqle/sqle/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MySQLPerformanceSchemaDigestTask implement the Task interface.
+//
+// MySQLPerformanceSchemaDigestTask is a loop task which collects the statements from
+// performance_schema.events_statements_summary_by_digest. The statistics of digest table are
+// accumulated since the server starts, so the task records the deltas between collections.
+type MySQLPerformanceSchemaDigestTask struct {
+	*sqlCollector
+
+	// lastDigests is the statistics of last collection, the first collection after the task
+	// starts is taken as the baseline and nothing is recorded.
+	lastDigests map[string] /* schema and digest */ *digestStatement
+}
+
+type digestStatement struct {
+	schema     string
+	digest     string
+	digestText string
+	// the counters are BIGINT UNSIGNED in performance_schema, SUM_TIMER_WAIT in picoseconds
+	// may exceed the max int64 on a busy server.
+	count        uint64
+	timerWait    uint64 // picoseconds
+	rowsExamined uint64
+	noIndexUsed  uint64
+}
+
+// newDigestStatement returns error if the counters of the digest row are invalid.
+func newDigestStatement(row map[string]sql.NullString) (*digestStatement, error) {
+	d := &digestStatement{
+		schema:     row["schema_name"].String,
+		digest:     row["digest"].String,
+		digestText: row["digest_text"].String,
+	}
+	for column, counter := range map[string]*uint64{
+		"count_star":        &d.count,
+		"sum_timer_wait":    &d.timerWait,
+		"sum_rows_examined": &d.rowsExamined,
+		"sum_no_index_used": &d.noIndexUsed,
+	} {
+		value, err := strconv.ParseUint(row[column].String, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s of digest %s error: %v", column, d.digest, err)
+		}
+		*counter = value
+	}
+	return d, nil
+}
+
+func (d *digestStatement) key() string {
+	return d.schema + "." + d.digest
+}
+
+func NewMySQLPerformanceSchemaDigestTask(entry *logrus.Entry, ap *model.AuditPlan) Task {
+	task := &MySQLPerformanceSchemaDigestTask{
+		sqlCollector: newSQLCollector(entry, ap),
+	}
+	task.sqlCollector.do = task.collectorDo
+	return task
+}
+
+func (at *MySQLPerformanceSchemaDigestTask) digestSQL() string {
+	return `
+SELECT IFNULL(SCHEMA_NAME, '') AS schema_name, DIGEST AS digest, DIGEST_TEXT AS digest_text,
+COUNT_STAR AS count_star, SUM_TIMER_WAIT AS sum_timer_wait,
+SUM_ROWS_EXAMINED AS sum_rows_examined, SUM_NO_INDEX_USED AS sum_no_index_used
+FROM performance_schema.events_statements_summary_by_digest
+WHERE DIGEST IS NOT NULL AND DIGEST_TEXT IS NOT NULL
+AND IFNULL(SCHEMA_NAME, '') NOT IN ('information_schema','performance_schema','mysql','sys')
+`
+}
+
+func (at *MySQLPerformanceSchemaDigestTask) Audit() (*AuditResultResp, error) {
+	return auditWithSchema(at.logger, at.persist, at.ap)
+}
+
+func (at *MySQLPerformanceSchemaDigestTask) collectorDo() {
+	if at.ap.InstanceName == "" {
+		at.logger.Warnf("instance is not configured")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*20)
+	defer cancel()
+
+	instance, _, err := dms.GetInstanceInProjectByName(ctx, string(at.ap.ProjectId), at.ap.InstanceName)
+	if err != nil {
+		at.logger.Warnf("get instance fail, error: %v", err)
+		return
+	}
+	db, err := executor.NewExecutor(at.logger, &driverV2.DSN{
+		Host:             instance.Host,
+		Port:             instance.Port,
+		User:             instance.User,
+		Password:         instance.Password,
+		AdditionalParams: instance.AdditionalParams,
+		DatabaseName:     at.ap.InstanceDatabase,
+	},
+		at.ap.InstanceDatabase)
+	if err != nil {
+		at.logger.Errorf("connect to instance fail, error: %v", err)
+		return
+	}
+	defer db.Db.Close()
+
+	res, err := db.Db.Query(at.digestSQL())
+	if err != nil {
+		at.logger.Errorf("query events_statements_summary_by_digest failed, error: %v", err)
+		return
+	}
+	digests := make([]*digestStatement, 0, len(res))
+	for _, row := range res {
+		d, err := newDigestStatement(row)
+		if err != nil {
+			at.logger.Warnf("skip the digest, error: %v", err)
+			continue
+		}
+		digests = append(digests, d)
+	}
+
+	deltas := computeDigestDeltas(at.lastDigests, digests)
+	at.lastDigests = map[string]*digestStatement{}
+	for _, d := range digests {
+		at.lastDigests[d.key()] = d
+	}
+
+	if len(deltas) == 0 {
+		return
+	}
+	err = at.persist.UpdateDigestAuditPlanSQLs(at.ap.ID, convertDigestDeltasToModelSQLs(deltas, time.Now()))
+	if err != nil {
+		at.logger.Errorf("save digests to storage fail, error: %v", err)
+	}
+}
+
+// computeDigestDeltas returns the digests executed since last collection. It returns nothing
+// if there is no last collection. The digest is taken as new if any of its counters decreases,
+// since the digest table may be truncated or the digest may be evicted and recreated.
+func computeDigestDeltas(last map[string]*digestStatement, current []*digestStatement) []*digestStatement {
+	if last == nil {
+		return nil
+	}
+	deltas := []*digestStatement{}
+	for _, d := range current {
+		delta := *d
+		if prev, ok := last[d.key()]; ok && prev.count <= d.count && prev.timerWait <= d.timerWait &&
+			prev.rowsExamined <= d.rowsExamined && prev.noIndexUsed <= d.noIndexUsed {
+			delta.count -= prev.count
+			delta.timerWait -= prev.timerWait
+			delta.rowsExamined -= prev.rowsExamined
+			delta.noIndexUsed -= prev.noIndexUsed
+		}
+		if delta.count == 0 {
+			continue
+		}
+		deltas = append(deltas, &delta)
+	}
+	return deltas
+}
+
+func convertDigestDeltasToModelSQLs(deltas []*digestStatement, now time.Time) []*model.AuditPlanSQLV2 {
+	sqls := make([]*SQL, 0, len(deltas))
+	for _, d := range deltas {
+		totalLatencyMs := float64(d.timerWait) / 1000 / 1000 / 1000
+		sqls = append(sqls, &SQL{
+			SQLContent:  d.digestText,
+			Fingerprint: d.digestText,
+			Schema:      d.schema,
+			Info: map[string]interface{}{
+				"counter":                d.count,
+				"total_latency_ms":       utils.Round(totalLatencyMs, 3),
+				"avg_latency_ms":         utils.Round(totalLatencyMs/float64(d.count), 3),
+				"rows_examined":          d.rowsExamined,
+				"no_index_used":          d.noIndexUsed,
+				"schema":                 d.schema,
+				"last_receive_timestamp": now.Format(time.RFC3339),
+			},
+		})
+	}
+	return convertSQLsToModelSQLs(sqls)
+}
+
+func (at *MySQLPerformanceSchemaDigestTask) GetSQLs(args map[string]interface{}) ([]Head, []map[string] /* head name */ string, uint64, error) {
+	auditPlanSQLs, count, err := at.persist.GetAuditPlanSQLsByReq(args)
+	if err != nil {
+		return nil, nil, count, err
+	}
+	heads := []Head{
+		{
+			Name: "sql",
+			Desc: "SQL指纹",
+			Type: "sql",
+		},
+		{
+			Name: "schema",
+			Desc: "数据库",
+		},
+		{
+			Name: "counter",
+			Desc: "执行次数",
+		},
+		{
+			Name: "avg_latency_ms",
+			Desc: "平均执行时间(ms)",
+		},
+		{
+			Name: "total_latency_ms",
+			Desc: "总执行时间(ms)",
+		},
+		{
+			Name: "rows_examined",
+			Desc: "扫描行数",
+		},
+		{
+			Name: "no_index_used",
+			Desc: "未使用索引次数",
+		},
+		{
+			Name: "last_receive_timestamp",
+			Desc: "最后采集时间",
+		},
+	}
+	rows := make([]map[string]string, 0, len(auditPlanSQLs))
+	for _, sql := range auditPlanSQLs {
+		info := struct {
+			Counter              int64   `json:"counter"`
+			AvgLatencyMs         float64 `json:"avg_latency_ms"`
+			TotalLatencyMs       float64 `json:"total_latency_ms"`
+			RowsExamined         int64   `json:"rows_examined"`
+			NoIndexUsed          int64   `json:"no_index_used"`
+			LastReceiveTimestamp string  `json:"last_receive_timestamp"`
+		}{}
+		if err := json.Unmarshal(sql.Info, &info); err != nil {
+			return nil, nil, 0, err
+		}
+		rows = append(rows, map[string]string{
+			"sql":                    sql.SQLContent,
+			"schema":                 sql.Schema,
+			"counter":                strconv.FormatInt(info.Counter, 10),
+			"avg_latency_ms":         fmt.Sprintf("%v", info.AvgLatencyMs),
+			"total_latency_ms":       fmt.Sprintf("%v", info.TotalLatencyMs),
+			"rows_examined":          strconv.FormatInt(info.RowsExamined, 10),
+			"no_index_used":          strconv.FormatInt(info.NoIndexUsed, 10),
+			"last_receive_timestamp": info.LastReceiveTimestamp,
+		})
+	}
+	return heads, rows, count, nil
+}
@@ -0,0 +1,84 @@
+package auditplan
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDigestDeltas(t *testing.T) {
+	current := []*digestStatement{
+		{schema: "db1", digest: "d1", digestText: "SELECT * FROM `t1`", count: 10, timerWait: 1000, rowsExamined: 100, noIndexUsed: 10},
+		{schema: "db1", digest: "d2", digestText: "UPDATE `t1` SET `a` = ?", count: 5, timerWait: 500, rowsExamined: 5, noIndexUsed: 0},
+	}
+	// the first collection is taken as the baseline
+	assert.Len(t, computeDigestDeltas(nil, current), 0)
+
+	last := map[string]*digestStatement{}
+	for _, d := range current {
+		last[d.key()] = d
+	}
+	next := []*digestStatement{
+		// executed since last collection
+		{schema: "db1", digest: "d1", digestText: "SELECT * FROM `t1`", count: 15, timerWait: 1600, rowsExamined: 150, noIndexUsed: 15},
+		// not executed since last collection
+		{schema: "db1", digest: "d2", digestText: "UPDATE `t1` SET `a` = ?", count: 5, timerWait: 500, rowsExamined: 5, noIndexUsed: 0},
+		// new digest
+		{schema: "db2", digest: "d1", digestText: "SELECT * FROM `t1`", count: 2, timerWait: 20, rowsExamined: 2, noIndexUsed: 2},
+	}
+	deltas := computeDigestDeltas(last, next)
+	assert.Len(t, deltas, 2)
+	assert.Equal(t, digestStatement{schema: "db1", digest: "d1", digestText: "SELECT * FROM `t1`", count: 5, timerWait: 600, rowsExamined: 50, noIndexUsed: 5}, *deltas[0])
+	assert.Equal(t, *next[2], *deltas[1])
+
+	// the digest table is truncated
+	truncated := []*digestStatement{
+		{schema: "db1", digest: "d1", digestText: "SELECT * FROM `t1`", count: 3, timerWait: 300, rowsExamined: 30, noIndexUsed: 3},
+	}
+	deltas = computeDigestDeltas(last, truncated)
+	assert.Len(t, deltas, 1)
+	assert.Equal(t, *truncated[0], *deltas[0])
+}
+
+func TestNewDigestStatement(t *testing.T) {
+	row := map[string]sql.NullString{
+		"schema_name":       {String: "db1", Valid: true},
+		"digest":            {String: "d1", Valid: true},
+		"digest_text":       {String: "SELECT * FROM `t1`", Valid: true},
+		"count_star":        {String: "10", Valid: true},
+		"sum_timer_wait":    {String: "18446744073709551000", Valid: true},
+		"sum_rows_examined": {String: "100", Valid: true},
+		"sum_no_index_used": {String: "0", Valid: true},
+	}
+	d, err := newDigestStatement(row)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), d.count)
+	assert.Equal(t, uint64(18446744073709551000), d.timerWait)
+	assert.Equal(t, uint64(100), d.rowsExamined)
+
+	row["sum_rows_examined"] = sql.NullString{}
+	_, err = newDigestStatement(row)
+	assert.Error(t, err)
+}
+
+func TestConvertDigestDeltasToModelSQLs(t *testing.T) {
+	now := time.Now()
+	sqls := convertDigestDeltasToModelSQLs([]*digestStatement{
+		{schema: "db1", digest: "d1", digestText: "SELECT * FROM `t1`", count: 4, timerWait: 10 * 1000 * 1000 * 1000, rowsExamined: 40, noIndexUsed: 4},
+	}, now)
+	assert.Len(t, sqls, 1)
+	assert.Equal(t, "SELECT * FROM `t1`", sqls[0].Fingerprint)
+	assert.Equal(t, "db1", sqls[0].Schema)
+
+	info := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(sqls[0].Info, &info))
+	assert.Equal(t, float64(4), info["counter"])
+	assert.Equal(t, float64(10), info["total_latency_ms"])
+	assert.Equal(t, 2.5, info["avg_latency_ms"])
+	assert.Equal(t, float64(40), info["rows_examined"])
+	assert.Equal(t, float64(4), info["no_index_used"])
+	assert.Equal(t, now.Format(time.RFC3339), info["last_receive_timestamp"])
+}
@@ -0,0 +1,161 @@
+package auditplan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/dms"
+	"github.com/actiontech/sqle/sqle/model"
+	"github.com/actiontech/sqle/sqle/pkg/postgresql"
+	"github.com/actiontech/sqle/sqle/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PostgreSQLPgStatStatementsTask implement the Task interface.
+//
+// PostgreSQLPgStatStatementsTask is a loop task which samples the statements from
+// pg_stat_statements of PostgreSQL instance.
+type PostgreSQLPgStatStatementsTask struct {
+	*sqlCollector
+}
+
+func NewPostgreSQLPgStatStatementsTask(entry *logrus.Entry, ap *model.AuditPlan) Task {
+	task := &PostgreSQLPgStatStatementsTask{
+		sqlCollector: newSQLCollector(entry, ap),
+	}
+	task.sqlCollector.do = task.collectorDo
+	return task
+}
+
+func (at *PostgreSQLPgStatStatementsTask) collectorDo() {
+	select {
+	case <-at.cancel:
+		at.logger.Info("cancel task")
+		return
+	default:
+	}
+
+	if at.ap.InstanceName == "" {
+		at.logger.Warnf("instance is not configured")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*20)
+	defer cancel()
+	inst, _, err := dms.GetInstanceInProjectByName(ctx, string(at.ap.ProjectId), at.ap.InstanceName)
+	if err != nil {
+		at.logger.Warnf("get instance fail, error: %v", err)
+		return
+	}
+	db, err := postgresql.NewDB(&postgresql.DSN{
+		Host:         inst.Host,
+		Port:         inst.Port,
+		User:         inst.User,
+		Password:     inst.Password,
+		DatabaseName: at.ap.InstanceDatabase,
+	})
+	if err != nil {
+		at.logger.Errorf("connect to instance fail, error: %v", err)
+		return
+	}
+	defer db.Close()
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	statements, err := db.QueryStatStatements(ctx, at.ap.Params.GetParam(paramKeyTopN).Int())
+	if err != nil {
+		at.logger.Errorf("query pg_stat_statements fail, error: %v", err)
+		return
+	}
+	statements = postgresql.MergeStatStatements(statements)
+	if len(statements) == 0 {
+		return
+	}
+	err = at.persist.UpdateSnapshotAuditPlanSQLs(at.ap.ID, convertStatStatementsToModelSQLs(statements, time.Now()))
+	if err != nil {
+		at.logger.Errorf("save pg_stat_statements to storage fail, error: %v", err)
+	}
+}
+
+func convertStatStatementsToModelSQLs(statements []*postgresql.StatStatement, now time.Time) []*model.AuditPlanSQLV2 {
+	sqls := make([]*SQL, 0, len(statements))
+	for _, s := range statements {
+		sqls = append(sqls, &SQL{
+			SQLContent:  s.Query,
+			Fingerprint: s.Query,
+			Schema:      s.DatabaseName,
+			Info: map[string]interface{}{
+				"queryid":                                 s.QueryId,
+				postgresql.StatStatementsColumnCalls:      s.Calls,
+				postgresql.StatStatementsColumnMeanTimeMs: utils.Round(s.MeanTimeMs(), 3),
+				postgresql.StatStatementsColumnTotalTime:  utils.Round(s.TotalTimeMs, 3),
+				postgresql.StatStatementsColumnRows:       s.Rows,
+				"last_receive_timestamp":                  now.Format(time.RFC3339),
+			},
+		})
+	}
+	return convertSQLsToModelSQLs(sqls)
+}
+
+func (at *PostgreSQLPgStatStatementsTask) Audit() (*AuditResultResp, error) {
+	return (&DefaultTask{at.baseTask}).Audit()
+}
+
+func (at *PostgreSQLPgStatStatementsTask) GetSQLs(args map[string]interface{}) ([]Head, []map[string] /* head name */ string, uint64, error) {
+	auditPlanSQLs, count, err := at.persist.GetAuditPlanSQLsByReq(args)
+	if err != nil {
+		return nil, nil, count, err
+	}
+	heads := []Head{
+		{
+			Name: "sql",
+			Desc: "SQL语句",
+			Type: "sql",
+		},
+		{
+			Name: "schema",
+			Desc: "数据库",
+		},
+		{
+			Name: postgresql.StatStatementsColumnCalls,
+			Desc: "总执行次数",
+		},
+		{
+			Name: postgresql.StatStatementsColumnMeanTimeMs,
+			Desc: "平均执行时间(ms)",
+		},
+		{
+			Name: postgresql.StatStatementsColumnTotalTime,
+			Desc: "总执行时间(ms)",
+		},
+		{
+			Name: postgresql.StatStatementsColumnRows,
+			Desc: "总返回/影响行数",
+		},
+	}
+	rows := make([]map[string]string, 0, len(auditPlanSQLs))
+	for _, sql := range auditPlanSQLs {
+		info := struct {
+			Calls      int64   `json:"calls"`
+			MeanTimeMs float64 `json:"mean_time_ms"`
+			TotalTime  float64 `json:"total_time_ms"`
+			Rows       int64   `json:"rows"`
+		}{}
+		if err := json.Unmarshal(sql.Info, &info); err != nil {
+			return nil, nil, 0, err
+		}
+		rows = append(rows, map[string]string{
+			"sql":                                sql.SQLContent,
+			"schema":                             sql.Schema,
+			postgresql.StatStatementsColumnCalls: strconv.FormatInt(info.Calls, 10),
+			postgresql.StatStatementsColumnMeanTimeMs: fmt.Sprintf("%v", info.MeanTimeMs),
+			postgresql.StatStatementsColumnTotalTime:  fmt.Sprintf("%v", info.TotalTime),
+			postgresql.StatStatementsColumnRows:       strconv.FormatInt(info.Rows, 10),
+		})
+	}
+	return heads, rows, count, nil
+}
@@ -0,0 +1,142 @@
+package auditplan
+
+import (
+	"sort"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/model"
+	"github.com/actiontech/sqle/sqle/notification"
+)
+
+const (
+	// regressionBaselineBuckets is the number of metric buckets before the analyzed bucket which
+	// the baseline is computed from.
+	regressionBaselineBuckets = 7 * 24
+	// regressionMinBaselineBuckets is the minimum number of buckets in which the SQL is executed
+	// before the baseline is used to flag the regression, it avoids flagging the SQL which is just
+	// collected.
+	regressionMinBaselineBuckets = 3
+)
+
+// detectSQLRegressions compares the metrics of SQLs in the analyzed bucket with the baselines which
+// are summed over the earlier buckets. The SQL whose executions or average query time is not less
+// than the baseline multiplied by ratio is flagged, and the SQL without baseline is flagged as new
+// one if there are baselines of other SQLs, which means the audit plan has collected SQLs before.
+func detectSQLRegressions(current, baselines map[string] /*fingerprint md5*/ *model.AuditPlanSQLMetricStat,
+	ratio float64) []*model.AuditPlanSQLRegression {
+
+	md5s := make([]string, 0, len(current))
+	for md5 := range current {
+		md5s = append(md5s, md5)
+	}
+	sort.Strings(md5s)
+
+	regressions := []*model.AuditPlanSQLRegression{}
+	for _, md5 := range md5s {
+		stat := current[md5]
+		if stat.Count <= 0 {
+			continue
+		}
+		fingerprint := stat.Fingerprint
+		// the SQL may be removed by the full sync of audit plan.
+		if fingerprint == "" {
+			fingerprint = stat.FingerprintMD5
+		}
+		baseline, ok := baselines[md5]
+		if !ok {
+			if len(baselines) > 0 {
+				regressions = append(regressions, &model.AuditPlanSQLRegression{
+					Type:        model.AuditPlanSQLRegressionTypeNew,
+					Fingerprint: fingerprint,
+					Schema:      stat.Schema,
+					Current:     float64(stat.Count),
+				})
+			}
+			continue
+		}
+		if baseline.Buckets < regressionMinBaselineBuckets || baseline.Count <= 0 {
+			continue
+		}
+
+		baselineCount := float64(baseline.Count) / float64(baseline.Buckets)
+		if float64(stat.Count) >= baselineCount*ratio {
+			regressions = append(regressions, &model.AuditPlanSQLRegression{
+				Type:        model.AuditPlanSQLRegressionTypeFrequency,
+				Fingerprint: fingerprint,
+				Schema:      stat.Schema,
+				Baseline:    baselineCount,
+				Current:     float64(stat.Count),
+			})
+		}
+		// the query time is unknown if it is not reported by the collector.
+		if baseline.QueryTimeTotal <= 0 || stat.QueryTimeTotal <= 0 {
+			continue
+		}
+		baselineQueryTimeAvg := baseline.QueryTimeTotal / float64(baseline.Count)
+		queryTimeAvg := stat.QueryTimeTotal / float64(stat.Count)
+		if queryTimeAvg >= baselineQueryTimeAvg*ratio {
+			regressions = append(regressions, &model.AuditPlanSQLRegression{
+				Type:        model.AuditPlanSQLRegressionTypeLatency,
+				Fingerprint: fingerprint,
+				Schema:      stat.Schema,
+				Baseline:    baselineQueryTimeAvg,
+				Current:     queryTimeAvg,
+			})
+		}
+	}
+	return regressions
+}
+
+// analyzeSQLRegressions flags the regressions of SQLs in the latest completed bucket of SQL metrics
+// and notifies them. The SQLs of a bucket may be uploaded by many requests, so the bucket is
+// analyzed by the first collection after it is completed. It does nothing if the regression ratio
+// of audit plan is not set.
+func (at *baseTask) analyzeSQLRegressions() {
+	ratio := at.ap.Params.GetParam(paramKeyRegressionRatio).Float64()
+	if ratio <= 1 {
+		return
+	}
+
+	currentBucketAt := time.Now().Truncate(model.AuditPlanSQLMetricBucket)
+	bucketAt, exist, err := at.persist.GetLatestAuditPlanSQLMetricBucket(at.ap.ID, currentBucketAt)
+	if err != nil {
+		at.logger.Errorf("get latest sql metric bucket failed, error: %v", err)
+		return
+	}
+	if !exist {
+		return
+	}
+	claimed, err := at.persist.ClaimAuditPlanSQLRegressionBucket(at.ap.ID, bucketAt)
+	if err != nil {
+		at.logger.Errorf("claim sql metric bucket for regression analysis failed, error: %v", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	current, err := at.persist.GetAuditPlanSQLMetricStats(at.ap.ID, bucketAt, bucketAt.Add(model.AuditPlanSQLMetricBucket))
+	if err != nil {
+		at.logger.Errorf("get sql metrics failed, error: %v", err)
+		return
+	}
+	baselineStart := bucketAt.Add(-regressionBaselineBuckets * model.AuditPlanSQLMetricBucket)
+	baselines, err := at.persist.GetAuditPlanSQLMetricStats(at.ap.ID, baselineStart, bucketAt)
+	if err != nil {
+		at.logger.Errorf("get sql baselines failed, error: %v", err)
+		return
+	}
+
+	regressions := detectSQLRegressions(current, baselines, ratio)
+	if len(regressions) == 0 {
+		return
+	}
+	at.logger.Infof("%d sql regressions are found in the metrics of %v", len(regressions), bucketAt)
+	err = notification.NotifyAuditPlan(at.ap.ID, notification.AuditPlanNotifyData{
+		Type:        notification.AuditPlanNotifyTypeSQLRegression,
+		Regressions: regressions,
+	})
+	if err != nil {
+		at.logger.Errorf("notify sql regressions failed, error: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package auditplan
+
+import (
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectSQLRegressions(t *testing.T) {
+	stat := func(md5 string, buckets uint, count int64, queryTimeTotal float64) *model.AuditPlanSQLMetricStat {
+		return &model.AuditPlanSQLMetricStat{
+			FingerprintMD5: md5,
+			Fingerprint:    "select * from " + md5 + " where id = ?",
+			Schema:         "db1",
+			Buckets:        buckets,
+			Count:          count,
+			QueryTimeTotal: queryTimeTotal,
+		}
+	}
+	stats := func(s ...*model.AuditPlanSQLMetricStat) map[string]*model.AuditPlanSQLMetricStat {
+		res := map[string]*model.AuditPlanSQLMetricStat{}
+		for _, v := range s {
+			res[v.FingerprintMD5] = v
+		}
+		return res
+	}
+
+	// the SQLs of the first bucket are not new ones
+	regressions := detectSQLRegressions(stats(stat("t1", 1, 10, 1)), stats(), 2)
+	assert.Len(t, regressions, 0)
+
+	// the baseline is not used until the SQL is executed in enough buckets
+	regressions = detectSQLRegressions(stats(stat("t1", 1, 100, 100)),
+		stats(stat("t1", regressionMinBaselineBuckets-1, 20, 2)), 2)
+	assert.Len(t, regressions, 0)
+
+	// the baseline is the executions in one bucket, 30 / 3 = 10
+	baselines := stats(stat("t1", regressionMinBaselineBuckets, 30, 3))
+	regressions = detectSQLRegressions(stats(stat("t1", 1, 19, 1.9)), baselines, 2)
+	assert.Len(t, regressions, 0)
+
+	// frequency regression
+	regressions = detectSQLRegressions(stats(stat("t1", 1, 20, 2)), baselines, 2)
+	assert.Len(t, regressions, 1)
+	assert.Equal(t, model.AuditPlanSQLRegressionTypeFrequency, regressions[0].Type)
+	assert.Equal(t, float64(10), regressions[0].Baseline)
+	assert.Equal(t, float64(20), regressions[0].Current)
+
+	// latency regression, the baseline is 3 / 30 = 0.1s
+	regressions = detectSQLRegressions(stats(stat("t1", 1, 10, 2)), baselines, 2)
+	assert.Len(t, regressions, 1)
+	assert.Equal(t, model.AuditPlanSQLRegressionTypeLatency, regressions[0].Type)
+	assert.InDelta(t, 0.1, regressions[0].Baseline, 0.0001)
+	assert.InDelta(t, 0.2, regressions[0].Current, 0.0001)
+
+	// the latency is not compared if the query time is unknown
+	regressions = detectSQLRegressions(stats(stat("t1", 1, 10, 0)), baselines, 2)
+	assert.Len(t, regressions, 0)
+
+	// new fingerprint
+	regressions = detectSQLRegressions(stats(stat("t2", 1, 1, 0)), baselines, 2)
+	assert.Len(t, regressions, 1)
+	assert.Equal(t, model.AuditPlanSQLRegressionTypeNew, regressions[0].Type)
+	assert.Equal(t, "select * from t2 where id = ?", regressions[0].Fingerprint)
+	assert.Equal(t, "db1", regressions[0].Schema)
+	assert.Equal(t, float64(1), regressions[0].Current)
+}
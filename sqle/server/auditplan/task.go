@@ -310,11 +310,19 @@ func convertRawSQLToModelSQLs(sqls []string, schema string) []*model.AuditPlanSQ
 }
 
 func (at *baseTask) FullSyncSQLs(sqls []*SQL) error {
-	return at.persist.OverrideAuditPlanSQLs(at.ap.ID, convertSQLsToModelSQLs(sqls))
+	if err := at.persist.OverrideAuditPlanSQLs(at.ap.ID, convertSQLsToModelSQLs(sqls)); err != nil {
+		return err
+	}
+	at.analyzeSQLRegressions()
+	return nil
 }
 
 func (at *baseTask) PartialSyncSQLs(sqls []*SQL) error {
-	return at.persist.UpdateDefaultAuditPlanSQLs(at.ap.ID, convertSQLsToModelSQLs(sqls))
+	if err := at.persist.UpdateDefaultAuditPlanSQLs(at.ap.ID, convertSQLsToModelSQLs(sqls)); err != nil {
+		return err
+	}
+	at.analyzeSQLRegressions()
+	return nil
 }
 
 func (at *baseTask) GetSQLs(args map[string]interface{}) ([]Head, []map[string] /* head name */ string, uint64, error) {
@@ -560,7 +568,7 @@ func (at *OracleTopSQLTask) collectorDo() {
 	ctx, cancel = context.WithCancel(context.Background())
 	defer cancel()
 
-	sqls, err := db.QueryTopSQLs(ctx, at.ap.Params.GetParam("top_n").Int(), at.ap.Params.GetParam("order_by_column").String())
+	sqls, err := db.QueryTopSQLs(ctx, at.ap.Params.GetParam(paramKeyTopN).Int(), at.ap.Params.GetParam("order_by_column").String())
 	if err != nil {
 		at.logger.Errorf("query top sql fail, error: %v", err)
 		return
@@ -585,8 +593,11 @@ func (at *OracleTopSQLTask) collectorDo() {
 		err = at.persist.OverrideAuditPlanSQLs(at.ap.ID, convertSQLsToModelSQLs(apSQLs))
 		if err != nil {
 			at.logger.Errorf("save top sql to storage fail, error: %v", err)
+			return
 		}
 	}
+
+	at.analyzeSQLRegressions()
 }
 
 func (at *OracleTopSQLTask) Audit() (*AuditResultResp, error) {
@@ -1301,6 +1312,7 @@ func (at *MySQLProcesslistTask) collectorDo() {
 			at.logger.Errorf("save processlist to storage fail, error: %v", err)
 			return
 		}
+		at.analyzeSQLRegressions()
 	}
 }
 
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/actiontech/sqle/sqle/model"
+)
+
+// autoRollback rolls back the task if the auto rollback is enabled by workflow template after its
+// execution fails or is terminated, the cause is shown in the record. The rollback SQLs are executed
+// in the reverse order of the executed SQLs, and the rollback is recorded as a step of the workflow
+// record.
+func (a *action) autoRollback(cause string) {
+	template := a.getWorkflowTemplate()
+	if template == nil || !template.EnableAutoRollback {
+		return
+	}
+	st := model.GetStorage()
+	instanceRecord, err := st.GetWorkInstanceRecordByTaskId(fmt.Sprintf("%d", a.task.ID))
+	if err != nil {
+		a.entry.Errorf("get workflow instance record of task error: %v", err)
+		return
+	}
+	rollbackSQLs, err := st.GetRollbackSQLsByTaskId(a.task.ID)
+	if err != nil {
+		a.entry.Errorf("get rollback SQLs of task error: %v", err)
+		return
+	}
+	chunks := map[uint][]*model.ExecuteSQLChunk{}
+	for _, executeSQL := range a.task.ExecuteSQLs {
+		if executeSQL.ExecStatus != model.SQLExecuteStatusFailed && executeSQL.ExecStatus != model.SQLExecuteStatusTerminateSucc {
+			continue
+		}
+		sqlChunks, err := st.GetExecuteSQLChunksByExecuteSQLId(executeSQL.ID)
+		if err != nil {
+			a.entry.Errorf("get chunks of SQL %d error: %v", executeSQL.Number, err)
+			return
+		}
+		if len(sqlChunks) > 0 {
+			chunks[executeSQL.ID] = sqlChunks
+		}
+	}
+	rollbackSQLs = filterAutoRollbackSQLs(a.task.ExecuteSQLs, rollbackSQLs, chunks)
+
+	if err := st.UpdateTask(a.task, map[string]interface{}{
+		"auto_rollback_status": model.TaskAutoRollbackStatusRollingBack,
+	}); err != nil {
+		a.entry.Errorf("update auto rollback status of task error: %v", err)
+		return
+	}
+	a.entry.Infof("start auto rollback, %d rollback SQLs", len(rollbackSQLs))
+	record := &model.WorkflowAutoRollback{
+		WorkflowRecordId: instanceRecord.WorkflowRecordId,
+		TaskId:           a.task.ID,
+	}
+	if err := a.execRollbackSQLs(rollbackSQLs); err != nil {
+		a.entry.Errorf("auto rollback error: %v", err)
+		record.State = model.WorkflowAutoRollbackStateFailed
+		record.Reason = fmt.Sprintf("任务 %d %s，自动回滚失败: %v", a.task.ID, cause, err)
+	} else {
+		a.entry.Info("auto rollback finished")
+		record.State = model.WorkflowAutoRollbackStateSucceeded
+		record.Reason = fmt.Sprintf("任务 %d %s，已自动回滚 %d 条 SQL", a.task.ID, cause, len(rollbackSQLs))
+	}
+	if err := st.Save(record); err != nil {
+		a.entry.Errorf("save auto rollback record error: %v", err)
+	}
+	status := model.TaskAutoRollbackStatusSucceeded
+	if record.State == model.WorkflowAutoRollbackStateFailed {
+		status = model.TaskAutoRollbackStatusFailed
+	}
+	if err := st.UpdateTask(a.task, map[string]interface{}{"auto_rollback_status": status}); err != nil {
+		a.entry.Errorf("update auto rollback status of task error: %v", err)
+	}
+}
+
+// filterAutoRollbackSQLs returns the rollback SQLs to be executed, in the reverse order of the
+// execute SQLs. The SQL not succeeded is rolled back only if it is executed in chunks and some
+// chunks have been committed, and only the rollback SQLs of the committed chunks are executed.
+// The other SQLs not succeeded are never rolled back, they are either rolled back by database,
+// e.g. the SQLs in a failed transaction, or not executed at all.
+func filterAutoRollbackSQLs(executeSQLs []*model.ExecuteSQL, rollbackSQLs []*model.RollbackSQL,
+	chunks map[uint] /*execute sql id*/ []*model.ExecuteSQLChunk) []*model.RollbackSQL {
+
+	rollbackSQLMap := map[uint]*model.RollbackSQL{}
+	for _, rollbackSQL := range rollbackSQLs {
+		rollbackSQLMap[rollbackSQL.ExecuteSQLId] = rollbackSQL
+	}
+	sqls := []*model.RollbackSQL{}
+	for i := len(executeSQLs) - 1; i >= 0; i-- {
+		executeSQL := executeSQLs[i]
+		rollbackSQL, ok := rollbackSQLMap[executeSQL.ID]
+		if !ok {
+			continue
+		}
+		switch executeSQL.ExecStatus {
+		case model.SQLExecuteStatusSucceeded:
+		case model.SQLExecuteStatusFailed, model.SQLExecuteStatusTerminateSucc:
+			content, ok := getCommittedChunksRollbackSQL(chunks[executeSQL.ID])
+			if !ok {
+				continue
+			}
+			partial := *rollbackSQL
+			partial.Content = content
+			rollbackSQL = &partial
+		default:
+			continue
+		}
+		if rollbackSQL.Content == "" {
+			continue
+		}
+		sqls = append(sqls, rollbackSQL)
+	}
+	return sqls
+}
+
+// getCommittedChunksRollbackSQL returns the rollback SQLs of committed chunks in reverse order, it
+// returns false if no chunk is committed or any committed chunk has no rollback SQL.
+func getCommittedChunksRollbackSQL(chunks []*model.ExecuteSQLChunk) (string, bool) {
+	sqls := []string{}
+	for i := len(chunks) - 1; i >= 0; i-- {
+		chunk := chunks[i]
+		if chunk.ExecStatus != model.SQLExecuteStatusSucceeded || chunk.RowAffects == 0 {
+			continue
+		}
+		if chunk.RollbackSQL == "" {
+			return "", false
+		}
+		sqls = append(sqls, chunk.RollbackSQL)
+	}
+	return strings.Join(sqls, "\n"), len(sqls) > 0
+}
+
+// execRollbackSQLs stops at the first failed SQL.
+func (a *action) execRollbackSQLs(rollbackSQLs []*model.RollbackSQL) error {
+	st := model.GetStorage()
+	for _, rollbackSQL := range rollbackSQLs {
+		if err := st.UpdateRollbackSqlStatus(&rollbackSQL.BaseSQL, model.SQLExecuteStatusDoing, ""); err != nil {
+			return err
+		}
+		nodes, err := a.plugin.Parse(context.TODO(), rollbackSQL.Content)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if _, execErr := a.plugin.Exec(context.TODO(), node.Text); execErr != nil {
+				if err := st.UpdateRollbackSqlStatus(&rollbackSQL.BaseSQL, model.SQLExecuteStatusFailed, execErr.Error()); err != nil {
+					a.entry.Errorf("update rollback SQL status error: %v", err)
+				}
+				return execErr
+			}
+		}
+		if err := st.UpdateRollbackSqlStatus(&rollbackSQL.BaseSQL, model.SQLExecuteStatusSucceeded, model.TaskExecResultOK); err != nil {
+			return err
+		}
+	}
+	return nil
+}
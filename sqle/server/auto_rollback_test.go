@@ -0,0 +1,74 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterAutoRollbackSQLs(t *testing.T) {
+	newExecuteSQL := func(id uint, status string) *model.ExecuteSQL {
+		sql := &model.ExecuteSQL{BaseSQL: model.BaseSQL{ExecStatus: status}}
+		sql.ID = id
+		return sql
+	}
+	newRollbackSQL := func(executeSQLId uint, content string) *model.RollbackSQL {
+		return &model.RollbackSQL{BaseSQL: model.BaseSQL{Content: content}, ExecuteSQLId: executeSQLId}
+	}
+	executeSQLs := []*model.ExecuteSQL{
+		newExecuteSQL(1, model.SQLExecuteStatusSucceeded),
+		newExecuteSQL(2, model.SQLExecuteStatusSucceeded),
+		newExecuteSQL(3, model.SQLExecuteStatusSucceeded),
+		newExecuteSQL(4, model.SQLExecuteStatusFailed),
+		newExecuteSQL(5, model.SQLExecuteStatusInitialized),
+	}
+	rollbackSQLs := []*model.RollbackSQL{
+		newRollbackSQL(5, "rollback 5"),
+		newRollbackSQL(4, "rollback 4"),
+		newRollbackSQL(3, ""),
+		newRollbackSQL(1, "rollback 1"),
+	}
+	contents := func(sqls []*model.RollbackSQL) []string {
+		res := []string{}
+		for _, sql := range sqls {
+			res = append(res, sql.Content)
+		}
+		return res
+	}
+
+	// the failed SQL without committed chunks is not rolled back
+	assert.Equal(t, []string{"rollback 1"},
+		contents(filterAutoRollbackSQLs(executeSQLs, rollbackSQLs, nil)))
+
+	// only the committed chunks of the failed SQL are rolled back
+	chunks := map[uint][]*model.ExecuteSQLChunk{
+		4: {
+			{Number: 1, ExecStatus: model.SQLExecuteStatusSucceeded, RowAffects: 10, RollbackSQL: "rollback chunk 1"},
+			{Number: 2, ExecStatus: model.SQLExecuteStatusSucceeded, RowAffects: 0, RollbackSQL: "rollback chunk 2"},
+			{Number: 3, ExecStatus: model.SQLExecuteStatusSucceeded, RowAffects: 10, RollbackSQL: "rollback chunk 3"},
+			{Number: 4, ExecStatus: model.SQLExecuteStatusFailed, RollbackSQL: "rollback chunk 4"},
+		},
+	}
+	assert.Equal(t, []string{"rollback chunk 3\nrollback chunk 1", "rollback 1"},
+		contents(filterAutoRollbackSQLs(executeSQLs, rollbackSQLs, chunks)))
+	assert.Equal(t, "rollback 4", rollbackSQLs[1].Content)
+}
+
+func TestFilterAutoRollbackSQLsOfFailedTransaction(t *testing.T) {
+	// all SQLs in the failed transaction are marked as failed, they have been rolled back by database.
+	executeSQLs := []*model.ExecuteSQL{}
+	rollbackSQLs := []*model.RollbackSQL{}
+	for id := uint(1); id <= 3; id++ {
+		sql := &model.ExecuteSQL{BaseSQL: model.BaseSQL{ExecStatus: model.SQLExecuteStatusFailed}}
+		sql.ID = id
+		executeSQLs = append(executeSQLs, sql)
+		rollbackSQLs = append(rollbackSQLs, &model.RollbackSQL{
+			BaseSQL:      model.BaseSQL{Content: fmt.Sprintf("DELETE FROM t1 WHERE id = %d", id)},
+			ExecuteSQLId: id,
+		})
+	}
+	assert.Len(t, filterAutoRollbackSQLs(executeSQLs, rollbackSQLs, nil), 0)
+}
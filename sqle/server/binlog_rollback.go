@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/model"
+)
+
+const binlogRollbackTimeout = 10 * time.Minute
+
+// binlogRollbacker returns the plugin as driver.BinlogRollbacker if the rollback SQL of
+// DML should be generated from binlog after execution.
+func (a *action) binlogRollbacker() (driver.BinlogRollbacker, bool) {
+	r, ok := a.plugin.(driver.BinlogRollbacker)
+	if !ok || !r.IsBinlogRollbackEnabled() {
+		return nil, false
+	}
+	return r, true
+}
+
+// getBinlogPosition returns nil if the binlog position can not be got, the rollback
+// SQL generated before execution will be kept in this case.
+func (a *action) getBinlogPosition(r driver.BinlogRollbacker) *driver.BinlogPosition {
+	pos, connectionId, err := r.GetBinlogPosition(context.TODO())
+	if err != nil {
+		a.entry.Warnf("get binlog position error: %v", err)
+		return nil
+	}
+	a.binlogConnectionId = connectionId
+	return pos
+}
+
+func setBinlogPosition(sql *model.BaseSQL, start, end *driver.BinlogPosition) {
+	sql.StartBinlogFile, sql.StartBinlogPos = start.File, start.Pos
+	sql.EndBinlogFile, sql.EndBinlogPos = end.File, end.Pos
+}
+
+// isDML returns false for DDL, the binlog positions of DDL are recorded but the rollback
+// SQL of DDL is not generated from binlog.
+func (a *action) isDML(executeSQL *model.ExecuteSQL) bool {
+	nodes, err := a.plugin.Parse(context.TODO(), executeSQL.Content)
+	if err != nil || len(nodes) == 0 {
+		return false
+	}
+	return nodes[0].Type == driverV2.SQLTypeDML
+}
+
+func sameBinlogPosition(a, b *model.BaseSQL) bool {
+	return a.StartBinlogFile == b.StartBinlogFile && a.StartBinlogPos == b.StartBinlogPos &&
+		a.EndBinlogFile == b.EndBinlogFile && a.EndBinlogPos == b.EndBinlogPos
+}
+
+// genRollbackSQLByBinlog replaces the rollback SQLs generated before execution with the SQLs
+// generated from binlog, the status of generation is recorded on the task. The DMLs executed in
+// one transaction share the same binlog positions, so the rollback SQLs of transaction are
+// merged into the first DML of transaction.
+func (a *action) genRollbackSQLByBinlog() {
+	r, ok := a.binlogRollbacker()
+	if !ok {
+		return
+	}
+	st := model.GetStorage()
+	if err := st.UpdateTask(a.task, map[string]interface{}{
+		"binlog_rollback_status": model.TaskBinlogRollbackStatusGenerating,
+	}); err != nil {
+		a.entry.Errorf("update binlog rollback status of task error: %v", err)
+		return
+	}
+	status := model.TaskBinlogRollbackStatusSucceeded
+	if err := a.saveRollbackSQLByBinlog(r); err != nil {
+		a.entry.Errorf("save rollback SQL generated by binlog error: %v", err)
+		status = model.TaskBinlogRollbackStatusFailed
+	}
+	if err := st.UpdateTask(a.task, map[string]interface{}{"binlog_rollback_status": status}); err != nil {
+		a.entry.Errorf("update binlog rollback status of task error: %v", err)
+	}
+}
+
+// saveRollbackSQLByBinlog returns error if the rollback SQLs of any transaction are failed to be
+// generated, the rollback SQLs generated before execution are kept for the transaction.
+func (a *action) saveRollbackSQLByBinlog(r driver.BinlogRollbacker) error {
+	rollbackSQLs := map[uint]*model.RollbackSQL{}
+	for _, rollbackSQL := range a.task.RollbackSQLs {
+		rollbackSQLs[rollbackSQL.ExecuteSQLId] = rollbackSQL
+	}
+
+	newRollbackSQL := func(executeSQL *model.ExecuteSQL) *model.RollbackSQL {
+		if rollbackSQL, ok := rollbackSQLs[executeSQL.ID]; ok {
+			return rollbackSQL
+		}
+		return &model.RollbackSQL{
+			BaseSQL:      model.BaseSQL{TaskId: executeSQL.TaskId},
+			ExecuteSQLId: executeSQL.ID,
+		}
+	}
+
+	updated := []*model.RollbackSQL{}
+	failed := 0
+	var first *model.ExecuteSQL
+	firstFailed := false
+	for _, executeSQL := range a.task.ExecuteSQLs {
+		if executeSQL.ExecStatus != model.SQLExecuteStatusSucceeded || executeSQL.StartBinlogFile == "" ||
+			!a.isDML(executeSQL) {
+			first = nil
+			continue
+		}
+		if first != nil && sameBinlogPosition(&first.BaseSQL, &executeSQL.BaseSQL) {
+			// keep the rollback SQLs generated before execution if the transaction is failed to be generated.
+			if firstFailed {
+				continue
+			}
+			rollbackSQL := newRollbackSQL(executeSQL)
+			rollbackSQL.Content = ""
+			rollbackSQL.Description = fmt.Sprintf("与第 %d 条 SQL 在同一事务中执行，回滚语句已合并至该 SQL 的回滚语句", first.Number)
+			updated = append(updated, rollbackSQL)
+			continue
+		}
+
+		first, firstFailed = executeSQL, false
+		start := &driver.BinlogPosition{File: executeSQL.StartBinlogFile, Pos: executeSQL.StartBinlogPos}
+		end := &driver.BinlogPosition{File: executeSQL.EndBinlogFile, Pos: executeSQL.EndBinlogPos}
+		ctx, cancel := context.WithTimeout(context.Background(), binlogRollbackTimeout)
+		sqls, err := r.GenRollbackSQLByBinlog(ctx, start, end, a.binlogConnectionId)
+		cancel()
+		if err != nil {
+			a.entry.Errorf("generate rollback SQL of execute SQL %d by binlog error: %v, the rollback SQL generated before execution is kept",
+				executeSQL.ID, err)
+			firstFailed = true
+			failed++
+			continue
+		}
+		rollbackSQL := newRollbackSQL(executeSQL)
+		rollbackSQL.Content = strings.Join(sqls, "\n")
+		rollbackSQL.Description = "回滚语句基于 binlog 生成"
+		updated = append(updated, rollbackSQL)
+	}
+	if len(updated) > 0 {
+		if err := model.GetStorage().UpdateRollbackSQLs(updated); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to generate rollback SQLs of %d transactions by binlog", failed)
+	}
+	return nil
+}
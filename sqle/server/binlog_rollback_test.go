@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/model"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// binlogDriver parses all the SQLs as DML and fails to read binlog.
+type binlogDriver struct {
+	mockDriver
+}
+
+func (d *binlogDriver) Parse(ctx context.Context, sqlText string) ([]driverV2.Node, error) {
+	return []driverV2.Node{{Text: sqlText, Type: driverV2.SQLTypeDML}}, nil
+}
+
+func (d *binlogDriver) IsBinlogRollbackEnabled() bool {
+	return true
+}
+
+func (d *binlogDriver) GetBinlogPosition(ctx context.Context) (*driver.BinlogPosition, string, error) {
+	return &driver.BinlogPosition{File: "mysql-bin.000001", Pos: 4}, "10", nil
+}
+
+func (d *binlogDriver) GenRollbackSQLByBinlog(ctx context.Context, start, end *driver.BinlogPosition, connectionId string) ([]string, error) {
+	return nil, errors.New("binlog is purged")
+}
+
+func expectUpdateBinlogRollbackStatus(mock sqlmock.Sqlmock, status string) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `tasks` SET `binlog_rollback_status` = ?")).
+		WithArgs(status, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestGenRollbackSQLByBinlogFailed(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	model.InitMockStorage(mockDB)
+
+	act := getAction([]string{"update t1 set a = 1"}, ActionTypeExecute, &binlogDriver{})
+	executeSQL := act.task.ExecuteSQLs[0]
+	executeSQL.ExecStatus = model.SQLExecuteStatusSucceeded
+	executeSQL.StartBinlogFile, executeSQL.StartBinlogPos = "mysql-bin.000001", 4
+	executeSQL.EndBinlogFile, executeSQL.EndBinlogPos = "mysql-bin.000001", 100
+
+	// the rollback SQL generated before execution is kept.
+	expectUpdateBinlogRollbackStatus(mock, model.TaskBinlogRollbackStatusGenerating)
+	expectUpdateBinlogRollbackStatus(mock, model.TaskBinlogRollbackStatusFailed)
+	act.genRollbackSQLByBinlog()
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
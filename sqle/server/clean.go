@@ -29,6 +29,7 @@ func (j *CleanJob) job(entry *logrus.Entry) {
 	j.CleanExpiredWorkflows(entry)
 	j.CleanExpiredTasks(entry)
 	j.CleanExpiredOperationLog(entry)
+	j.CleanExpiredAuditPlanSQLMetrics(entry)
 }
 
 func (j *CleanJob) CleanExpiredWorkflows(entry *logrus.Entry) {
@@ -125,3 +126,32 @@ func getOperationRecordExpiredHours(
 
 	return operationRecordExpiredHours
 }
+
+func (j *CleanJob) CleanExpiredAuditPlanSQLMetrics(entry *logrus.Entry) {
+	st := model.GetStorage()
+	expiredHours := getAuditPlanSQLMetricExpiredHours(st, entry)
+	start := time.Now().Add(-time.Duration(expiredHours) * time.Hour)
+	count, err := st.DeleteExpiredAuditPlanSQLMetrics(start)
+	if err != nil {
+		entry.Errorf("delete expired audit plan sql metrics error: %v", err)
+		return
+	}
+	if count > 0 {
+		entry.Infof("delete expired audit plan sql metrics succeeded, count: %d", count)
+	}
+}
+
+func getAuditPlanSQLMetricExpiredHours(s *model.Storage, entry *logrus.Entry) int {
+	systemVariables, err := s.GetAllSystemVariables()
+	if err != nil {
+		entry.Warnf("get system variables failed, err: %s", err.Error())
+		return model.DefaultAuditPlanSQLMetricExpiredHours
+	}
+	expiredHours, err := strconv.Atoi(systemVariables[model.SystemVariableAuditPlanSQLMetricExpiredHours].Value)
+	if err != nil || expiredHours <= 0 {
+		entry.Warnf("invalid system variable audit_plan_sql_metric_expired_hours, use default value %d",
+			model.DefaultAuditPlanSQLMetricExpiredHours)
+		return model.DefaultAuditPlanSQLMetricExpiredHours
+	}
+	return expiredHours
+}
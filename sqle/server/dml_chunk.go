@@ -0,0 +1,245 @@
+package server
+
+import (
+	"context"
+	_errors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/model"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const replicaLagCheckInterval = time.Second
+
+var errChunkExecutionTerminated = fmt.Errorf("the execution in chunks is terminated")
+
+// getDMLChunkConfig returns nil if the DML should be executed as a whole. The DML is executed as
+// a whole if it fails to check, the DML is likely to fail in execution in this case.
+func (a *action) getDMLChunkConfig(executeSQL *model.ExecuteSQL) (driver.DMLChunker, *driver.DMLChunkConfig) {
+	chunker, ok := a.plugin.(driver.DMLChunker)
+	if !ok {
+		return nil, nil
+	}
+	config, err := chunker.GetDMLChunkConfig(context.TODO(), executeSQL.Content)
+	if err != nil {
+		a.entry.Warnf("check whether to execute SQL %d in chunks error: %v", executeSQL.Number, err)
+		return nil, nil
+	}
+	if config == nil {
+		return nil, nil
+	}
+	return chunker, config
+}
+
+// execSQLInChunks executes the DML in chunks ranged by primary key, each chunk is committed
+// separately. The rollback SQL of each chunk is generated before the chunk is executed, and
+// the rollback SQL of DML is replaced with the rollback SQLs of executed chunks.
+func (a *action) execSQLInChunks(executeSQL *model.ExecuteSQL, chunker driver.DMLChunker, config *driver.DMLChunkConfig) error {
+	if err := a.guardReplicaLag(executeSQL); err != nil {
+		return err
+	}
+	st := model.GetStorage()
+	if err := st.UpdateExecuteSqlStatus(&executeSQL.BaseSQL, model.SQLExecuteStatusDoing, ""); err != nil {
+		return err
+	}
+	a.entry.Infof("execute SQL %d in chunks of %d rows", executeSQL.Number, config.ChunkSize)
+
+	binlogRollbacker, useBinlog := a.binlogRollbacker()
+	var startBinlogPos *driver.BinlogPosition
+	if useBinlog {
+		startBinlogPos = a.getBinlogPosition(binlogRollbacker)
+	}
+
+	stopWatch := a.watchExecuteSQL(executeSQL)
+	chunks, execErr := a.execDMLChunks(executeSQL, chunker, config)
+	stopWatch()
+	if startBinlogPos != nil {
+		if endBinlogPos := a.getBinlogPosition(binlogRollbacker); endBinlogPos != nil {
+			setBinlogPosition(&executeSQL.BaseSQL, startBinlogPos, endBinlogPos)
+		}
+	}
+
+	executeSQL.RowAffects = 0
+	for _, chunk := range chunks {
+		executeSQL.RowAffects += chunk.RowAffects
+	}
+	if execErr != nil {
+		executeSQL.ExecStatus = model.SQLExecuteStatusFailed
+		executeSQL.ExecResult = execErr.Error()
+		if a.hasTermination() && (_errors.Is(mysql.ErrInvalidConn, execErr) || execErr == errChunkExecutionTerminated) {
+			executeSQL.ExecStatus = model.SQLExecuteStatusTerminateSucc
+		}
+	} else {
+		executeSQL.ExecStatus = model.SQLExecuteStatusSucceeded
+		executeSQL.ExecResult = model.TaskExecResultOK
+	}
+	if err := st.Save(executeSQL); err != nil {
+		return err
+	}
+	if err := a.updateRollbackSQLByChunks(executeSQL, chunks); err != nil {
+		a.entry.Errorf("save rollback SQL of chunks error: %v", err)
+	}
+	return execErr
+}
+
+// execDMLChunks returns the chunks have been executed or failed to be executed.
+func (a *action) execDMLChunks(executeSQL *model.ExecuteSQL, chunker driver.DMLChunker,
+	config *driver.DMLChunkConfig) ([]*model.ExecuteSQLChunk, error) {
+	st := model.GetStorage()
+	ctx := context.TODO()
+	chunks, err := st.GetExecuteSQLChunksByExecuteSQLId(executeSQL.ID)
+	if err != nil {
+		return nil, err
+	}
+	number, prev, done := resumeDMLChunks(chunks)
+	if done {
+		return chunks, nil
+	}
+	if prev != nil {
+		a.entry.Infof("resume SQL %d from chunk %d, the rows before %s have been committed",
+			executeSQL.Number, number, prev.UpperBound)
+	}
+	for ; ; number++ {
+		if a.hasTermination() {
+			return chunks, errChunkExecutionTerminated
+		}
+		chunk, err := chunker.NextDMLChunk(ctx, executeSQL.Content, config.ChunkSize, prev)
+		if err != nil {
+			return chunks, err
+		}
+		record := &model.ExecuteSQLChunk{
+			TaskId:       executeSQL.TaskId,
+			ExecuteSQLId: executeSQL.ID,
+			Number:       number,
+			Content:      chunk.SQL,
+			LowerBound:   chunk.LowerBound,
+			UpperBound:   chunk.UpperBound,
+			ExecStatus:   model.SQLExecuteStatusDoing,
+		}
+		rollbackSQL, reason, err := a.plugin.GenRollbackSQL(ctx, chunk.SQL)
+		if err != nil {
+			reason = err.Error()
+		}
+		record.RollbackSQL, record.RollbackDescription = rollbackSQL, reason
+		if err := st.Save(record); err != nil {
+			return chunks, err
+		}
+		chunks = append(chunks, record)
+
+		result, execErr := a.plugin.Exec(ctx, chunk.SQL)
+		if execErr != nil {
+			record.ExecStatus = model.SQLExecuteStatusFailed
+			record.ExecResult = execErr.Error()
+		} else {
+			record.ExecStatus = model.SQLExecuteStatusSucceeded
+			record.ExecResult = model.TaskExecResultOK
+			if result != nil {
+				record.RowAffects, _ = result.RowsAffected()
+			}
+		}
+		if err := st.Save(record); err != nil {
+			return chunks, err
+		}
+		if execErr != nil {
+			return chunks, execErr
+		}
+		if !chunk.HasUpperBound {
+			return chunks, nil
+		}
+		prev = chunk
+		a.waitForNextChunk(chunker, config)
+	}
+}
+
+// resumeDMLChunks returns the number of next chunk and the last committed chunk of the DML which
+// has been executed in chunks before, the execution of a resumed DML goes on after the last
+// committed chunk instead of from the start. It returns done if the last chunk has been committed.
+func resumeDMLChunks(chunks []*model.ExecuteSQLChunk) (number uint, prev *driver.DMLChunk, done bool) {
+	number = 1
+	for _, chunk := range chunks {
+		if chunk.Number >= number {
+			number = chunk.Number + 1
+		}
+		if chunk.ExecStatus != model.SQLExecuteStatusSucceeded {
+			continue
+		}
+		// only the last chunk has no upper bound.
+		if chunk.UpperBound == "" {
+			return number, nil, true
+		}
+		prev = &driver.DMLChunk{
+			SQL:           chunk.Content,
+			HasLowerBound: chunk.LowerBound != "",
+			LowerBound:    chunk.LowerBound,
+			HasUpperBound: true,
+			UpperBound:    chunk.UpperBound,
+		}
+	}
+	return number, prev, false
+}
+
+// waitForNextChunk sleeps between chunks and waits for the replicas to catch up. The execution
+// is not blocked if the lag of replicas can not be got.
+func (a *action) waitForNextChunk(chunker driver.DMLChunker, config *driver.DMLChunkConfig) {
+	time.Sleep(time.Duration(config.SleepMillis) * time.Millisecond)
+	if config.MaxReplicaLagSeconds <= 0 {
+		return
+	}
+	for !a.hasTermination() {
+		lag, ok, err := chunker.GetReplicaLag(context.TODO())
+		if err != nil {
+			a.entry.Warnf("get replica lag error: %v", err)
+			return
+		}
+		if !ok || lag <= config.MaxReplicaLagSeconds {
+			return
+		}
+		a.entry.Infof("replica lag %ds exceeds %ds, wait for the replicas to catch up", lag, config.MaxReplicaLagSeconds)
+		time.Sleep(replicaLagCheckInterval)
+	}
+}
+
+// updateRollbackSQLByChunks replaces the rollback SQL of DML with the rollback SQLs of executed
+// chunks in reverse order, it is kept if any executed chunk has no rollback SQL.
+func (a *action) updateRollbackSQLByChunks(executeSQL *model.ExecuteSQL, chunks []*model.ExecuteSQLChunk) error {
+	sqls := []string{}
+	for i := len(chunks) - 1; i >= 0; i-- {
+		chunk := chunks[i]
+		if chunk.ExecStatus != model.SQLExecuteStatusSucceeded {
+			continue
+		}
+		if chunk.RowAffects == 0 {
+			continue
+		}
+		if chunk.RollbackSQL == "" {
+			a.entry.Warnf("the chunk %d of SQL %d has no rollback SQL: %s, the rollback SQL of SQL is kept",
+				chunk.Number, executeSQL.Number, chunk.RollbackDescription)
+			return nil
+		}
+		sqls = append(sqls, chunk.RollbackSQL)
+	}
+	if len(sqls) == 0 {
+		return nil
+	}
+
+	var rollbackSQL *model.RollbackSQL
+	for _, sql := range a.task.RollbackSQLs {
+		if sql.ExecuteSQLId == executeSQL.ID {
+			rollbackSQL = sql
+		}
+	}
+	if rollbackSQL == nil {
+		rollbackSQL = &model.RollbackSQL{
+			BaseSQL:      model.BaseSQL{TaskId: executeSQL.TaskId},
+			ExecuteSQLId: executeSQL.ID,
+		}
+		a.task.RollbackSQLs = append(a.task.RollbackSQLs, rollbackSQL)
+	}
+	rollbackSQL.Content = strings.Join(sqls, "\n")
+	rollbackSQL.Description = fmt.Sprintf("SQL 分批执行，回滚语句由 %d 个批次执行前生成的回滚语句合并而成", len(sqls))
+	return model.GetStorage().UpdateRollbackSQLs([]*model.RollbackSQL{rollbackSQL})
+}
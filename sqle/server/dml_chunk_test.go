@@ -0,0 +1,48 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumeDMLChunks(t *testing.T) {
+	newChunk := func(number uint, status, lower, upper string) *model.ExecuteSQLChunk {
+		return &model.ExecuteSQLChunk{Number: number, ExecStatus: status, LowerBound: lower, UpperBound: upper}
+	}
+
+	// the DML is executed for the first time
+	number, prev, done := resumeDMLChunks(nil)
+	assert.Equal(t, uint(1), number)
+	assert.Nil(t, prev)
+	assert.False(t, done)
+
+	// the DML failed at the first chunk
+	number, prev, done = resumeDMLChunks([]*model.ExecuteSQLChunk{
+		newChunk(1, model.SQLExecuteStatusFailed, "", "100"),
+	})
+	assert.Equal(t, uint(2), number)
+	assert.Nil(t, prev)
+	assert.False(t, done)
+
+	// the DML failed at the third chunk, it goes on after the second chunk
+	number, prev, done = resumeDMLChunks([]*model.ExecuteSQLChunk{
+		newChunk(1, model.SQLExecuteStatusSucceeded, "", "100"),
+		newChunk(2, model.SQLExecuteStatusSucceeded, "100", "200"),
+		newChunk(3, model.SQLExecuteStatusFailed, "200", "300"),
+	})
+	assert.Equal(t, uint(4), number)
+	assert.NotNil(t, prev)
+	assert.True(t, prev.HasUpperBound)
+	assert.Equal(t, "200", prev.UpperBound)
+	assert.False(t, done)
+
+	// the last chunk has been committed
+	_, _, done = resumeDMLChunks([]*model.ExecuteSQLChunk{
+		newChunk(1, model.SQLExecuteStatusSucceeded, "", "100"),
+		newChunk(2, model.SQLExecuteStatusSucceeded, "100", ""),
+	})
+	assert.True(t, done)
+}
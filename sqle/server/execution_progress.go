@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/model"
+)
+
+const executionWatchInterval = time.Second
+
+// watchExecuteSQL reports the progress of the running SQL periodically until the returned
+// function is called. Nothing is saved if the SQL finishes within the first interval, so
+// that the short SQLs do not write storage.
+func (a *action) watchExecuteSQL(executeSQL *model.ExecuteSQL) (stop func()) {
+	reporter, canReport := a.plugin.(driver.StatementProgressReporter)
+	controller, canControl := a.plugin.(driver.OnlineDDLController)
+	if !canReport && !canControl {
+		return func() {}
+	}
+	startAt := time.Now()
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		var statement *driver.StatementProgress
+		var onlineDDL *driver.OnlineDDLProgress
+		var applied *driver.OnlineDDLControl
+		for {
+			select {
+			case <-done:
+				if statement != nil {
+					endAt := time.Now()
+					statement.State = ""
+					statement.ElapsedSeconds = int64(endAt.Sub(startAt).Seconds())
+					a.saveStatementProgress(executeSQL, statement, startAt, &endAt)
+				}
+				if onlineDDL != nil {
+					a.saveOnlineDDLProgress(executeSQL, onlineDDL, false)
+				}
+				return
+			case <-time.After(executionWatchInterval):
+			}
+			if canReport {
+				if progress := a.reportStatementProgress(reporter, executeSQL, startAt); progress != nil {
+					statement = progress
+				}
+			}
+			if canControl {
+				var progress *driver.OnlineDDLProgress
+				progress, applied = a.reportOnlineDDL(controller, executeSQL, applied)
+				if progress != nil {
+					onlineDDL = progress
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// reportStatementProgress saves the progress of the running statement, the elapsed time is
+// counted from the start of execution, because the SQL executed by online DDL tool consists
+// of many statements. It returns nil if the progress is not got.
+func (a *action) reportStatementProgress(r driver.StatementProgressReporter, executeSQL *model.ExecuteSQL,
+	startAt time.Time) *driver.StatementProgress {
+	progress, err := r.GetStatementProgress(context.TODO())
+	if err != nil {
+		a.entry.Errorf("get statement progress error: %v", err)
+		return nil
+	}
+	if progress == nil {
+		progress = &driver.StatementProgress{}
+	}
+	progress.ElapsedSeconds = int64(time.Since(startAt).Seconds())
+	a.saveStatementProgress(executeSQL, progress, startAt, nil)
+	return progress
+}
+
+func (a *action) saveStatementProgress(executeSQL *model.ExecuteSQL, progress *driver.StatementProgress,
+	startAt time.Time, endAt *time.Time) {
+	err := model.GetStorage().SaveExecuteSQLProgress(&model.ExecuteSQLProgress{
+		TaskId:         executeSQL.TaskId,
+		ExecuteSQLId:   executeSQL.ID,
+		Number:         executeSQL.Number,
+		StartAt:        &startAt,
+		EndAt:          endAt,
+		State:          progress.State,
+		ElapsedSeconds: progress.ElapsedSeconds,
+		RowsExamined:   progress.RowsExamined,
+		RowsAffected:   progress.RowsAffected,
+	})
+	if err != nil {
+		a.entry.Errorf("save statement progress error: %v", err)
+	}
+}
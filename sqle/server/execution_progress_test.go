@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/model"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type progressDriver struct {
+	mockDriver
+}
+
+func (d *progressDriver) GetStatementProgress(ctx context.Context) (*driver.StatementProgress, error) {
+	return &driver.StatementProgress{State: "Sending data", ElapsedSeconds: 100, RowsExamined: 1000}, nil
+}
+
+func TestWatchExecuteSQL(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	model.InitMockStorage(mockDB)
+
+	act := getAction([]string{"update t1 set a=1"}, ActionTypeExecute, &progressDriver{})
+	executeSQL := act.task.ExecuteSQLs[0]
+	executeSQL.ID = 10
+	executeSQL.TaskId = act.task.ID
+	executeSQL.Number = 1
+
+	// the elapsed time is counted from the start of execution
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO execute_sql_progresses")).
+		WithArgs(act.task.ID, 10, 1, sqlmock.AnyArg(), nil, "Sending data", 1, 1000, 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO execute_sql_progresses")).
+		WithArgs(act.task.ID, 10, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), "", 1, 1000, 0).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+
+	stop := act.watchExecuteSQL(executeSQL)
+	time.Sleep(executionWatchInterval + executionWatchInterval/2)
+	stop()
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWatchExecuteSQLFinishedQuickly(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	model.InitMockStorage(mockDB)
+
+	act := getAction([]string{"update t1 set a=1"}, ActionTypeExecute, &progressDriver{})
+	stop := act.watchExecuteSQL(act.task.ExecuteSQLs[0])
+	stop()
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/model"
+)
+
+const (
+	metadataLockCheckInterval = 2 * time.Second
+	// maxBlockerSQLLength limits the length of SQL of blocking session in the result of check.
+	maxBlockerSQLLength = 200
+)
+
+var errMetadataLockCheckTerminated = fmt.Errorf("the execution is terminated while waiting for the sessions blocking DDL")
+
+// checkMetadataLocks checks the sessions which block the DDL before it is executed, the DDL
+// waits for the metadata lock held by them and blocks all the following queries on the table.
+// The DDL is refused, waits for the sessions or waits for the confirmation of executor according
+// to the policy, and the blocking sessions are recorded on the SQL.
+func (a *action) checkMetadataLocks(executeSQL *model.ExecuteSQL) error {
+	checker, ok := a.plugin.(driver.MetadataLockChecker)
+	if !ok {
+		return nil
+	}
+	config, err := checker.GetMetadataLockCheckConfig(context.TODO())
+	if err != nil {
+		return a.saveMetadataLockCheckResult(executeSQL, fmt.Sprintf("元数据锁检查配置错误: %v", err), err)
+	}
+	if config == nil {
+		return nil
+	}
+
+	startAt := time.Now()
+	waiting := false
+	for {
+		if a.hasTermination() {
+			return a.saveMetadataLockCheckResult(executeSQL, "等待阻塞会话结束时上线被中止", errMetadataLockCheckTerminated)
+		}
+		blockers, err := checker.GetMetadataLockBlockers(context.TODO(), executeSQL.Content)
+		if err != nil {
+			return a.saveMetadataLockCheckResult(executeSQL, fmt.Sprintf("检查元数据锁失败: %v", err), err)
+		}
+		if len(blockers) == 0 {
+			if !waiting {
+				return nil
+			}
+			result := fmt.Sprintf("等待 %d 秒后阻塞会话已结束", int64(time.Since(startAt).Seconds()))
+			return a.saveMetadataLockCheckResult(executeSQL, result, nil)
+		}
+
+		sessions := formatMetadataLockBlockers(blockers)
+		switch config.Policy {
+		case driver.MetadataLockCheckPolicyPrompt:
+			return a.waitForConfirmation(executeSQL, sessions)
+		case driver.MetadataLockCheckPolicyWait:
+			if int64(time.Since(startAt).Seconds()) < config.MaxWaitSeconds {
+				break
+			}
+			fallthrough
+		default:
+			return a.saveMetadataLockCheckResult(executeSQL, fmt.Sprintf("存在阻塞 DDL 的会话，拒绝执行 DDL: %s", sessions),
+				fmt.Errorf("the DDL is blocked by %d sessions", len(blockers)))
+		}
+		if !waiting {
+			waiting = true
+			a.entry.Infof("SQL %d is blocked by %d sessions, wait for them to finish", executeSQL.Number, len(blockers))
+			result := fmt.Sprintf("存在阻塞 DDL 的会话，等待会话结束: %s", sessions)
+			if err := a.saveMetadataLockCheckResult(executeSQL, result, nil); err != nil {
+				return err
+			}
+		}
+		time.Sleep(metadataLockCheckInterval)
+	}
+}
+
+// waitForConfirmation pauses the DDL until the executor confirms to execute it, the confirmation
+// is written to the exec status of SQL by ConfirmTaskSQLOnWorkflowV1.
+func (a *action) waitForConfirmation(executeSQL *model.ExecuteSQL, sessions string) error {
+	a.entry.Infof("SQL %d is blocked by other sessions, wait for the executor to confirm", executeSQL.Number)
+	executeSQL.ExecStatus = model.SQLExecuteStatusWaitingForConfirm
+	result := fmt.Sprintf("存在阻塞 DDL 的会话，等待执行人确认是否执行 DDL: %s", sessions)
+	if err := a.saveMetadataLockCheckResult(executeSQL, result, nil); err != nil {
+		return err
+	}
+	for {
+		time.Sleep(metadataLockCheckInterval)
+		if a.hasTermination() {
+			return a.saveMetadataLockCheckResult(executeSQL, "等待执行人确认时上线被中止", errMetadataLockCheckTerminated)
+		}
+		status, err := model.GetStorage().GetExecuteSQLStatusById(executeSQL.ID)
+		if err != nil {
+			return err
+		}
+		if status != model.SQLExecuteStatusWaitingForConfirm {
+			executeSQL.ExecStatus = status
+			result := fmt.Sprintf("存在阻塞 DDL 的会话，执行人已确认执行 DDL: %s", sessions)
+			return a.saveMetadataLockCheckResult(executeSQL, result, nil)
+		}
+	}
+}
+
+func formatMetadataLockBlockers(blockers []*driver.MetadataLockBlocker) string {
+	sessions := make([]string, 0, len(blockers))
+	for _, b := range blockers {
+		session := fmt.Sprintf("连接 %s(%s@%s) 已运行 %d 秒", b.ConnectionId, b.User, b.Host, b.ElapsedSeconds)
+		if b.Table != "" {
+			session = fmt.Sprintf("%s，持有表 %s 的 %s 锁", session, b.Table, b.LockType)
+		}
+		if b.SQL != "" {
+			sql := b.SQL
+			if len([]rune(sql)) > maxBlockerSQLLength {
+				sql = string([]rune(sql)[:maxBlockerSQLLength]) + "..."
+			}
+			session = fmt.Sprintf("%s，正在执行: %s", session, sql)
+		}
+		sessions = append(sessions, session)
+	}
+	return strings.Join(sessions, "; ")
+}
+
+func (a *action) saveMetadataLockCheckResult(executeSQL *model.ExecuteSQL, result string, checkErr error) error {
+	executeSQL.MetadataLockCheckResult = result
+	if checkErr != nil {
+		executeSQL.ExecStatus = model.SQLExecuteStatusFailed
+		executeSQL.ExecResult = fmt.Sprintf("%v, %s", checkErr, result)
+		if checkErr == errMetadataLockCheckTerminated {
+			executeSQL.ExecStatus = model.SQLExecuteStatusTerminateSucc
+			executeSQL.ExecResult = checkErr.Error()
+		}
+	}
+	if err := model.GetStorage().Save(executeSQL); err != nil {
+		return err
+	}
+	return checkErr
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/model"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var mockBlocker = &driver.MetadataLockBlocker{
+	ConnectionId:   "10",
+	User:           "app",
+	Host:           "127.0.0.1",
+	Table:          "db1.t1",
+	LockType:       "SHARED_READ",
+	ElapsedSeconds: 100,
+	SQL:            "select * from t1",
+}
+
+// expectSaveMetadataLockCheckResult expects the exec status and the result of check of the SQL is saved.
+func expectSaveMetadataLockCheckResult(mock sqlmock.Sqlmock, execStatus, checkResult string) {
+	expectSaveExecuteSQL(mock, map[string]interface{}{
+		"exec_status":                execStatus,
+		"metadata_lock_check_result": checkResult,
+	})
+}
+
+func TestCheckMetadataLocks(t *testing.T) {
+	blocked := "连接 10(app@127.0.0.1) 已运行 100 秒，持有表 db1.t1 的 SHARED_READ 锁，正在执行: select * from t1"
+	cases := []struct {
+		name        string
+		policy      driver.MetadataLockCheckPolicy
+		blockers    [][]*driver.MetadataLockBlocker
+		execStatus  string
+		checkResult string
+		wantErr     bool
+	}{
+		{
+			name:     "no blocker",
+			policy:   driver.MetadataLockCheckPolicyRefuse,
+			blockers: [][]*driver.MetadataLockBlocker{nil},
+		},
+		{
+			name:        "refuse",
+			policy:      driver.MetadataLockCheckPolicyRefuse,
+			blockers:    [][]*driver.MetadataLockBlocker{{mockBlocker}},
+			execStatus:  model.SQLExecuteStatusFailed,
+			checkResult: "存在阻塞 DDL 的会话，拒绝执行 DDL: " + blocked,
+			wantErr:     true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			model.InitMockStorage(mockDB)
+
+			act := getGuardAction(&guardDriver{
+				mdlConfig: &driver.MetadataLockCheckConfig{Policy: c.policy, MaxWaitSeconds: 60},
+				blockers:  c.blockers,
+			}, "alter table t1 add column a int")
+			if c.checkResult != "" {
+				expectSaveMetadataLockCheckResult(mock, c.execStatus, c.checkResult)
+			}
+			err = act.checkMetadataLocks(act.task.ExecuteSQLs[0])
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCheckMetadataLocksWait(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	model.InitMockStorage(mockDB)
+
+	act := getGuardAction(&guardDriver{
+		mdlConfig: &driver.MetadataLockCheckConfig{Policy: driver.MetadataLockCheckPolicyWait, MaxWaitSeconds: 60},
+		blockers:  [][]*driver.MetadataLockBlocker{{mockBlocker}, nil},
+	}, "alter table t1 add column a int")
+	expectSaveMetadataLockCheckResult(mock, model.SQLExecuteStatusInitialized, "存在阻塞 DDL 的会话，等待会话结束: "+
+		"连接 10(app@127.0.0.1) 已运行 100 秒，持有表 db1.t1 的 SHARED_READ 锁，正在执行: select * from t1")
+	expectSaveMetadataLockCheckResult(mock, model.SQLExecuteStatusInitialized, "等待 2 秒后阻塞会话已结束")
+	assert.NoError(t, act.checkMetadataLocks(act.task.ExecuteSQLs[0]))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckMetadataLocksPrompt(t *testing.T) {
+	blocked := "连接 10(app@127.0.0.1) 已运行 100 秒，持有表 db1.t1 的 SHARED_READ 锁，正在执行: select * from t1"
+	newAction := func() *action {
+		return getGuardAction(&guardDriver{
+			mdlConfig: &driver.MetadataLockCheckConfig{Policy: driver.MetadataLockCheckPolicyPrompt},
+			blockers:  [][]*driver.MetadataLockBlocker{{mockBlocker}},
+		}, "alter table t1 add column a int")
+	}
+
+	t.Run("confirmed", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		model.InitMockStorage(mockDB)
+
+		act := newAction()
+		expectSaveMetadataLockCheckResult(mock, model.SQLExecuteStatusWaitingForConfirm, "存在阻塞 DDL 的会话，等待执行人确认是否执行 DDL: "+blocked)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT exec_status FROM `execute_sql_detail`")).
+			WillReturnRows(sqlmock.NewRows([]string{"exec_status"}).AddRow(model.SQLExecuteStatusDoing))
+		expectSaveMetadataLockCheckResult(mock, model.SQLExecuteStatusDoing, "存在阻塞 DDL 的会话，执行人已确认执行 DDL: "+blocked)
+		assert.NoError(t, act.checkMetadataLocks(act.task.ExecuteSQLs[0]))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("terminated", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		model.InitMockStorage(mockDB)
+
+		act := newAction()
+		expectSaveMetadataLockCheckResult(mock, model.SQLExecuteStatusWaitingForConfirm, "存在阻塞 DDL 的会话，等待执行人确认是否执行 DDL: "+blocked)
+		expectSaveMetadataLockCheckResult(mock, model.SQLExecuteStatusTerminateSucc, "等待执行人确认时上线被中止")
+		// terminate while waiting for confirmation
+		time.AfterFunc(metadataLockCheckInterval/2, act.terminate)
+		assert.Equal(t, errMetadataLockCheckTerminated, act.checkMetadataLocks(act.task.ExecuteSQLs[0]))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
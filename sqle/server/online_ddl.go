@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/model"
+)
+
+// reportOnlineDDL reports the progress of the online DDL executed by the SQL and applies the
+// control saved by user. The control is read from storage, so that it works no matter which
+// node the control request is sent to. It returns nil progress if no online DDL running.
+func (a *action) reportOnlineDDL(c driver.OnlineDDLController, executeSQL *model.ExecuteSQL,
+	applied *driver.OnlineDDLControl) (*driver.OnlineDDLProgress, *driver.OnlineDDLControl) {
+	progress, err := c.GetOnlineDDLProgress(context.TODO())
+	if err != nil {
+		a.entry.Errorf("get online DDL progress error: %v", err)
+		return nil, applied
+	}
+	if progress == nil {
+		return nil, applied
+	}
+	a.saveOnlineDDLProgress(executeSQL, progress, true)
+	return progress, a.applyOnlineDDLControl(c, executeSQL, applied)
+}
+
+func (a *action) saveOnlineDDLProgress(executeSQL *model.ExecuteSQL, progress *driver.OnlineDDLProgress, running bool) {
+	err := model.GetStorage().SaveOnlineDDLProgress(&model.OnlineDDLExecution{
+		TaskId:         executeSQL.TaskId,
+		ExecuteSQLId:   executeSQL.ID,
+		Number:         executeSQL.Number,
+		Running:        running,
+		RowsCopied:     progress.RowsCopied,
+		RowsEstimate:   progress.RowsEstimate,
+		ProgressPct:    progress.ProgressPct,
+		ETASeconds:     progress.ETASeconds,
+		Throttled:      progress.Throttled,
+		ThrottleReason: progress.ThrottleReason,
+	})
+	if err != nil {
+		a.entry.Errorf("save online DDL progress error: %v", err)
+	}
+}
+
+// applyOnlineDDLControl applies the control saved by user if it is changed, it returns the
+// control applied to the online DDL.
+func (a *action) applyOnlineDDLControl(c driver.OnlineDDLController, executeSQL *model.ExecuteSQL,
+	applied *driver.OnlineDDLControl) *driver.OnlineDDLControl {
+	execution, exist, err := model.GetStorage().GetOnlineDDLExecutionByExecuteSQLId(executeSQL.ID)
+	if err != nil {
+		a.entry.Errorf("get online DDL control error: %v", err)
+		return applied
+	}
+	if !exist {
+		return applied
+	}
+	control := &driver.OnlineDDLControl{
+		Paused:       execution.Paused,
+		NiceRatio:    execution.NiceRatio,
+		MaxLagMillis: execution.MaxLagMillis,
+		ChunkSize:    execution.ChunkSize,
+	}
+	if applied != nil && applied.Equal(control) {
+		return applied
+	}
+	if _, err := c.ControlOnlineDDL(context.TODO(), control); err != nil {
+		a.entry.Errorf("control online DDL error: %v", err)
+		return applied
+	}
+	niceRatio := "unchanged"
+	if control.NiceRatio != nil {
+		niceRatio = fmt.Sprintf("%v", *control.NiceRatio)
+	}
+	a.entry.Infof("online DDL of SQL %d is controlled, paused: %v, nice ratio: %s, max lag millis: %v, chunk size: %v",
+		executeSQL.Number, control.Paused, niceRatio, control.MaxLagMillis, control.ChunkSize)
+	return control
+}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/dms"
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/log"
+	"github.com/actiontech/sqle/sqle/model"
+
+	xerrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// rehearsalLocks serializes the rehearsals on the same sandbox instance, the schemas
+// recreated by rehearsals have the same name as the schemas of target instance.
+var rehearsalLocks sync.Map
+
+func lockRehearsalInstance(instanceId uint64) func() {
+	l, _ := rehearsalLocks.LoadOrStore(instanceId, &sync.Mutex{})
+	mu := l.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// RehearseWorkflowTasks rehearses the tasks of workflow on the sandbox instance configured in
+// workflow template, the rehearsal reports are saved for the approvers of workflow.
+func RehearseWorkflowTasks(projectUid string, template *model.WorkflowTemplate, tasks []*model.Task) {
+	if template.RehearsalInstanceName == "" {
+		return
+	}
+	entry := log.NewEntry().WithField("rehearsal_instance", template.RehearsalInstanceName)
+	// it runs in its own goroutine, a panic must not crash the server.
+	defer func() {
+		if r := recover(); r != nil {
+			debug.PrintStack()
+			entry.Errorf("rehearse workflow tasks panic: %v", r)
+		}
+	}()
+	sandbox, exist, err := dms.GetInstanceInProjectByName(context.Background(), projectUid, template.RehearsalInstanceName)
+	if err != nil {
+		entry.Errorf("get sandbox instance error: %v", err)
+		return
+	}
+	if !exist {
+		sandbox = nil
+	}
+	for _, task := range tasks {
+		rehearseTask(entry.WithField("task_id", task.ID), task.ID, task.Instance, sandbox, template.RehearsalInstanceName)
+	}
+}
+
+func rehearseTask(l *logrus.Entry, taskId uint, instance *model.Instance, sandbox *model.Instance, sandboxName string) {
+	st := model.GetStorage()
+	startAt := time.Now()
+	rehearsal := &model.TaskRehearsal{
+		TaskId:       taskId,
+		InstanceName: sandboxName,
+		Status:       model.TaskRehearsalStatusRunning,
+		StartAt:      &startAt,
+	}
+	if err := st.ReplaceTaskRehearsal(rehearsal); err != nil {
+		l.Errorf("save rehearsal error: %v", err)
+		return
+	}
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				debug.PrintStack()
+				l.Errorf("rehearse task panic: %v", r)
+				err = fmt.Errorf("rehearsal is interrupted by an unknown error, check std.log for details")
+			}
+		}()
+		task, exist, err := st.GetTaskDetailById(strconv.Itoa(int(taskId)))
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return fmt.Errorf("task not exist")
+		}
+		task.Instance = instance
+		return rehearse(l, task, sandbox, rehearsal)
+	}()
+
+	endAt := time.Now()
+	rehearsal.EndAt = &endAt
+	rehearsal.Status = model.TaskRehearsalStatusSucceeded
+	if err != nil {
+		l.Errorf("rehearse task error: %v", err)
+		rehearsal.Status = model.TaskRehearsalStatusFailed
+		rehearsal.ErrorMessage = err.Error()
+	}
+	for _, sql := range rehearsal.SQLs {
+		if sql.ExecStatus == model.SQLExecuteStatusFailed {
+			rehearsal.Status = model.TaskRehearsalStatusFailed
+		}
+	}
+	if err := st.Save(rehearsal); err != nil {
+		l.Errorf("save rehearsal error: %v", err)
+	}
+}
+
+// rehearse recreates the schemas used by the task on the sandbox instance, executes the SQLs
+// of task on it and drops the schemas at last. Only the table definitions are recreated, so
+// the RowAffects of DML is the number of rows affected on empty tables.
+func rehearse(l *logrus.Entry, task *model.Task, sandbox *model.Instance, rehearsal *model.TaskRehearsal) error {
+	ctx := context.Background()
+	if sandbox == nil {
+		return fmt.Errorf("sandbox instance %s not exist", rehearsal.InstanceName)
+	}
+	if task.Instance == nil {
+		return fmt.Errorf("the instance of task not exist")
+	}
+	if sandbox.ID == task.Instance.ID {
+		return fmt.Errorf("sandbox instance can not be the instance of task")
+	}
+	if sandbox.DbType != task.Instance.DbType {
+		return fmt.Errorf("the db type of sandbox instance is %s, but the db type of task is %s", sandbox.DbType, task.Instance.DbType)
+	}
+
+	target, err := newDriverManagerWithAudit(l, task.Instance, task.Schema, task.DBType, nil)
+	if err != nil {
+		return xerrors.Wrap(err, "open plugin of task instance")
+	}
+	defer target.Close(ctx)
+	targetRehearser, ok := target.(driver.Rehearser)
+	if !ok {
+		return fmt.Errorf("rehearsal is not supported by db type %s", task.DBType)
+	}
+	schemas, err := getRehearsalSchemas(ctx, task, target, targetRehearser)
+	if err != nil {
+		return err
+	}
+
+	defer lockRehearsalInstance(sandbox.ID)()
+	admin, err := newDriverManagerWithAudit(l, sandbox, "", sandbox.DbType, nil)
+	if err != nil {
+		return xerrors.Wrap(err, "open plugin of sandbox instance")
+	}
+	defer admin.Close(ctx)
+	adminRehearser, ok := admin.(driver.Rehearser)
+	if !ok {
+		return fmt.Errorf("rehearsal is not supported by db type %s", sandbox.DbType)
+	}
+
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := adminRehearser.CreateSchemaWithTables(ctx, name, schemas[name]); err != nil {
+			return xerrors.Wrapf(err, "recreate schema %s on sandbox instance", name)
+		}
+		defer func(name string) {
+			if err := adminRehearser.DropSchema(ctx, name); err != nil {
+				l.Errorf("drop schema %s on sandbox instance error: %v", name, err)
+			}
+		}(name)
+	}
+
+	p, err := newDriverManagerWithAudit(l, sandbox, task.Schema, sandbox.DbType, nil)
+	if err != nil {
+		return xerrors.Wrap(err, "open plugin of sandbox instance")
+	}
+	defer p.Close(ctx)
+	rehearsal.SQLs = rehearseSQLs(ctx, p, task.ExecuteSQLs, func(sql string) error {
+		return checkRehearsalSchemas(ctx, targetRehearser, sql, schemas)
+	})
+	return nil
+}
+
+// checkRehearsalSchemas returns error if the SQL names a schema which is not recreated on the
+// sandbox instance, e.g. "DROP DATABASE db1", it must not be executed on the schemas of the
+// sandbox instance itself.
+func checkRehearsalSchemas(ctx context.Context, r driver.Rehearser, sql string, schemas map[string][]string) error {
+	names, err := r.ExtractSchemasFromSQL(ctx, sql)
+	if err != nil {
+		return xerrors.Wrap(err, "extract schemas from SQL")
+	}
+	for _, name := range names {
+		if _, ok := schemas[name]; !ok {
+			return fmt.Errorf("schema %s is not recreated on the sandbox instance, the SQL can not be rehearsed", name)
+		}
+	}
+	return nil
+}
+
+// getRehearsalSchemas returns the CREATE TABLE statements of the existing tables used by the
+// SQLs of task, grouped by schema.
+func getRehearsalSchemas(ctx context.Context, task *model.Task, p driver.Plugin, r driver.Rehearser) (map[string][]string, error) {
+	schemas := map[string][]string{}
+	if task.Schema != "" {
+		schemas[task.Schema] = []string{}
+	}
+	visited := map[string]struct{}{}
+	for _, executeSQL := range task.ExecuteSQLs {
+		tables, err := p.ExtractTableFromSQL(ctx, executeSQL.Content)
+		if err != nil {
+			return nil, xerrors.Wrapf(err, "extract tables from SQL %d", executeSQL.Number)
+		}
+		for _, table := range tables {
+			schema := table.Schema
+			if schema == "" {
+				schema = task.Schema
+			}
+			if schema == "" {
+				continue
+			}
+			key := fmt.Sprintf("%s.%s", schema, table.Name)
+			if _, ok := visited[key]; ok {
+				continue
+			}
+			visited[key] = struct{}{}
+			if _, ok := schemas[schema]; !ok {
+				schemas[schema] = []string{}
+			}
+			// the table not exist may be created by the task.
+			createTableSQL, exist, err := r.ShowCreateTable(ctx, schema, table.Name)
+			if err != nil {
+				return nil, xerrors.Wrapf(err, "show create table %s", key)
+			}
+			if exist {
+				schemas[schema] = append(schemas[schema], createTableSQL)
+			}
+		}
+	}
+	return schemas, nil
+}
+
+// rehearseSQLs executes the SQLs one by one and stops at the first failed SQL like execution,
+// the SQL is failed without execution if check returns error.
+func rehearseSQLs(ctx context.Context, p driver.Plugin, executeSQLs []*model.ExecuteSQL, check func(sql string) error) []*model.RehearsalSQL {
+	sqls := make([]*model.RehearsalSQL, 0, len(executeSQLs))
+	failed := false
+	for _, executeSQL := range executeSQLs {
+		sql := &model.RehearsalSQL{
+			ExecuteSQLId: executeSQL.ID,
+			Number:       executeSQL.Number,
+			Content:      executeSQL.Content,
+			ExecStatus:   model.SQLExecuteStatusInitialized,
+		}
+		sqls = append(sqls, sql)
+		if failed {
+			continue
+		}
+		if err := check(executeSQL.Content); err != nil {
+			failed = true
+			sql.ExecStatus = model.SQLExecuteStatusFailed
+			sql.ExecResult = err.Error()
+			continue
+		}
+		start := time.Now()
+		result, err := p.Exec(ctx, executeSQL.Content)
+		sql.ExecDurationMs = time.Since(start).Milliseconds()
+		if err != nil {
+			failed = true
+			sql.ExecStatus = model.SQLExecuteStatusFailed
+			sql.ExecResult = err.Error()
+			continue
+		}
+		sql.ExecStatus = model.SQLExecuteStatusSucceeded
+		sql.ExecResult = model.TaskExecResultOK
+		if result != nil {
+			sql.RowAffects, _ = result.RowsAffected()
+		}
+	}
+	return sqls
+}
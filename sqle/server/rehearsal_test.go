@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	_driver "database/sql/driver"
+	"fmt"
+	"testing"
+
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/model"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type rehearsalDriver struct {
+	extractTableDriver
+	createTables map[string]string
+	failedSQL    string
+	sqlSchemas   map[string][]string
+}
+
+func (d *rehearsalDriver) Exec(ctx context.Context, query string) (_driver.Result, error) {
+	if query == d.failedSQL {
+		return nil, fmt.Errorf("mock error: %s", query)
+	}
+	return sqlmock.NewResult(0, 2), nil
+}
+
+func (d *rehearsalDriver) ShowCreateTable(ctx context.Context, schema, table string) (string, bool, error) {
+	createTableSQL, ok := d.createTables[fmt.Sprintf("%s.%s", schema, table)]
+	return createTableSQL, ok, nil
+}
+
+func (d *rehearsalDriver) CreateSchemaWithTables(ctx context.Context, schema string, createTableSQLs []string) error {
+	return nil
+}
+
+func (d *rehearsalDriver) DropSchema(ctx context.Context, schema string) error {
+	return nil
+}
+
+func (d *rehearsalDriver) ExtractSchemasFromSQL(ctx context.Context, sql string) ([]string, error) {
+	return d.sqlSchemas[sql], nil
+}
+
+func TestGetRehearsalSchemas(t *testing.T) {
+	p := &rehearsalDriver{
+		extractTableDriver: extractTableDriver{tables: map[string][]*driverV2.Table{
+			"create table t3 (id int)":                 {{Name: "t3"}},
+			"insert into t1 select * from db2.t2":      {{Name: "t1"}, {Name: "t2", Schema: "db2"}},
+			"update t1 join t3 on t1.id=t3.id set a=1": {{Name: "t1"}, {Name: "t3"}},
+		}},
+		createTables: map[string]string{
+			"db1.t1": "CREATE TABLE `t1` (`id` int)",
+			"db2.t2": "CREATE TABLE `t2` (`id` int)",
+		},
+	}
+	task := &model.Task{Schema: "db1"}
+	for _, sql := range []string{
+		"create table t3 (id int)",
+		"insert into t1 select * from db2.t2",
+		"update t1 join t3 on t1.id=t3.id set a=1",
+	} {
+		task.ExecuteSQLs = append(task.ExecuteSQLs, &model.ExecuteSQL{BaseSQL: model.BaseSQL{Content: sql}})
+	}
+
+	schemas, err := getRehearsalSchemas(context.TODO(), task, p, p)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"db1": {"CREATE TABLE `t1` (`id` int)"},
+		"db2": {"CREATE TABLE `t2` (`id` int)"},
+	}, schemas)
+}
+
+func TestRehearseSQLs(t *testing.T) {
+	p := &rehearsalDriver{failedSQL: "alter table t1 add column a int"}
+	executeSQLs := []*model.ExecuteSQL{}
+	for i, sql := range []string{
+		"update t1 set b=1",
+		"alter table t1 add column a int",
+		"update t1 set a=1",
+	} {
+		executeSQLs = append(executeSQLs, &model.ExecuteSQL{BaseSQL: model.BaseSQL{
+			Model:   model.Model{ID: uint(i + 10)},
+			Number:  uint(i + 1),
+			Content: sql,
+		}})
+	}
+
+	sqls := rehearseSQLs(context.TODO(), p, executeSQLs, func(sql string) error { return nil })
+	assert.Len(t, sqls, 3)
+
+	assert.Equal(t, uint(10), sqls[0].ExecuteSQLId)
+	assert.Equal(t, model.SQLExecuteStatusSucceeded, sqls[0].ExecStatus)
+	assert.Equal(t, int64(2), sqls[0].RowAffects)
+
+	assert.Equal(t, model.SQLExecuteStatusFailed, sqls[1].ExecStatus)
+	assert.Equal(t, "mock error: alter table t1 add column a int", sqls[1].ExecResult)
+
+	// the SQLs after the failed SQL are not executed
+	assert.Equal(t, model.SQLExecuteStatusInitialized, sqls[2].ExecStatus)
+	assert.Equal(t, uint(3), sqls[2].Number)
+}
+
+func TestRehearseSQLs_SchemaNotRecreated(t *testing.T) {
+	p := &rehearsalDriver{sqlSchemas: map[string][]string{
+		"update db1.t1 set b=1": {"db1"},
+		"drop database db3":     {"db3"},
+		"update db2.t2 set b=1": {"db2"},
+	}}
+	schemas := map[string][]string{"db1": {}, "db2": {}}
+	executeSQLs := []*model.ExecuteSQL{}
+	for i, sql := range []string{
+		"update db1.t1 set b=1",
+		"drop database db3",
+		"update db2.t2 set b=1",
+	} {
+		executeSQLs = append(executeSQLs, &model.ExecuteSQL{BaseSQL: model.BaseSQL{Number: uint(i + 1), Content: sql}})
+	}
+
+	sqls := rehearseSQLs(context.TODO(), p, executeSQLs, func(sql string) error {
+		return checkRehearsalSchemas(context.TODO(), p, sql, schemas)
+	})
+	assert.Len(t, sqls, 3)
+	assert.Equal(t, model.SQLExecuteStatusSucceeded, sqls[0].ExecStatus)
+	assert.Equal(t, model.SQLExecuteStatusFailed, sqls[1].ExecStatus)
+	assert.Equal(t, "schema db3 is not recreated on the sandbox instance, the SQL can not be rehearsed", sqls[1].ExecResult)
+	assert.Equal(t, model.SQLExecuteStatusInitialized, sqls[2].ExecStatus)
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/model"
+)
+
+var errReplicaLagGuardTerminated = fmt.Errorf("the execution is terminated while waiting for the replica lag to drop")
+
+// guardReplicaLag checks the replica lag before the SQLs are executed, it is called before each
+// execution of task so that the lag is checked both before the execution and between the SQLs.
+// The SQLs are marked as failed if the guard does not pass, and the result of guard is recorded
+// on the SQLs anyway.
+func (a *action) guardReplicaLag(executeSQLs ...*model.ExecuteSQL) error {
+	guard, ok := a.plugin.(driver.ReplicaLagGuard)
+	if !ok {
+		return nil
+	}
+	config, err := guard.GetReplicaLagGuardConfig(context.TODO())
+	if err != nil {
+		return a.saveReplicaLagGuardResult(executeSQLs, fmt.Sprintf("从库延迟检查配置错误: %v", err), err)
+	}
+	if config == nil {
+		return nil
+	}
+
+	paused := false
+	startAt := time.Now()
+	for {
+		if a.hasTermination() {
+			return a.saveReplicaLagGuardResult(executeSQLs, "等待从库延迟恢复时上线被中止", errReplicaLagGuardTerminated)
+		}
+		lag, ok, err := guard.GetReplicaLag(context.TODO())
+		if err != nil {
+			return a.saveReplicaLagGuardResult(executeSQLs, fmt.Sprintf("获取从库延迟失败: %v", err), err)
+		}
+		if !ok {
+			return a.saveReplicaLagGuardResult(executeSQLs, "未发现从库，跳过从库延迟检查", nil)
+		}
+		if lag <= config.MaxLagSeconds {
+			result := fmt.Sprintf("从库延迟 %d 秒，未超过阈值 %d 秒", lag, config.MaxLagSeconds)
+			if paused {
+				result = fmt.Sprintf("%s，暂停等待 %d 秒后继续上线", result, int64(time.Since(startAt).Seconds()))
+			}
+			return a.saveReplicaLagGuardResult(executeSQLs, result, nil)
+		}
+		if !config.PauseOnLag {
+			return a.saveReplicaLagGuardResult(executeSQLs, fmt.Sprintf("从库延迟 %d 秒，超过阈值 %d 秒", lag, config.MaxLagSeconds),
+				fmt.Errorf("replica lag %ds exceeds %ds", lag, config.MaxLagSeconds))
+		}
+		if !paused {
+			paused = true
+			a.entry.Infof("replica lag %ds exceeds %ds, pause the execution until the replicas catch up", lag, config.MaxLagSeconds)
+			result := fmt.Sprintf("从库延迟 %d 秒，超过阈值 %d 秒，暂停上线等待延迟恢复", lag, config.MaxLagSeconds)
+			if err := a.saveReplicaLagGuardResult(executeSQLs, result, nil); err != nil {
+				return err
+			}
+		}
+		time.Sleep(replicaLagCheckInterval)
+	}
+}
+
+func (a *action) saveReplicaLagGuardResult(executeSQLs []*model.ExecuteSQL, result string, guardErr error) error {
+	for _, executeSQL := range executeSQLs {
+		executeSQL.ReplicaLagGuardResult = result
+		if guardErr != nil {
+			executeSQL.ExecStatus = model.SQLExecuteStatusFailed
+			executeSQL.ExecResult = guardErr.Error()
+			if guardErr == errReplicaLagGuardTerminated {
+				executeSQL.ExecStatus = model.SQLExecuteStatusTerminateSucc
+			}
+		}
+	}
+	if err := model.GetStorage().UpdateExecuteSQLs(executeSQLs); err != nil {
+		return err
+	}
+	return guardErr
+}
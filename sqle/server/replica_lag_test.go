@@ -0,0 +1,56 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/driver"
+	"github.com/actiontech/sqle/sqle/model"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectUpdateExecuteSQL expects the exec status and the result of guard of the SQL is saved.
+func expectUpdateExecuteSQL(mock sqlmock.Sqlmock, execStatus, guardResult string) {
+	expectSaveExecuteSQL(mock, map[string]interface{}{
+		"exec_status":              execStatus,
+		"replica_lag_guard_result": guardResult,
+	})
+}
+
+func TestGuardReplicaLag(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	model.InitMockStorage(mockDB)
+
+	plugin := &guardDriver{lagConfig: &driver.ReplicaLagGuardConfig{MaxLagSeconds: 10, PauseOnLag: true}, lags: []int64{3}}
+	act := getGuardAction(plugin, "update t1 set a=1")
+	expectUpdateExecuteSQL(mock, model.SQLExecuteStatusInitialized, "从库延迟 3 秒，未超过阈值 10 秒")
+	assert.NoError(t, act.guardReplicaLag(act.task.ExecuteSQLs...))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGuardReplicaLagPaused(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	model.InitMockStorage(mockDB)
+
+	plugin := &guardDriver{lagConfig: &driver.ReplicaLagGuardConfig{MaxLagSeconds: 10, PauseOnLag: true}, lags: []int64{30, 5}}
+	act := getGuardAction(plugin, "update t1 set a=1")
+	expectUpdateExecuteSQL(mock, model.SQLExecuteStatusInitialized, "从库延迟 30 秒，超过阈值 10 秒，暂停上线等待延迟恢复")
+	expectUpdateExecuteSQL(mock, model.SQLExecuteStatusInitialized, "从库延迟 5 秒，未超过阈值 10 秒，暂停等待 1 秒后继续上线")
+	assert.NoError(t, act.guardReplicaLag(act.task.ExecuteSQLs...))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGuardReplicaLagFailed(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	model.InitMockStorage(mockDB)
+
+	plugin := &guardDriver{lagConfig: &driver.ReplicaLagGuardConfig{MaxLagSeconds: 10, PauseOnLag: false}, lags: []int64{30}}
+	act := getGuardAction(plugin, "update t1 set a=1")
+	expectUpdateExecuteSQL(mock, model.SQLExecuteStatusFailed, "从库延迟 30 秒，超过阈值 10 秒")
+	assert.EqualError(t, act.guardReplicaLag(act.task.ExecuteSQLs...), "replica lag 30s exceeds 10s")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
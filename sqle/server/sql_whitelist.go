@@ -0,0 +1,283 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/actiontech/sqle/sqle/common"
+	"github.com/actiontech/sqle/sqle/driver"
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/model"
+	"github.com/actiontech/sqle/sqle/notification"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SqlWhitelistExpirationJob struct {
+	BaseJob
+}
+
+func NewSqlWhitelistExpirationJob(entry *logrus.Entry) ServerJob {
+	entry = entry.WithField("job", "sql_whitelist_expiration")
+	j := &SqlWhitelistExpirationJob{}
+	j.BaseJob = *NewBaseJob(entry, 5*time.Minute, j.disableExpiredSqlWhitelist)
+	return j
+}
+
+// disableExpiredSqlWhitelist disables the expired whitelist and notifies the creator,
+// so that the waiver will not be kept forever without anyone noticing.
+func (j *SqlWhitelistExpirationJob) disableExpiredSqlWhitelist(entry *logrus.Entry) {
+	st := model.GetStorage()
+	whitelist, err := st.GetExpiredSqlWhitelist(time.Now())
+	if err != nil {
+		entry.Errorf("get expired sql whitelist from storage error: %v", err)
+		return
+	}
+	disabledIds := make([]string, 0, len(whitelist))
+	for _, wl := range whitelist {
+		if err := st.DisableSqlWhitelist(wl.ID); err != nil {
+			entry.Errorf("disable expired sql whitelist %d error: %v", wl.ID, err)
+			continue
+		}
+		disabledIds = append(disabledIds, strconv.FormatUint(uint64(wl.ID), 10))
+		if err := notification.NotifySqlWhitelistExpired(wl); err != nil {
+			entry.Errorf("notify creator of expired sql whitelist %d error: %v", wl.ID, err)
+		}
+	}
+	if len(disabledIds) > 0 {
+		entry.Infof("disable expired sql whitelist [%s] success", strings.Join(disabledIds, ", "))
+	}
+}
+
+// GenSqlWhitelistFingerprints computes the fingerprint of fp_match whitelist for the DB
+// type of each loaded plugin, it should be called before the whitelist is saved. The
+// fingerprint which fails to compute here is computed again at audit time.
+func GenSqlWhitelistFingerprints(l *logrus.Entry, wl *model.SqlWhitelist) {
+	wl.Fingerprints = model.SqlWhitelistFingerprints{}
+	if wl.MatchType != model.SQLWhitelistFPMatch {
+		return
+	}
+	for _, dbType := range driver.GetPluginManager().AllDrivers() {
+		p, err := common.NewDriverManagerWithoutCfg(l, dbType)
+		if err != nil {
+			l.Warnf("open plugin %s to compute whitelist fingerprint error: %v", dbType, err)
+			continue
+		}
+		node, err := parse(l, p, wl.Value)
+		p.Close(context.TODO())
+		if err != nil {
+			l.Warnf("parse whitelist sql by plugin %s error: %v", dbType, err)
+			continue
+		}
+		wl.Fingerprints[dbType] = node.Fingerprint
+	}
+}
+
+// SqlWhitelistTablePattern is the value of table_match whitelist, it looks like
+// "SELECT audit_log" or "INSERT db1.tmp_*". The first word is the statement type,
+// "*" means any statement. The table supports the wildcards of path.Match, and
+// the schema is optional.
+type SqlWhitelistTablePattern struct {
+	Statement string
+	Schema    string
+	Table     string
+}
+
+func ParseSqlWhitelistTablePattern(value string) (*SqlWhitelistTablePattern, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("table pattern should be \"<statement> [schema.]<table>\", e.g. \"SELECT audit_log\"")
+	}
+	pattern := &SqlWhitelistTablePattern{Statement: strings.ToUpper(fields[0])}
+	table := strings.ToLower(fields[1])
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		pattern.Schema, pattern.Table = table[:i], table[i+1:]
+	} else {
+		pattern.Table = table
+	}
+	for _, p := range []string{pattern.Schema, pattern.Table} {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid table pattern %s: %v", fields[1], err)
+		}
+	}
+	return pattern, nil
+}
+
+// Match returns true if the statement type matches and all of the tables used by SQL
+// match the pattern, so that the SQL using other tables is still audited.
+func (p *SqlWhitelistTablePattern) Match(statement string, tables []*driverV2.Table) bool {
+	if p.Statement != "*" && p.Statement != strings.ToUpper(statement) {
+		return false
+	}
+	if len(tables) == 0 {
+		return false
+	}
+	for _, table := range tables {
+		if ok, _ := path.Match(p.Table, strings.ToLower(table.Name)); !ok {
+			return false
+		}
+		if p.Schema == "" {
+			continue
+		}
+		if ok, _ := path.Match(p.Schema, strings.ToLower(table.Schema)); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckSqlWhitelistValue checks whether the value of whitelist is valid for the match type.
+func CheckSqlWhitelistValue(matchType, value string) error {
+	switch matchType {
+	case model.SQLWhitelistRegexMatch:
+		if _, err := compileSqlWhitelistRegexp(value); err != nil {
+			return fmt.Errorf("invalid regular expression: %v", err)
+		}
+	case model.SQLWhitelistTableMatch:
+		if _, err := ParseSqlWhitelistTablePattern(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compileSqlWhitelistRegexp anchors the regular expression, so that it must match the whole SQL
+// text, a SQL with anything appended to the whitelisted statement is not waived.
+func compileSqlWhitelistRegexp(value string) (*regexp.Regexp, error) {
+	return regexp.Compile(fmt.Sprintf("^(?:%s)$", value))
+}
+
+var statementKeywordRegexp = regexp.MustCompile(`^[A-Za-z]+`)
+
+// getStatementKeyword returns the first keyword of SQL, e.g. "SELECT", the leading
+// comments and brackets are skipped.
+func getStatementKeyword(sql string) string {
+	for {
+		sql = strings.TrimLeft(sql, " \t\r\n(")
+		switch {
+		case strings.HasPrefix(sql, "--") || strings.HasPrefix(sql, "#"):
+			i := strings.IndexByte(sql, '\n')
+			if i < 0 {
+				return ""
+			}
+			sql = sql[i+1:]
+		case strings.HasPrefix(sql, "/*"):
+			i := strings.Index(sql, "*/")
+			if i < 0 {
+				return ""
+			}
+			sql = sql[i+2:]
+		default:
+			return strings.ToUpper(statementKeywordRegexp.FindString(sql))
+		}
+	}
+}
+
+type sqlWhitelistRegexp struct {
+	whitelist model.SqlWhitelist
+	regexp    *regexp.Regexp
+}
+
+type sqlWhitelistTablePattern struct {
+	whitelist model.SqlWhitelist
+	pattern   *SqlWhitelistTablePattern
+}
+
+// sqlWhitelistMatcher matches SQL with whitelist. The exact_match and fp_match whitelist
+// are matched by hash set, so that the cost of matching does not grow with the size of
+// whitelist. The regex_match and table_match whitelist are evaluated one by one.
+type sqlWhitelistMatcher struct {
+	l           *logrus.Entry
+	p           driver.Plugin
+	exact       map[string] /*capitalized value*/ []model.SqlWhitelist
+	fingerprint map[string] /*fingerprint*/ []model.SqlWhitelist
+	regexps     []sqlWhitelistRegexp
+	tables      []sqlWhitelistTablePattern
+}
+
+// newSqlWhitelistMatcher builds the matcher from the whitelist which is effective for the
+// task. The fingerprint missing for the DB type of task, e.g. the plugin is loaded after the
+// whitelist is saved, is computed by p and saved for the next audit.
+func newSqlWhitelistMatcher(l *logrus.Entry, task *model.Task, p driver.Plugin, whitelist []model.SqlWhitelist) *sqlWhitelistMatcher {
+	m := &sqlWhitelistMatcher{
+		l:           l,
+		p:           p,
+		exact:       map[string][]model.SqlWhitelist{},
+		fingerprint: map[string][]model.SqlWhitelist{},
+	}
+	now := time.Now()
+	for _, wl := range whitelist {
+		if !wl.IsEffective(now) || !wl.InScope(task.InstanceName(), task.Schema) {
+			continue
+		}
+		switch wl.MatchType {
+		case model.SQLWhitelistRegexMatch:
+			re, err := compileSqlWhitelistRegexp(wl.Value)
+			if err != nil {
+				l.Errorf("compile regular expression of whitelist %d error: %v", wl.ID, err)
+				continue
+			}
+			m.regexps = append(m.regexps, sqlWhitelistRegexp{whitelist: wl, regexp: re})
+		case model.SQLWhitelistTableMatch:
+			pattern, err := ParseSqlWhitelistTablePattern(wl.Value)
+			if err != nil {
+				l.Errorf("parse table pattern of whitelist %d error: %v", wl.ID, err)
+				continue
+			}
+			m.tables = append(m.tables, sqlWhitelistTablePattern{whitelist: wl, pattern: pattern})
+		case model.SQLWhitelistFPMatch:
+			fp, ok := wl.Fingerprints[task.DBType]
+			if !ok {
+				node, err := parse(l, p, wl.Value)
+				if err != nil {
+					l.Errorf("parse whitelist sql error: %v,please check the accuracy of whitelist SQL: %s", err, wl.Value)
+					continue
+				}
+				fp = node.Fingerprint
+				if wl.Fingerprints == nil {
+					wl.Fingerprints = model.SqlWhitelistFingerprints{}
+				}
+				wl.Fingerprints[task.DBType] = fp
+				if err := model.GetStorage().UpdateSqlWhitelistFingerprints(wl.ID, wl.Fingerprints); err != nil {
+					l.Errorf("save fingerprint of whitelist %d error: %v", wl.ID, err)
+				}
+			}
+			m.fingerprint[fp] = append(m.fingerprint[fp], wl)
+		default:
+			m.exact[wl.CapitalizedValue] = append(m.exact[wl.CapitalizedValue], wl)
+		}
+	}
+	return m
+}
+
+// match returns the whitelist matched by node.
+func (m *sqlWhitelistMatcher) match(node driverV2.Node) []model.SqlWhitelist {
+	matched := []model.SqlWhitelist{}
+	matched = append(matched, m.exact[strings.ToUpper(node.Text)]...)
+	matched = append(matched, m.fingerprint[node.Fingerprint]...)
+	for _, r := range m.regexps {
+		if r.regexp.MatchString(node.Text) {
+			matched = append(matched, r.whitelist)
+		}
+	}
+	if len(m.tables) == 0 {
+		return matched
+	}
+	tables, err := m.p.ExtractTableFromSQL(context.TODO(), node.Text)
+	if err != nil {
+		m.l.Warnf("extract table from sql for table_match whitelist error: %v", err)
+		return matched
+	}
+	statement := getStatementKeyword(node.Text)
+	for _, t := range m.tables {
+		if t.pattern.Match(statement, tables) {
+			matched = append(matched, t.whitelist)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/log"
+	"github.com/actiontech/sqle/sqle/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqlWhitelistMatcher(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	newWhitelist := func(id uint, value, matchType string) model.SqlWhitelist {
+		wl := model.SqlWhitelist{
+			Model:            model.Model{ID: id},
+			Value:            value,
+			CapitalizedValue: value,
+			MatchType:        matchType,
+		}
+		if matchType == model.SQLWhitelistFPMatch {
+			wl.Fingerprints = model.SqlWhitelistFingerprints{"mysql": value}
+		}
+		return wl
+	}
+	disabled := newWhitelist(3, "SELECT 2", model.SQLWhitelistExactMatch)
+	disabled.Status = model.SQLWhitelistStatusDisabled
+	expired := newWhitelist(4, "SELECT 3", model.SQLWhitelistExactMatch)
+	expired.ExpiredAt = &past
+	outOfScope := newWhitelist(5, "SELECT 4", model.SQLWhitelistExactMatch)
+	outOfScope.InstanceName = "inst_2"
+	whitelist := []model.SqlWhitelist{
+		newWhitelist(1, "SELECT 1", model.SQLWhitelistExactMatch),
+		newWhitelist(2, "SELECT * FROM T1 WHERE ID=?", model.SQLWhitelistFPMatch),
+		disabled, expired, outOfScope,
+	}
+	task := &model.Task{DBType: "mysql", Schema: "db1", Instance: &model.Instance{Name: "inst_1"}}
+
+	// the fingerprint is computed already, the whitelist should not be parsed again.
+	m := newSqlWhitelistMatcher(log.NewEntry(), task, &mockDriver{parseError: true}, whitelist)
+
+	matched := m.match(driverV2.Node{Text: "select 1", Fingerprint: "SELECT 1"})
+	assert.Len(t, matched, 1)
+	assert.Equal(t, uint(1), matched[0].ID)
+
+	matched = m.match(driverV2.Node{Text: "select * from t1 where id=1", Fingerprint: "SELECT * FROM T1 WHERE ID=?"})
+	assert.Len(t, matched, 1)
+	assert.Equal(t, uint(2), matched[0].ID)
+
+	for _, sql := range []string{"SELECT 2", "SELECT 3", "SELECT 4"} {
+		assert.Len(t, m.match(driverV2.Node{Text: sql, Fingerprint: sql}), 0)
+	}
+}
+
+type extractTableDriver struct {
+	mockDriver
+	tables map[string][]*driverV2.Table
+}
+
+func (d *extractTableDriver) ExtractTableFromSQL(ctx context.Context, sql string) ([]*driverV2.Table, error) {
+	return d.tables[sql], nil
+}
+
+func TestSqlWhitelistMatcher_RegexAndTable(t *testing.T) {
+	whitelist := []model.SqlWhitelist{
+		{Model: model.Model{ID: 1}, Value: `(?i)^select .* from report_\w+`, MatchType: model.SQLWhitelistRegexMatch},
+		{Model: model.Model{ID: 2}, Value: "SELECT audit_log", MatchType: model.SQLWhitelistTableMatch},
+		{Model: model.Model{ID: 3}, Value: "insert db1.tmp_*", MatchType: model.SQLWhitelistTableMatch},
+		{Model: model.Model{ID: 4}, Value: "(", MatchType: model.SQLWhitelistRegexMatch},
+		{Model: model.Model{ID: 5}, Value: `select \d+`, MatchType: model.SQLWhitelistRegexMatch},
+	}
+	p := &extractTableDriver{tables: map[string][]*driverV2.Table{
+		"select * from report_2023":                  {{Name: "report_2023", Schema: "db1"}},
+		"/* hint */ select * from audit_log":         {{Name: "audit_log", Schema: "db1"}},
+		"delete from audit_log":                      {{Name: "audit_log", Schema: "db1"}},
+		"select * from audit_log join user":          {{Name: "audit_log", Schema: "db1"}, {Name: "user", Schema: "db1"}},
+		"insert into tmp_order values (1)":           {{Name: "tmp_order", Schema: "db1"}},
+		"insert into db2.tmp_order values (1)":       {{Name: "tmp_order", Schema: "db2"}},
+		"insert into tmp_order select * from orders": {{Name: "orders", Schema: "db1"}, {Name: "tmp_order", Schema: "db1"}},
+	}}
+	task := &model.Task{DBType: "mysql", Schema: "db1", Instance: &model.Instance{Name: "inst_1"}}
+	m := newSqlWhitelistMatcher(log.NewEntry(), task, p, whitelist)
+
+	for sql, expected := range map[string][]uint{
+		"select * from report_2023":                  {1},
+		"/* hint */ select * from audit_log":         {2},
+		"delete from audit_log":                      nil,
+		"select * from audit_log join user":          nil,
+		"insert into tmp_order values (1)":           {3},
+		"insert into db2.tmp_order values (1)":       nil,
+		"insert into tmp_order select * from orders": nil,
+		"select 1":                            {5},
+		"select 1 union select * from secret": nil,
+		"drop table t1; select 1":             nil,
+	} {
+		ids := []uint{}
+		for _, wl := range m.match(driverV2.Node{Text: sql}) {
+			ids = append(ids, wl.ID)
+		}
+		assert.ElementsMatch(t, expected, ids, sql)
+	}
+}
+
+func TestCheckSqlWhitelistValue(t *testing.T) {
+	assert.NoError(t, CheckSqlWhitelistValue(model.SQLWhitelistRegexMatch, `^select \d+$`))
+	assert.Error(t, CheckSqlWhitelistValue(model.SQLWhitelistRegexMatch, `(`))
+	assert.NoError(t, CheckSqlWhitelistValue(model.SQLWhitelistTableMatch, "* db1.tmp_*"))
+	assert.Error(t, CheckSqlWhitelistValue(model.SQLWhitelistTableMatch, "audit_log"))
+	assert.Error(t, CheckSqlWhitelistValue(model.SQLWhitelistTableMatch, "SELECT tmp_["))
+	assert.NoError(t, CheckSqlWhitelistValue(model.SQLWhitelistExactMatch, "("))
+}
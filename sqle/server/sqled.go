@@ -175,7 +175,7 @@ func (s *Sqled) do(action *action) error {
 	switch action.typ {
 	case ActionTypeAudit:
 		err = action.audit()
-	case ActionTypeExecute:
+	case ActionTypeExecute, ActionTypeResume:
 		err = action.execute()
 	case ActionTypeRollback:
 		err = action.rollback()
@@ -200,6 +200,8 @@ const (
 	ActionTypeAudit = iota + 1
 	ActionTypeExecute
 	ActionTypeRollback
+	// ActionTypeResume executes the failed task again from the failed SQL.
+	ActionTypeResume
 )
 
 // Action is an action for the task;
@@ -221,6 +223,10 @@ type action struct {
 
 	customRules []*model.CustomRule
 	rules       []*model.Rule
+
+	// binlogConnectionId is the id of connection executing SQLs, it is used to filter the
+	// binlog events when generating rollback SQL by binlog.
+	binlogConnectionId string
 }
 
 const (
@@ -260,6 +266,8 @@ var (
 	ErrActionRollbackOnRollbackedTask    = _errors.New("task has been rollbacked, can not do rollback on it")
 	ErrActionRollbackOnExecuteFailedTask = _errors.New("task has been executed failed, can not do rollback on it")
 	ErrActionRollbackOnNonExecutedTask   = _errors.New("task has not been executed, can not do rollback on it")
+	ErrActionResumeOnNonFailedTask       = _errors.New("task has not been executed failed, can not do resume on it")
+	ErrActionRollbackOnGeneratingTask    = _errors.New("rollback SQLs of task are being generated by binlog, can not do rollback on it")
 )
 
 // validation validate whether task can do action type(a.typ) or not.
@@ -276,7 +284,7 @@ func (a *action) validation(task *model.Task) error {
 			return errors.New(errors.TaskActionInvalid, ErrActionExecuteOnNonAuditedTask)
 		}
 	case ActionTypeRollback:
-		if task.HasDoingRollback() {
+		if task.HasDoingRollback() || task.AutoRollbackStatus != "" {
 			return errors.New(errors.TaskActionDone, ErrActionRollbackOnRollbackedTask)
 		}
 		if task.IsExecuteFailed() {
@@ -285,6 +293,13 @@ func (a *action) validation(task *model.Task) error {
 		if !task.HasDoingExecute() {
 			return errors.New(errors.TaskActionInvalid, ErrActionRollbackOnNonExecutedTask)
 		}
+		if task.BinlogRollbackStatus == model.TaskBinlogRollbackStatusGenerating {
+			return errors.New(errors.TaskActionInvalid, ErrActionRollbackOnGeneratingTask)
+		}
+	case ActionTypeResume:
+		if task.Status != model.TaskStatusExecuteFailed {
+			return errors.New(errors.TaskActionInvalid, ErrActionResumeOnNonFailedTask)
+		}
 	}
 	return nil
 }
@@ -318,11 +333,13 @@ func (a *action) audit() (err error) {
 			a.entry.Errorf("save rollback SQLs error:%v", err)
 			return err
 		}
-	}
 
-	if err = st.UpdateExecuteSQLs(a.task.ExecuteSQLs); err != nil {
-		a.entry.Errorf("save SQLs error:%v", err)
-		return err
+		// the audit results are saved batch by batch during audit, the SQLs are saved again
+		// since the reasons of generating rollback SQLs are appended to the results.
+		if err = st.UpdateExecuteSQLs(a.task.ExecuteSQLs); err != nil {
+			a.entry.Errorf("save SQLs error:%v", err)
+			return err
+		}
 	}
 
 	if err = st.UpdateTask(a.task, map[string]interface{}{
@@ -351,13 +368,15 @@ func (a *action) execute() (err error) {
 
 	a.entry.Info("start execution...")
 
+	execStartAt := time.Now()
 	attrs := map[string]interface{}{
 		"status":        model.TaskStatusExecuting,
-		"exec_start_at": time.Now(),
+		"exec_start_at": execStartAt,
 	}
 	if err = st.UpdateTask(task, attrs); err != nil {
 		return err
 	}
+	deadline := a.getExecutionDeadline(execStartAt)
 
 	exeErrChan := make(chan error)
 	terminateErrChan := make(chan error)
@@ -373,7 +392,7 @@ func (a *action) execute() (err error) {
 				case <-a.done:
 					return
 				default:
-					if a.GetTaskStatus(st) == model.TaskStatusTerminating {
+					if a.GetTaskStatus(st) == model.TaskStatusTerminating || a.exceedsExecutionDeadline(st, deadline) {
 						a.terminate()
 						ctx, cancel := context.WithTimeout(
 							context.Background(), time.Minute*2)
@@ -389,6 +408,8 @@ func (a *action) execute() (err error) {
 
 	// update task status
 	taskStatus := model.TaskStatusExecuting
+	// autoRollbackCause is empty if the task is not rolled back automatically.
+	autoRollbackCause := ""
 
 	select {
 	case e := <-exeErrChan:
@@ -406,6 +427,9 @@ func (a *action) execute() (err error) {
 				break
 			}
 		}
+		if taskStatus == model.TaskStatusExecuteFailed {
+			autoRollbackCause = "上线失败"
+		}
 
 	case terminationErr := <-terminateErrChan:
 		if terminationErr != nil {
@@ -428,6 +452,10 @@ func (a *action) execute() (err error) {
 
 		} else {
 			a.terminatedSuccessfully() // NOTE: 如果中止成功，SQLs 状态已经被更新
+			// the SQLs are rolled back automatically by the plugin of execution, so wait for
+			// the execution to return.
+			err = <-exeErrChan
+			autoRollbackCause = "上线被中止"
 		}
 		taskStatus = model.TaskStatusExecuteFailed
 
@@ -442,7 +470,55 @@ func (a *action) execute() (err error) {
 		"status":      taskStatus,
 		"exec_end_at": time.Now(),
 	}
-	return st.UpdateTask(task, attrs)
+	if err := st.UpdateTask(task, attrs); err != nil {
+		return err
+	}
+
+	// the rollback SQLs are generated after the status of task is updated, since reading binlog
+	// may take a long time.
+	a.genRollbackSQLByBinlog()
+	if autoRollbackCause != "" {
+		a.autoRollback(autoRollbackCause)
+	}
+	return nil
+}
+
+// getWorkflowTemplate returns nil if the workflow template of the project is not found.
+func (a *action) getWorkflowTemplate() *model.WorkflowTemplate {
+	if a.task.Instance == nil {
+		return nil
+	}
+	template, exist, err := model.GetStorage().GetWorkflowTemplateByProjectId(model.ProjectUID(a.task.Instance.ProjectId))
+	if err != nil {
+		a.entry.Errorf("get workflow template error: %v", err)
+		return nil
+	}
+	if !exist {
+		return nil
+	}
+	return template
+}
+
+// getExecutionDeadline returns the zero time if the execution of task has no deadline.
+func (a *action) getExecutionDeadline(execStartAt time.Time) time.Time {
+	template := a.getWorkflowTemplate()
+	if template == nil || template.TaskExecutionTimeoutSeconds == 0 {
+		return time.Time{}
+	}
+	return execStartAt.Add(time.Duration(template.TaskExecutionTimeoutSeconds) * time.Second)
+}
+
+// exceedsExecutionDeadline updates the task status to terminating if the deadline is exceeded,
+// the execution is terminated as it is terminated by user then.
+func (a *action) exceedsExecutionDeadline(st *model.Storage, deadline time.Time) bool {
+	if deadline.IsZero() || time.Now().Before(deadline) {
+		return false
+	}
+	a.entry.Warnf("the execution exceeds the deadline %v, terminate it", deadline.Format("2006-01-02 15:04:05"))
+	if err := st.UpdateTask(a.task, map[string]interface{}{"status": model.TaskStatusTerminating}); err != nil {
+		a.entry.Errorf("update task status to terminating error: %v", err)
+	}
+	return true
 }
 
 func (a *action) GetTaskStatus(st *model.Storage) string {
@@ -458,11 +534,20 @@ func (a *action) execTask() (err error) {
 
 	task := a.task
 
+	// the SQLs have been executed or skipped are not executed again when the task is resumed.
+	executeSQLs := make([]*model.ExecuteSQL, 0, len(task.ExecuteSQLs))
+	for _, executeSQL := range task.ExecuteSQLs {
+		if executeSQL.ExecStatus == model.SQLExecuteStatusSucceeded || executeSQL.ExecStatus == model.SQLExecuteStatusSkipped {
+			continue
+		}
+		executeSQLs = append(executeSQLs, executeSQL)
+	}
+
 	// txSQLs keep adjacent DMLs, execute in one transaction.
 	var txSQLs []*model.ExecuteSQL
 
-	for i := range task.ExecuteSQLs {
-		executeSQL := task.ExecuteSQLs[i]
+	for i := range executeSQLs {
+		executeSQL := executeSQLs[i]
 		var nodes []driverV2.Node
 		if nodes, err = a.plugin.Parse(context.TODO(), executeSQL.Content); err != nil {
 			return err
@@ -470,8 +555,20 @@ func (a *action) execTask() (err error) {
 
 		switch nodes[0].Type {
 		case driverV2.SQLTypeDML, driverV2.SQLTypeDQL:
+			if chunker, chunkConfig := a.getDMLChunkConfig(executeSQL); chunkConfig != nil {
+				if len(txSQLs) > 0 {
+					if err = a.execSQLs(txSQLs); err != nil {
+						return err
+					}
+					txSQLs = nil
+				}
+				if err = a.execSQLInChunks(executeSQL, chunker, chunkConfig); err != nil {
+					return err
+				}
+				continue
+			}
 			txSQLs = append(txSQLs, executeSQL)
-			if i == len(task.ExecuteSQLs)-1 {
+			if i == len(executeSQLs)-1 {
 				if err = a.execSQLs(txSQLs); err != nil {
 					return err
 				}
@@ -495,13 +592,31 @@ func (a *action) execTask() (err error) {
 
 // execSQL execute SQL and update SQL's executed status to storage.
 func (a *action) execSQL(executeSQL *model.ExecuteSQL) error {
+	if err := a.guardReplicaLag(executeSQL); err != nil {
+		return err
+	}
+	if err := a.checkMetadataLocks(executeSQL); err != nil {
+		return err
+	}
 	st := model.GetStorage()
 
 	if err := st.UpdateExecuteSqlStatus(&executeSQL.BaseSQL, model.SQLExecuteStatusDoing, ""); err != nil {
 		return err
 	}
 
+	binlogRollbacker, useBinlog := a.binlogRollbacker()
+	var startBinlogPos *driver.BinlogPosition
+	if useBinlog {
+		startBinlogPos = a.getBinlogPosition(binlogRollbacker)
+	}
+	stopWatch := a.watchExecuteSQL(executeSQL)
 	_, execErr := a.plugin.Exec(context.TODO(), executeSQL.Content)
+	stopWatch()
+	if startBinlogPos != nil {
+		if endBinlogPos := a.getBinlogPosition(binlogRollbacker); endBinlogPos != nil {
+			setBinlogPosition(&executeSQL.BaseSQL, startBinlogPos, endBinlogPos)
+		}
+	}
 	if execErr != nil {
 		executeSQL.ExecStatus = model.SQLExecuteStatusFailed
 		executeSQL.ExecResult = execErr.Error()
@@ -523,6 +638,9 @@ func (a *action) execSQL(executeSQL *model.ExecuteSQL) error {
 
 // execSQLs execute SQLs and update SQLs' executed status to storage.
 func (a *action) execSQLs(executeSQLs []*model.ExecuteSQL) error {
+	if err := a.guardReplicaLag(executeSQLs...); err != nil {
+		return err
+	}
 	st := model.GetStorage()
 
 	for _, executeSQL := range executeSQLs {
@@ -537,8 +655,19 @@ func (a *action) execSQLs(executeSQLs []*model.ExecuteSQL) error {
 		qs = append(qs, executeSQL.Content)
 	}
 
+	binlogRollbacker, useBinlog := a.binlogRollbacker()
+	var startBinlogPos, endBinlogPos *driver.BinlogPosition
+	if useBinlog {
+		startBinlogPos = a.getBinlogPosition(binlogRollbacker)
+	}
 	results, txErr := a.plugin.Tx(context.TODO(), qs...)
+	if startBinlogPos != nil {
+		endBinlogPos = a.getBinlogPosition(binlogRollbacker)
+	}
 	for idx, executeSQL := range executeSQLs {
+		if endBinlogPos != nil {
+			setBinlogPosition(&executeSQL.BaseSQL, startBinlogPos, endBinlogPos)
+		}
 		if txErr != nil {
 			executeSQL.ExecStatus = model.SQLExecuteStatusFailed
 			executeSQL.ExecResult = txErr.Error()
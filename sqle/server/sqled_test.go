@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/actiontech/sqle/sqle/driver"
 	_ "github.com/actiontech/sqle/sqle/driver/mysql"
@@ -17,6 +18,7 @@ import (
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/agiledragon/gomonkey"
+	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,6 +83,10 @@ func (d *mockDriver) Audit(ctx context.Context, sqls []string) ([]*driverV2.Audi
 	return nil, nil
 }
 
+func (d *mockDriver) AuditStream(ctx context.Context, source driver.AuditStreamSource, handler driver.AuditStreamHandler) error {
+	return driver.AuditInBatches(ctx, d.Audit, source, handler)
+}
+
 func (d *mockDriver) GenRollbackSQL(ctx context.Context, sql string) (string, string, error) {
 	return "", "", nil
 }
@@ -93,6 +99,10 @@ func (d *mockDriver) GetTableMetaBySQL(ctx context.Context, conf *driver.GetTabl
 	return nil, nil
 }
 
+func (d *mockDriver) ExtractTableFromSQL(ctx context.Context, sql string) ([]*driverV2.Table, error) {
+	return nil, nil
+}
+
 func (d *mockDriver) Query(ctx context.Context, sql string, conf *driverV2.QueryConf) (*driverV2.QueryResult, error) {
 	return nil, nil
 }
@@ -101,11 +111,84 @@ func (d *mockDriver) EstimateSQLAffectRows(ctx context.Context, sql string) (*dr
 	return nil, nil
 }
 
+// guardDriver is the plugin of the checks before the SQLs are executed.
+type guardDriver struct {
+	mockDriver
+	lagConfig *driver.ReplicaLagGuardConfig
+	lags      []int64
+	mdlConfig *driver.MetadataLockCheckConfig
+	blockers  [][]*driver.MetadataLockBlocker
+}
+
+func (d *guardDriver) GetReplicaLagGuardConfig(ctx context.Context) (*driver.ReplicaLagGuardConfig, error) {
+	return d.lagConfig, nil
+}
+
+func (d *guardDriver) GetReplicaLag(ctx context.Context) (int64, bool, error) {
+	lag := d.lags[0]
+	if len(d.lags) > 1 {
+		d.lags = d.lags[1:]
+	}
+	return lag, true, nil
+}
+
+func (d *guardDriver) GetMetadataLockCheckConfig(ctx context.Context) (*driver.MetadataLockCheckConfig, error) {
+	return d.mdlConfig, nil
+}
+
+func (d *guardDriver) GetMetadataLockBlockers(ctx context.Context, sql string) ([]*driver.MetadataLockBlocker, error) {
+	blockers := d.blockers[0]
+	if len(d.blockers) > 1 {
+		d.blockers = d.blockers[1:]
+	}
+	return blockers, nil
+}
+
+// expectSaveExecuteSQL expects the execute SQL is saved, the args of the columns
+// in values are matched and the others are ignored.
+func expectSaveExecuteSQL(mock sqlmock.Sqlmock, values map[string]interface{}) {
+	args := []_driver.Value{}
+	matched := 0
+	for _, field := range (&gorm.Scope{Value: &model.ExecuteSQL{}}).GetModelStruct().StructFields {
+		// the same columns as gorm saves, the created_at of the SQL is blank in the tests.
+		if !field.IsNormal || field.IsPrimaryKey || field.Name == "CreatedAt" {
+			continue
+		}
+		if value, ok := values[field.DBName]; ok {
+			args = append(args, value)
+			matched++
+		} else {
+			args = append(args, sqlmock.AnyArg())
+		}
+	}
+	if matched != len(values) {
+		panic(fmt.Sprintf("unknown columns of execute SQL in %v", values))
+	}
+	// the id in the where clause
+	args = append(args, sqlmock.AnyArg())
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `execute_sql_detail` SET")).
+		WithArgs(args...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+}
+
+func getGuardAction(plugin *guardDriver, sqls ...string) *action {
+	act := getAction(sqls, ActionTypeExecute, plugin)
+	for i, executeSQL := range act.task.ExecuteSQLs {
+		executeSQL.ID = uint(i + 1)
+		executeSQL.ExecStatus = model.SQLExecuteStatusInitialized
+		executeSQL.AuditStatus = model.SQLAuditStatusFinished
+	}
+	return act
+}
+
 func TestAction_validation(t *testing.T) {
 	actions := map[int]*action{
 		ActionTypeAudit:    {typ: ActionTypeAudit},
 		ActionTypeExecute:  {typ: ActionTypeExecute},
 		ActionTypeRollback: {typ: ActionTypeRollback},
+		ActionTypeResume:   {typ: ActionTypeResume},
 	}
 
 	auditingTask := &model.Task{
@@ -151,6 +234,11 @@ func TestAction_validation(t *testing.T) {
 		{BaseSQL: model.BaseSQL{ExecStatus: model.SQLExecuteStatusInitialized}, AuditStatus: model.SQLAuditStatusFinished},
 	}}
 	assert.EqualError(t, actions[ActionTypeRollback].validation(noExecutedTask), ErrActionRollbackOnNonExecutedTask.Error())
+
+	executedFailTask.Status = model.TaskStatusExecuteFailed
+	assert.Nil(t, actions[ActionTypeResume].validation(executedFailTask))
+	executedFailTask.Status = model.TaskStatusExecuteSucceeded
+	assert.EqualError(t, actions[ActionTypeResume].validation(executedFailTask), ErrActionResumeOnNonFailedTask.Error())
 }
 
 func Test_action_audit_UpdateTask(t *testing.T) {
@@ -163,16 +251,19 @@ func Test_action_audit_UpdateTask(t *testing.T) {
 		MatchType: model.SQLWhitelistExactMatch,
 	}
 	act := getAction([]string{"select * from t1"}, ActionTypeAudit, &mockDriver{})
+	act.task.ExecuteSQLs[0].ID = 1
 
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `sql_whitelist` WHERE `sql_whitelist`.`deleted_at` IS NULL AND ((sql_whitelist.project_id = ?))")).
 		WithArgs("").
 		WillReturnRows(sqlmock.NewRows([]string{"value", "match_type"}).AddRow(whitelist.Value, whitelist.MatchType))
 
-	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `execute_sql_detail`")).
-		WithArgs(model.MockTime, model.MockTime, nil, 0, 0, act.task.ExecuteSQLs[0].Content, "", "", 0, "", 0, 0, "", "", "", 0, "", model.SQLAuditStatusFinished, `[{"level":"normal","message":"白名单","rule_name":""}]`, "2882fdbb7d5bcda7b49ea0803493467e", "normal").
-		WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectCommit()
+	// the audited SQLs are saved once during audit.
+	expectSaveExecuteSQL(mock, map[string]interface{}{
+		"audit_status":      model.SQLAuditStatusFinished,
+		"audit_results":     `[{"level":"normal","message":"白名单","rule_name":""}]`,
+		"audit_fingerprint": "2882fdbb7d5bcda7b49ea0803493467e",
+		"audit_level":       "normal",
+	})
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE `tasks`")).
@@ -343,3 +434,21 @@ func TestScoreTask(t *testing.T) {
 
 	assert.Equal(t, int32(45), score)
 }
+
+func TestExceedsExecutionDeadline(t *testing.T) {
+	var updatedStatus string
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(&model.Storage{}), "UpdateTask", func(_ *model.Storage, _ *model.Task, attr ...interface{}) error {
+		updatedStatus = attr[0].(map[string]interface{})["status"].(string)
+		return nil
+	})
+	defer patches.Reset()
+	st := model.GetStorage()
+
+	act := getAction([]string{"update t1 set a=1"}, ActionTypeExecute, &mockDriver{})
+	assert.False(t, act.exceedsExecutionDeadline(st, time.Time{}))
+	assert.False(t, act.exceedsExecutionDeadline(st, time.Now().Add(time.Minute)))
+	assert.Equal(t, "", updatedStatus)
+
+	assert.True(t, act.exceedsExecutionDeadline(st, time.Now().Add(-time.Second)))
+	assert.Equal(t, model.TaskStatusTerminating, updatedStatus)
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/actiontech/sqle/sqle/errors"
+	"github.com/actiontech/sqle/sqle/model"
+)
+
+// taskExecution is the result of the task executed by ExecuteWorkflow, done is closed after
+// the task finishes.
+type taskExecution struct {
+	done      chan struct{}
+	succeeded bool
+}
+
+func newTaskExecutions(needExecTaskIdToUserId map[uint]string) map[uint]*taskExecution {
+	executions := make(map[uint]*taskExecution, len(needExecTaskIdToUserId))
+	for taskId := range needExecTaskIdToUserId {
+		executions[taskId] = &taskExecution{done: make(chan struct{})}
+	}
+	return executions
+}
+
+// checkUpstreamTasks checks that the upstream tasks of the tasks to execute are executed together
+// or have succeeded.
+func checkUpstreamTasks(workflow *model.Workflow, needExecTaskIdToUserId map[uint]string) error {
+	taskStatus := map[uint]string{}
+	for _, inst := range workflow.Record.InstanceRecords {
+		if inst.Task != nil {
+			taskStatus[inst.TaskId] = inst.Task.Status
+		}
+	}
+	for taskId, upstreamIds := range workflow.Record.GetTaskDependencies() {
+		if _, ok := needExecTaskIdToUserId[taskId]; !ok {
+			continue
+		}
+		for _, upstreamId := range upstreamIds {
+			if _, ok := needExecTaskIdToUserId[upstreamId]; ok {
+				continue
+			}
+			if taskStatus[upstreamId] != model.TaskStatusExecuteSucceeded {
+				return errors.New(errors.DataInvalid,
+					fmt.Errorf("task %d depends on task %d which has not been executed successfully", taskId, upstreamId))
+			}
+		}
+	}
+	return nil
+}
+
+// waitForUpstreamTasks returns the upstream task which does not succeed, it is 0 if all upstream
+// tasks succeed. The upstream tasks not executed together have succeeded, see checkUpstreamTasks.
+func waitForUpstreamTasks(executions map[uint]*taskExecution, upstreamIds []uint) (failedTaskId uint) {
+	for _, upstreamId := range upstreamIds {
+		execution, ok := executions[upstreamId]
+		if !ok {
+			continue
+		}
+		<-execution.done
+		if !execution.succeeded {
+			return upstreamId
+		}
+	}
+	return 0
+}
+
+// skipTask marks the task as failed without executing it, since its upstream task fails.
+func skipTask(s *model.Storage, taskId, failedUpstreamId uint) error {
+	reason := fmt.Sprintf("上游任务 %d 上线失败，跳过执行", failedUpstreamId)
+	if err := s.SkipExecuteSQLsByTaskId(taskId, reason); err != nil {
+		return err
+	}
+	return s.UpdateTaskStatusByIDs([]uint{taskId}, map[string]interface{}{"status": model.TaskStatusExecuteFailed})
+}
+
+// isTaskWaitingForUpstream returns true if the execution of task has been triggered but it is
+// not executed yet, since it is waiting for its upstream tasks.
+func isTaskWaitingForUpstream(workflow *model.Workflow, task *model.Task) bool {
+	if task.Status != model.TaskStatusAudited {
+		return false
+	}
+	for _, inst := range workflow.Record.InstanceRecords {
+		if inst.TaskId == task.ID {
+			return inst.IsSQLExecuted && inst.DependsOnTaskIds != ""
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/actiontech/sqle/sqle/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUpstreamTasks(t *testing.T) {
+	workflow := &model.Workflow{
+		Record: &model.WorkflowRecord{
+			InstanceRecords: []*model.WorkflowInstanceRecord{
+				{TaskId: 1, Task: &model.Task{Status: model.TaskStatusExecuteSucceeded}},
+				{TaskId: 2, Task: &model.Task{Status: model.TaskStatusAudited}},
+				{TaskId: 3, Task: &model.Task{Status: model.TaskStatusAudited}, DependsOnTaskIds: "1,2"},
+			},
+		},
+	}
+	// the upstream task is executed together
+	assert.NoError(t, checkUpstreamTasks(workflow, map[uint]string{2: "1", 3: "1"}))
+	// the upstream task 2 is not executed
+	assert.Error(t, checkUpstreamTasks(workflow, map[uint]string{3: "1"}))
+	// the task without upstream tasks
+	assert.NoError(t, checkUpstreamTasks(workflow, map[uint]string{2: "1"}))
+}
+
+func TestWaitForUpstreamTasks(t *testing.T) {
+	executions := newTaskExecutions(map[uint]string{1: "1", 2: "1", 3: "1"})
+	go func() {
+		executions[1].succeeded = true
+		close(executions[1].done)
+		close(executions[2].done)
+	}()
+	assert.Equal(t, uint(0), waitForUpstreamTasks(executions, []uint{1}))
+	assert.Equal(t, uint(2), waitForUpstreamTasks(executions, []uint{1, 2}))
+	// the upstream task not executed together has succeeded
+	assert.Equal(t, uint(0), waitForUpstreamTasks(executions, []uint{4}))
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/actiontech/sqle/sqle/common"
+	"github.com/actiontech/sqle/sqle/dms"
+	"github.com/actiontech/sqle/sqle/driver"
+	driverV2 "github.com/actiontech/sqle/sqle/driver/v2"
+	"github.com/actiontech/sqle/sqle/errors"
+	"github.com/actiontech/sqle/sqle/log"
+	"github.com/actiontech/sqle/sqle/model"
+	"github.com/actiontech/sqle/sqle/notification"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ResumeWorkflowTask executes the failed task of workflow again from the SQL which the execution
+// stops at, the failed SQL is retried, edited or skipped by the operation, and the SQLs executed
+// successfully are not executed again. The caller checks that the user is allowed to execute the
+// workflow, the edited SQL is audited again instead of being approved again.
+func ResumeWorkflowTask(workflow *model.Workflow, taskId uint, userId, operation, content, reason string) error {
+	if !workflow.CanResumeExecution() {
+		return errors.New(errors.DataInvalid,
+			fmt.Errorf("workflow status is %s, only the failed workflow can be resumed", workflow.Record.Status))
+	}
+
+	s := model.GetStorage()
+	task, exist, err := s.GetTaskDetailById(strconv.Itoa(int(taskId)))
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.New(errors.DataNotExist, fmt.Errorf("task is not exist. taskID=%v", taskId))
+	}
+	if task.Status != model.TaskStatusExecuteFailed {
+		return errors.New(errors.DataInvalid, fmt.Errorf("task status is %s, only the failed task can be resumed", task.Status))
+	}
+	failedSQL := task.GetFailedExecuteSQL()
+	if failedSQL == nil {
+		return errors.New(errors.DataInvalid, fmt.Errorf("there is no failed SQL in task %d", taskId))
+	}
+
+	if failedSQL.ExecStatus == model.SQLExecuteStatusTerminateFailed && operation != model.TaskResumeOperationSkip {
+		return errors.New(errors.DataInvalid,
+			fmt.Errorf("SQL %d failed to be terminated and may be still running, it can only be skipped", failedSQL.Number))
+	}
+	if task.AutoRollbackStatus != "" {
+		return errors.New(errors.DataInvalid,
+			fmt.Errorf("task %d has been rolled back automatically, it can not be resumed", taskId))
+	}
+	if operation == model.TaskResumeOperationEdit {
+		chunks, err := s.GetExecuteSQLChunksByExecuteSQLId(failedSQL.ID)
+		if err != nil {
+			return err
+		}
+		for _, chunk := range chunks {
+			if chunk.ExecStatus == model.SQLExecuteStatusSucceeded {
+				return errors.New(errors.DataInvalid,
+					fmt.Errorf("some chunks of SQL %d have been committed, retry it to resume from the last committed chunk", failedSQL.Number))
+			}
+		}
+	}
+
+	instance, exist, err := dms.GetInstancesById(context.Background(), task.InstanceId)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.New(errors.DataNotExist, fmt.Errorf("instance is not exist. instanceId=%v", task.InstanceId))
+	}
+	if err = common.CheckInstanceIsConnectable(instance); err != nil {
+		return errors.New(errors.ConnectRemoteDatabaseError, err)
+	}
+
+	l := log.NewEntry().WithField("task_id", taskId)
+	var editedSQL *model.ExecuteSQL
+	var editedRollbackSQL *model.RollbackSQL
+	if operation == model.TaskResumeOperationEdit {
+		editedSQL, err = auditEditedSQL(l, task, instance, failedSQL, content, userId)
+		if err != nil {
+			return err
+		}
+		editedRollbackSQL, err = genEditedRollbackSQL(l, task, instance, editedSQL)
+		if err != nil {
+			return err
+		}
+	}
+
+	record := &model.TaskResumeRecord{
+		TaskId:           task.ID,
+		ExecuteSQLId:     failedSQL.ID,
+		ExecuteSQLNumber: failedSQL.Number,
+		Operation:        operation,
+		Reason:           reason,
+		OperationUserId:  userId,
+	}
+	if operation == model.TaskResumeOperationEdit {
+		record.OriginalSQL = failedSQL.Content
+	}
+	resumed, err := s.ResumeTask(workflow, failedSQL, record, editedSQL, editedRollbackSQL)
+	if err != nil {
+		return err
+	}
+	if !resumed {
+		return errors.New(errors.DataInvalid, fmt.Errorf("task %d has been resumed by others", taskId))
+	}
+
+	l.Infof("resume task from SQL %d, the failed SQL is %s", failedSQL.Number, operation)
+	go func() {
+		task, err := GetSqled().AddTaskWaitResult(strconv.Itoa(int(taskId)), ActionTypeResume)
+		if err != nil {
+			l.Errorf("resume task error: %v", err)
+		}
+
+		updateStatus(s, workflow, l)
+
+		if err != nil || task.Status == model.TaskStatusExecuteFailed {
+			go notification.NotifyWorkflow(string(workflow.ProjectId), workflow.WorkflowId, notification.WorkflowNotifyTypeExecuteFail)
+		} else {
+			go notification.NotifyWorkflow(string(workflow.ProjectId), workflow.WorkflowId, notification.WorkflowNotifyTypeExecuteSuccess)
+		}
+	}()
+	return nil
+}
+
+// auditEditedSQL audits the SQL which replaces the failed SQL, the SQL must be a single statement
+// and its audit level must be allowed by the workflow template, like the SQLs of a new workflow.
+func auditEditedSQL(l *logrus.Entry, task *model.Task, instance *model.Instance, failedSQL *model.ExecuteSQL,
+	content, userId string) (*model.ExecuteSQL, error) {
+	s := model.GetStorage()
+	rules, customRules, err := s.GetAllRulesByTmpNameAndProjectIdInstanceDBType("", "", instance, task.DBType)
+	if err != nil {
+		return nil, err
+	}
+	plugin, err := newDriverManagerWithAudit(l, instance, task.Schema, task.DBType, rules)
+	if err != nil {
+		return nil, err
+	}
+	defer plugin.Close(context.TODO())
+
+	nodes, err := plugin.Parse(context.TODO(), content)
+	if err != nil {
+		return nil, errors.New(errors.DataInvalid, err)
+	}
+	if len(nodes) != 1 {
+		return nil, errors.New(errors.DataInvalid,
+			fmt.Errorf("the edited SQL must be a single statement, but there are %d statements", len(nodes)))
+	}
+
+	editedSQL := &model.ExecuteSQL{
+		BaseSQL: model.BaseSQL{
+			Number:  failedSQL.Number,
+			Content: nodes[0].Text,
+		},
+	}
+	auditTask := &model.Task{
+		Instance:    instance,
+		Schema:      task.Schema,
+		DBType:      task.DBType,
+		ExecuteSQLs: []*model.ExecuteSQL{editedSQL},
+	}
+	auditTask.CreateUserId, _ = strconv.ParseUint(userId, 10, 64)
+	if err = audit(l, auditTask, plugin, customRules); err != nil {
+		return nil, err
+	}
+
+	template, exist, err := s.GetWorkflowTemplateByProjectId(model.ProjectUID(instance.ProjectId))
+	if err != nil {
+		return nil, err
+	}
+	allowLevel := driverV2.RuleLevelError
+	if exist && template.AllowSubmitWhenLessAuditLevel != "" {
+		allowLevel = driverV2.RuleLevel(template.AllowSubmitWhenLessAuditLevel)
+	}
+	if driverV2.RuleLevel(editedSQL.AuditLevel).More(allowLevel) {
+		return nil, errors.New(errors.DataInvalid,
+			fmt.Errorf("the audit level of edited SQL is %s, which is higher than the allowable submission level(%v)", editedSQL.AuditLevel, allowLevel))
+	}
+	return editedSQL, nil
+}
+
+// genEditedRollbackSQL generates the rollback SQL of the edited SQL, which replaces the rollback
+// SQL of the failed SQL. The rollback SQL is empty if the plugin can not generate it.
+func genEditedRollbackSQL(l *logrus.Entry, task *model.Task, instance *model.Instance, editedSQL *model.ExecuteSQL) (
+	*model.RollbackSQL, error) {
+	rollbackSQL := &model.RollbackSQL{BaseSQL: model.BaseSQL{Description: "SQL 在恢复上线时被修改，无法生成新的回滚语句"}}
+	if !driver.GetPluginManager().IsOptionalModuleEnabled(task.DBType, driverV2.OptionalModuleGenRollbackSQL) {
+		return rollbackSQL, nil
+	}
+	p, err := newDriverManagerWithAudit(l, instance, task.Schema, task.DBType, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close(context.TODO())
+
+	rollbackSQLs, err := genRollbackSQL(l, &model.Task{ExecuteSQLs: []*model.ExecuteSQL{editedSQL}}, p)
+	if err != nil {
+		return nil, err
+	}
+	if len(rollbackSQLs) == 0 {
+		return rollbackSQL, nil
+	}
+	return rollbackSQLs[0], nil
+}
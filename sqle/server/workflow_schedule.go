@@ -107,6 +107,10 @@ func ExecuteWorkflow(workflow *model.Workflow, needExecTaskIdToUserId map[uint]s
 		}
 	}
 
+	if err := checkUpstreamTasks(workflow, needExecTaskIdToUserId); err != nil {
+		return err
+	}
+
 	currentStep := workflow.CurrentStep()
 	if currentStep == nil {
 		return fmt.Errorf("workflow current step not found")
@@ -146,11 +150,29 @@ func ExecuteWorkflow(workflow *model.Workflow, needExecTaskIdToUserId map[uint]s
 
 	l := log.NewEntry()
 	var lock sync.Mutex
+	dependencies := workflow.Record.GetTaskDependencies()
+	executions := newTaskExecutions(needExecTaskIdToUserId)
 	for taskId := range needExecTaskIdToUserId {
 		id := taskId
 		go func() {
+			execution := executions[id]
+			defer close(execution.done)
+
+			// the task is executed after its upstream tasks succeed, and is skipped if any of them fails.
+			if failedTaskId := waitForUpstreamTasks(executions, dependencies[id]); failedTaskId != 0 {
+				l.Warnf("skip task %d since its upstream task %d failed", id, failedTaskId)
+				if err := skipTask(s, id, failedTaskId); err != nil {
+					l.Errorf("skip task %d error: %v", id, err)
+				}
+				lock.Lock()
+				updateStatus(s, workflow, l)
+				lock.Unlock()
+				return
+			}
+
 			sqledServer := GetSqled()
 			task, err := sqledServer.AddTaskWaitResult(strconv.Itoa(int(id)), ActionTypeExecute)
+			execution.succeeded = err == nil && task.Status == model.TaskStatusExecuteSucceeded
 
 			{ // NOTE: Update the workflow status before sending notifications to ensure that the notification content reflects the latest information.
 				lock.Lock()
@@ -189,7 +211,10 @@ func updateStatus(s *model.Storage, workflow *model.Workflow, l *logrus.Entry) {
 		if task.Status == model.TaskStatusExecuteFailed {
 			hasExecuteFailed = true
 		}
-		if task.Status == model.TaskStatusAudited {
+		// the task waiting for its upstream tasks is considered as executing
+		if isTaskWaitingForUpstream(workflow, task) {
+			hasExecuting = true
+		} else if task.Status == model.TaskStatusAudited {
 			hasWaitExecute = true
 		}
 
@@ -12,6 +12,10 @@ const (
 type Config struct {
 	attempts uint
 	delay    time.Duration
+	// factor and maxDelay are used to increase the delay after each attempt,
+	// the delay is fixed if factor is not greater than 1.
+	factor   uint
+	maxDelay time.Duration
 }
 
 type Option func(*Config)
@@ -30,6 +34,26 @@ func Delay(delay time.Duration) Option {
 	}
 }
 
+// Option: Backoff, the delay is multiplied by factor after each attempt and is
+// limited by maxDelay, there is no limit if maxDelay is 0.
+func Backoff(factor uint, maxDelay time.Duration) Option {
+	return func(c *Config) {
+		c.factor = factor
+		c.maxDelay = maxDelay
+	}
+}
+
+func (c *Config) nextDelay(delay time.Duration) time.Duration {
+	if c.factor <= 1 {
+		return delay
+	}
+	delay *= time.Duration(c.factor)
+	if c.maxDelay > 0 && delay > c.maxDelay {
+		delay = c.maxDelay
+	}
+	return delay
+}
+
 func NewDefaultRetryConfig() *Config {
 	return &Config{
 		attempts: defaultAttempts,
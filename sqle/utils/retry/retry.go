@@ -32,6 +32,7 @@ func Do(retryableFunc RetryableFunc, doneChan chan struct{}, ops ...Option) erro
 
 	var idx uint = 0
 	errList := errListType{}
+	delay := cfg.delay
 
 	// cfg.attempts can not be 0.
 	for idx < cfg.attempts {
@@ -43,7 +44,8 @@ func Do(retryableFunc RetryableFunc, doneChan chan struct{}, ops ...Option) erro
 
 		idx++
 		errList = errList.AppendError(err.Error())
-		time.Sleep(cfg.delay)
+		time.Sleep(delay)
+		delay = cfg.nextDelay(delay)
 	}
 
 	if len(errList) == 0 {
@@ -87,3 +87,16 @@ func RetryDo(t *testing.T, expectedRetryCount int, isFuncResErr bool) (doneErr e
 		}
 	}
 }
+
+func TestConfigNextDelay(t *testing.T) {
+	cfg := NewDefaultRetryConfig()
+	assert.Equal(t, time.Second, cfg.nextDelay(time.Second))
+
+	Backoff(2, 5*time.Second)(cfg)
+	assert.Equal(t, 2*time.Second, cfg.nextDelay(time.Second))
+	assert.Equal(t, 4*time.Second, cfg.nextDelay(2*time.Second))
+	assert.Equal(t, 5*time.Second, cfg.nextDelay(4*time.Second))
+
+	Backoff(2, 0)(cfg)
+	assert.Equal(t, 8*time.Second, cfg.nextDelay(4*time.Second))
+}